@@ -6,15 +6,21 @@
 package baseController
 
 import (
+	"encoding/json"
+	"net/http"
 	"reflect"
 	"runtime"
+	"time"
 
 	"fmt"
 
 	"github.com/astaxie/beego"
 	"github.com/astaxie/beego/validation"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n"
 	"github.com/goinggo/beego-mgo/localize"
+	"github.com/goinggo/beego-mgo/localize/format"
 	"github.com/goinggo/beego-mgo/services"
+	"github.com/goinggo/beego-mgo/utilities/httpcache"
 	"github.com/goinggo/beego-mgo/utilities/mongo"
 	log "github.com/goinggo/tracelog"
 )
@@ -26,6 +32,9 @@ type (
 	BaseController struct {
 		beego.Controller
 		services.Service
+		Locale string
+		T      i18n.TranslateFunc
+		Format *format.Formatter
 	}
 )
 
@@ -41,6 +50,8 @@ func (baseController *BaseController) Prepare() {
 		baseController.UserID = "Unknown"
 	}
 
+	baseController.prepareLocale()
+
 	if err := baseController.Service.Prepare(); err != nil {
 		log.Errorf(err, baseController.UserID, "BaseController.Prepare", baseController.Ctx.Request.URL.Path)
 		baseController.ServeError(err)
@@ -62,6 +73,40 @@ func (baseController *BaseController) Finish() {
 	log.Completedf(baseController.UserID, "Finish", baseController.Ctx.Request.URL.Path)
 }
 
+// prepareLocale selects the locale and wind speed unit of the request from the
+// lang and units parameters or cookies and the Accept-Language header.
+func (baseController *BaseController) prepareLocale() {
+	candidates := []string{baseController.GetString("lang"), baseController.Ctx.GetCookie("lang")}
+	candidates = append(candidates, localize.AcceptLanguage(baseController.Ctx.Input.Header("Accept-Language"))...)
+	baseController.Locale = localize.RequestLocale(candidates...)
+
+	var err error
+	if baseController.T, err = localize.NewTranslation(baseController.Locale, localize.DefaultLocale); err != nil {
+		log.Warning(baseController.UserID, "BaseController.prepareLocale", "Locale[%s] : %v", baseController.Locale, err)
+	}
+
+	units := baseController.GetString("units")
+	if units == "" {
+		units = baseController.Ctx.GetCookie("units")
+	}
+	unit, _ := format.ParseUnit(units)
+	baseController.Format = format.New(baseController.Locale, unit, baseController.T)
+
+	// Views call the HTML translate function, which escapes translations
+	// unless they are marked as markup
+	htmlT, err := localize.NewHTMLTranslation(baseController.Locale, localize.DefaultLocale)
+	if err != nil {
+		log.Warning(baseController.UserID, "BaseController.prepareLocale", "Locale[%s] : %v", baseController.Locale, err)
+	}
+
+	// The layout sets the lang and dir attributes and adds the
+	// right-to-left stylesheets for locales such as ar-AR
+	baseController.Data["Lang"] = baseController.Locale
+	baseController.Data["Dir"] = localize.Direction(baseController.Locale)
+	baseController.Data["T"] = htmlT
+	baseController.Data["Format"] = baseController.Format
+}
+
 //** VALIDATION
 
 // ParseAndValidate will run the params through the validation framework and then
@@ -89,7 +134,7 @@ func (baseController *BaseController) ParseAndValidate(params interface{}) bool
 			// Look for an Error tag in the field
 			typeField := val.Type().Field(i)
 			tag := typeField.Tag
-			tagValue := tag.Get("Error")
+			tagValue := tag.Get("error")
 
 			// Was there an Error tag
 			if tagValue != "" {
@@ -105,7 +150,7 @@ func (baseController *BaseController) ParseAndValidate(params interface{}) bool
 			message, ok := messages2[err.Field]
 			if ok == true {
 				// Use a localized message if one exists
-				errors = append(errors, localize.T(message))
+				errors = append(errors, baseController.T(message))
 				continue
 			}
 
@@ -154,6 +199,39 @@ func (baseController *BaseController) CatchPanic(functionName string) {
 	}
 }
 
+//** HTTP CACHING
+
+// ServeCachedJson serves the json data with the Cache-Control header of the policy, an ETag
+// of the content and the last modified time if it is not zero. It serves 304 Not Modified
+// without a body when the conditional headers of the request show the client has the content.
+func (baseController *BaseController) ServeCachedJson(policy httpcache.Policy, lastModified time.Time) {
+	content, err := json.Marshal(baseController.Data["json"])
+	if err != nil {
+		baseController.ServeError(err)
+		return
+	}
+
+	etag := httpcache.ETag(content)
+	output := baseController.Ctx.Output
+	output.Header("Cache-Control", policy.String())
+	output.Header("ETag", etag)
+	if lastModified.IsZero() == false {
+		output.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	// The content depends on the locale and units of the request.
+	output.Header("Vary", "Accept-Language, Cookie")
+
+	if httpcache.NotModified(baseController.Ctx.Request, etag, lastModified) {
+		output.SetStatus(http.StatusNotModified)
+		output.Body([]byte{})
+		return
+	}
+
+	output.Header("Content-Type", "application/json; charset=utf-8")
+	output.Body(content)
+}
+
 //** AJAX SUPPORT
 
 // AjaxResponse returns a standard ajax response.
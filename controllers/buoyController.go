@@ -6,17 +6,57 @@
 package controllers
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego/validation"
 	bc "github.com/goinggo/beego-mgo/controllers/baseController"
+	"github.com/goinggo/beego-mgo/models/buoyModels"
 	"github.com/goinggo/beego-mgo/services/buoyService"
+	"github.com/goinggo/beego-mgo/utilities/httpcache"
+	"github.com/goinggo/beego-mgo/utilities/websocket"
 	log "github.com/goinggo/tracelog"
 )
 
+//** CONSTANTS
+
+// heartbeatInterval is how often an idle push connection is written to, so proxies keep it open.
+const heartbeatInterval = 15 * time.Second
+
+//** PACKAGE VARIABLES
+
+// stationPolicy makes the dashboards polling a station revalidate it every time,
+// so they get a 304 Not Modified until its condition changes.
+var stationPolicy = httpcache.Revalidate
+
 //** TYPES
 
-// BuoyController manages the API for buoy related functionality.
-type BuoyController struct {
-	bc.BaseController
-}
+type (
+	// BuoyController manages the API for buoy related functionality.
+	BuoyController struct {
+		bc.BaseController
+	}
+
+	// buoyUpdate is a condition update pushed to the browser.
+	buoyUpdate struct {
+		buoyModels.BuoyEvent
+		Display buoyModels.BuoyDisplay `json:"display"`
+	}
+
+	// stationBatchParams are the station ids of RetrieveStations.
+	stationBatchParams struct {
+		StationIDs []string `form:"-" valid:"Required; MaxSize(100)" error:"invalid_station_ids"`
+	}
+
+	// subscribeRequest changes the subscription of a WebSocket connection.
+	subscribeRequest struct {
+		Regions  []string `json:"regions"`
+		Stations []string `json:"stations"`
+	}
+)
 
 //** WEB FUNCTIONS
 
@@ -32,6 +72,7 @@ func (controller *BuoyController) Index() {
 		return
 	}
 
+	controller.Data["Region"] = region
 	controller.Data["Stations"] = buoyStations
 	controller.Layout = "shared/basic-layout.html"
 	controller.TplNames = "buoy/content.html"
@@ -68,8 +109,10 @@ func (controller *BuoyController) RetrieveStation() {
 	controller.AjaxResponse(0, "SUCCESS", view)
 }
 
-// RetrieveStationJSON handles the example 3 tab.
+// RetrieveStationJSON handles the example 3 tab. The response has an ETag and the time
+// the condition was last updated, and is not sent again while it has not changed.
 // http://localhost:9003/buoy/station/42002
+// http://localhost:9003/buoy/station/42002?display=true&lang=fr-FR&units=knots
 func (controller *BuoyController) RetrieveStationJSON() {
 	// The call to ParseForm inside of ParseAndValidate is failing. This is a BAD FIX
 	params := struct {
@@ -87,6 +130,278 @@ func (controller *BuoyController) RetrieveStationJSON() {
 		return
 	}
 
+	var lastModified time.Time
+	if buoyStation.UpdatedAt != nil {
+		lastModified = *buoyStation.UpdatedAt
+	}
+
+	// Add the conditions formatted for the request locale when asked for
+	if controller.GetString("display") == "true" {
+		controller.Data["json"] = struct {
+			*buoyModels.BuoyStation
+			Display buoyModels.BuoyDisplay `json:"display"`
+		}{buoyStation, controller.display(&buoyStation.Condition)}
+		controller.ServeCachedJson(stationPolicy, lastModified)
+		return
+	}
+
 	controller.Data["json"] = buoyStation
+	controller.ServeCachedJson(stationPolicy, lastModified)
+}
+
+// RetrieveStations returns the stations of the stationId parameters, or of the station_ids
+// of a JSON body, and the ids of the stations that do not exist.
+// curl -d stationId=42001 -d stationId=42002 http://localhost:9003/buoy/stations/batch
+// curl -d '{"station_ids": ["42001", "42002"]}' http://localhost:9003/buoy/stations/batch
+func (controller *BuoyController) RetrieveStations() {
+	params := stationBatchParams{controller.GetStrings("stationId")}
+
+	if len(params.StationIDs) == 0 && len(controller.Ctx.Input.RequestBody) > 0 {
+		var body struct {
+			StationIDs []string `json:"station_ids"`
+		}
+		if err := json.Unmarshal(controller.Ctx.Input.RequestBody, &body); err != nil {
+			controller.ServeValidationErrors([]string{controller.T("invalid_station_ids")})
+			return
+		}
+		params.StationIDs = body.StationIDs
+	}
+
+	if controller.ParseAndValidate(&params) == false {
+		return
+	}
+
+	batch, err := buoyService.FindStations(&controller.Service, params.StationIDs)
+	if err != nil {
+		log.CompletedErrorf(err, controller.UserID, "BuoyController.RetrieveStations", "StationIDs%v", params.StationIDs)
+		controller.ServeError(err)
+		return
+	}
+
+	controller.Data["json"] = batch
 	controller.ServeJson()
 }
+
+// ExportRegion streams the stations of the region as a file in the format parameter:
+// csv, geojson or kml. The columns parameter selects the csv columns.
+// http://localhost:9003/buoy/region/Gulf%20Of%20Mexico/export?format=geojson
+// http://localhost:9003/buoy/region/Gulf%20Of%20Mexico/export?format=csv&columns=station_id,name,wind_speed_milehour
+func (controller *BuoyController) ExportRegion() {
+	// The call to ParseForm inside of ParseAndValidate is failing. This is a BAD FIX
+	params := struct {
+		Region string `form:":region" valid:"Required" error:"invalid_region"`
+	}{controller.GetString(":region")}
+
+	if controller.ParseAndValidate(&params) == false {
+		return
+	}
+
+	var columns []string
+	if value := controller.GetString("columns"); value != "" {
+		columns = strings.Split(value, ",")
+	}
+
+	w := controller.Ctx.ResponseWriter
+	exporter, err := buoyService.NewExporter(controller.GetString("format"), w, columns)
+	if err != nil {
+		controller.ServeValidationErrors([]string{err.Error()})
+		return
+	}
+
+	filename := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '"' || r == '/' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, params.Region)
+
+	w.Header().Set("Content-Type", exporter.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.%s\"", filename, exporter.Extension()))
+	w.WriteHeader(http.StatusOK)
+
+	// The stations are written as they are read, so an error can only cut the file short.
+	if err := buoyService.ExportRegion(&controller.Service, params.Region, exporter); err != nil {
+		log.CompletedErrorf(err, controller.UserID, "BuoyController.ExportRegion", "Region[%s]", params.Region)
+	}
+
+	controller.Finish()
+	controller.StopRun()
+}
+
+//** PUSH FUNCTIONS
+
+// Events streams the condition updates of the region and station parameters as Server-Sent Events.
+// http://localhost:9003/buoy/events?region=Gulf+Of+Mexico&station=42002
+func (controller *BuoyController) Events() {
+	regions := controller.GetStrings("region")
+	stationIDs := controller.GetStrings("station")
+	log.Startedf(controller.UserID, "BuoyController.Events", "Regions%v Stations%v", regions, stationIDs)
+
+	w := controller.Ctx.ResponseWriter
+	flusher, ok := w.(http.Flusher)
+	if ok == false {
+		controller.ServeError(fmt.Errorf("Streaming Not Supported"))
+		return
+	}
+
+	// The connection stays open, so it must not hold on to a mongo session.
+	controller.Service.Finish()
+
+	subscription := buoyService.Subscribe(regions, stationIDs)
+	defer subscription.Unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 5000\n\n")
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	done := controller.Ctx.Request.Context().Done()
+	for {
+		select {
+		case event, ok := <-subscription.Events():
+			if ok == false {
+				controller.stopStream("BuoyController.Events")
+				return
+			}
+
+			data, err := json.Marshal(controller.update(event))
+			if err != nil {
+				log.Error(err, controller.UserID, "BuoyController.Events")
+				continue
+			}
+			fmt.Fprintf(w, "event: condition\ndata: %s\n\n", data)
+
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+
+		case <-done:
+			controller.stopStream("BuoyController.Events")
+			return
+		}
+
+		flusher.Flush()
+	}
+}
+
+// Socket pushes the condition updates of the region and station parameters over a WebSocket.
+// The browser changes the subscription by sending {"regions": [...], "stations": [...]}.
+// Pages on other sites are refused unless their origin is in BUOY_ALLOWEDORIGINS.
+// ws://localhost:9003/buoy/socket?region=Gulf+Of+Mexico
+func (controller *BuoyController) Socket() {
+	regions := controller.GetStrings("region")
+	stationIDs := controller.GetStrings("station")
+	log.Startedf(controller.UserID, "BuoyController.Socket", "Regions%v Stations%v", regions, stationIDs)
+
+	conn, err := websocket.Upgrade(controller.Ctx.ResponseWriter, controller.Ctx.Request, buoyService.Config.AllowedOrigins)
+	if err != nil {
+		log.CompletedError(err, controller.UserID, "BuoyController.Socket")
+		controller.StopRun()
+		return
+	}
+	defer conn.Close()
+
+	// The connection stays open, so it must not hold on to a mongo session.
+	controller.Service.Finish()
+
+	// Read the subscription changes until the browser goes away.
+	requests := make(chan subscribeRequest)
+	done := make(chan struct{})
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		defer close(done)
+		for {
+			message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			var request subscribeRequest
+			if err := json.Unmarshal(message, &request); err != nil {
+				log.Warning(controller.UserID, "BuoyController.Socket", "Invalid Subscription %q : %v", message, err)
+				continue
+			}
+
+			select {
+			case requests <- request:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	subscription := buoyService.Subscribe(regions, stationIDs)
+	defer func() {
+		subscription.Unsubscribe()
+	}()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-subscription.Events():
+			if ok == false {
+				controller.stopStream("BuoyController.Socket")
+				return
+			}
+
+			if err := conn.WriteJSON(controller.update(event)); err != nil {
+				controller.stopStream("BuoyController.Socket")
+				return
+			}
+
+		case request := <-requests:
+			subscription.Unsubscribe()
+			subscription = buoyService.Subscribe(request.Regions, request.Stations)
+
+		case <-heartbeat.C:
+			conn.WritePing()
+
+		case <-done:
+			controller.stopStream("BuoyController.Socket")
+			return
+		}
+	}
+}
+
+//** VALIDATION
+
+// Valid implements validation.ValidFormer. Every station id must be valid
+// on its own, as the station id of RetrieveStation.
+func (params *stationBatchParams) Valid(valid *validation.Validation) {
+	for _, stationID := range params.StationIDs {
+		if len(stationID) < 4 {
+			valid.SetError("StationIDs", "Every Station Id Must Have At Least 4 Characters")
+			return
+		}
+	}
+}
+
+//** PRIVATE FUNCTIONS
+
+// update adds the conditions formatted for the request locale to the event.
+func (controller *BuoyController) update(event buoyModels.BuoyEvent) buoyUpdate {
+	return buoyUpdate{event, controller.display(&event.Condition)}
+}
+
+// stopStream completes a push connection. The response has been written,
+// so the request stops without rendering a view.
+func (controller *BuoyController) stopStream(functionName string) {
+	log.Completed(controller.UserID, functionName)
+	controller.StopRun()
+}
+
+// display formats the conditions of a station for the request locale.
+func (controller *BuoyController) display(condition *buoyModels.BuoyCondition) buoyModels.BuoyDisplay {
+	return buoyModels.BuoyDisplay{
+		WindSpeed:     controller.Format.WindSpeed(condition.WindSpeed),
+		WindDirection: controller.Format.WindDirection(condition.WindDirection),
+		WindGust:      controller.Format.WindSpeed(condition.WindGust),
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of controller source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+// Package controllers implements the controller layer for the buoy API.
+package controllers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"strconv"
+
+	bc "github.com/goinggo/beego-mgo/controllers/baseController"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+	"github.com/goinggo/beego-mgo/models/translationModels"
+	"github.com/goinggo/beego-mgo/services/translationService"
+	"github.com/goinggo/beego-mgo/utilities/httpcache"
+	log "github.com/goinggo/tracelog"
+)
+
+//** TYPES
+
+// TranslationController manages the admin API for the runtime editable translations.
+type TranslationController struct {
+	bc.BaseController
+}
+
+//** ADMIN FUNCTIONS
+
+// List returns the translations of all locales or of the locale parameter.
+// http://localhost:9003/admin/translations?locale=fr-FR
+func (controller *TranslationController) List() {
+	if controller.authorize() == false {
+		return
+	}
+
+	localeID := controller.GetString("locale")
+	translations, err := translationService.FindTranslations(&controller.Service, localeID)
+	if err != nil {
+		log.CompletedErrorf(err, controller.UserID, "TranslationController.List", "Locale[%s]", localeID)
+		controller.ServeError(err)
+		return
+	}
+
+	controller.Ctx.Output.Header("Cache-Control", httpcache.NoStore.String())
+	controller.Data["json"] = translations
+	controller.ServeJson()
+}
+
+// Save stores the draft in the request body for a translation. The version parameter is
+// the version that was edited, or 0 for a new translation.
+// http://localhost:9003/admin/translations/fr-FR/invalid_station_id?version=3
+func (controller *TranslationController) Save() {
+	if controller.authorize() == false {
+		return
+	}
+
+	localeID := controller.GetString(":locale")
+	translationID := controller.GetString(":translationId")
+
+	var content translationModels.TranslationContent
+	if err := json.Unmarshal(controller.Ctx.Input.RequestBody, &content); err != nil {
+		controller.ServeValidationErrors([]string{controller.T("invalid_translation")})
+		return
+	}
+
+	if err := translationService.ValidateDraft(localeID, translationID, &content); err != nil {
+		controller.ServeValidationErrors([]string{err.Error()})
+		return
+	}
+
+	version, ok := controller.version()
+	if ok == false {
+		return
+	}
+
+	translation, err := translationService.SaveDraft(&controller.Service, localeID, translationID, content, version)
+	if err != nil {
+		log.CompletedErrorf(err, controller.UserID, "TranslationController.Save", "Locale[%s] TranslationID[%s]", localeID, translationID)
+		controller.serveServiceError(err)
+		return
+	}
+
+	controller.Data["json"] = translation
+	controller.ServeJson()
+}
+
+// Publish publishes the draft of the version parameter and reloads the translations.
+// Other instances reload them once they notice the new revision.
+// http://localhost:9003/admin/translations/fr-FR/invalid_station_id/publish?version=4
+func (controller *TranslationController) Publish() {
+	if controller.authorize() == false {
+		return
+	}
+
+	localeID := controller.GetString(":locale")
+	translationID := controller.GetString(":translationId")
+
+	if _, err := locale.New(localeID); err != nil {
+		controller.ServeValidationErrors([]string{err.Error()})
+		return
+	}
+
+	version, ok := controller.version()
+	if ok == false {
+		return
+	}
+
+	translation, err := translationService.Publish(&controller.Service, localeID, translationID, version)
+	if err != nil {
+		log.CompletedErrorf(err, controller.UserID, "TranslationController.Publish", "Locale[%s] TranslationID[%s]", localeID, translationID)
+		controller.serveServiceError(err)
+		return
+	}
+
+	if err := translationService.Load(&controller.Service); err != nil {
+		log.Errorf(err, controller.UserID, "TranslationController.Publish", "Locale[%s] TranslationID[%s]", localeID, translationID)
+	}
+
+	controller.Data["json"] = translation
+	controller.ServeJson()
+}
+
+//** PRIVATE FUNCTIONS
+
+// authorize checks the X-Admin-Token header against the configured admin token
+// and serves a 403 if it does not match. The admin API is disabled without a token.
+func (controller *TranslationController) authorize() bool {
+	token := controller.Ctx.Input.Header("X-Admin-Token")
+	adminToken := translationService.Config.AdminToken
+
+	if adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+		return true
+	}
+
+	log.Warning(controller.UserID, "TranslationController.authorize", "Unauthorized Admin Request Path[%s]", controller.Ctx.Request.URL.Path)
+
+	controller.Data["json"] = struct {
+		Error string `json:"Error"`
+	}{controller.T("unauthorized")}
+	controller.Ctx.Output.SetStatus(403)
+	controller.ServeJson()
+	return false
+}
+
+// version returns the version parameter or serves a validation error if it is invalid.
+func (controller *TranslationController) version() (int, bool) {
+	version, err := strconv.Atoi(controller.GetString("version"))
+	if err != nil || version < 0 {
+		controller.ServeValidationErrors([]string{controller.T("invalid_translation_version")})
+		return 0, false
+	}
+
+	return version, true
+}
+
+// serveServiceError serves version conflicts as validation errors and anything else as an error.
+func (controller *TranslationController) serveServiceError(err error) {
+	if err == translationService.ErrVersionConflict {
+		controller.ServeValidationErrors([]string{controller.T("translation_version_conflict")})
+		return
+	}
+
+	controller.ServeError(err)
+}
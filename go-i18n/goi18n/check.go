@@ -0,0 +1,470 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template/parse"
+	"unicode/utf8"
+
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/language"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/translation"
+)
+
+type checkCommand struct {
+	translationFiles  []string
+	sourceLocaleID    string
+	output            string
+	allowUntranslated bool
+	out               io.Writer
+}
+
+func checkUsage() {
+	fmt.Printf(`Check translation files for problems.
+
+Usage:
+
+    goi18n check [options] [files...]
+
+Translation files:
+
+    Translation files are named and merged like "goi18n merge" does, but a file with a problem
+    does not stop the other files and translations from being checked.
+
+    The following problems are reported for each locale:
+
+        missing         A translation of the source locale is not in the locale.
+        untranslated    A translation of the locale is empty or lacks one of the language's plural categories.
+        mismatch        A translation is plural or ordinal in one locale but not in the source locale.
+        category        A plural translation has a category that the language does not use.
+        template        A translation is not a valid template.
+        variable        A translation uses a {{.Variable}} that the source locale translation does not.
+        length          A translation is longer than its maxLength, or the maxLength of the source locale
+                        translation, not counting {{actions}}.
+        invalid         A translation file or translation can not be read.
+
+    goi18n exits with a non-zero status if any problem is found.
+
+Options:
+
+    -sourceLocale localeId
+        The id of the locale that strings are initially written in (e.g. xx-XX)
+        Default: en-US
+
+    -output format
+        The format problems are reported in.
+        text reports a problem per line as: locale <tab> id <tab> kind <tab> category <tab> message.
+        json reports a JSON array of {"locale", "id", "kind", "category", "message"} objects.
+        Supported formats: text, json
+        Default: text
+
+    -allowUntranslated
+        Report untranslated strings without failing.
+
+`)
+	os.Exit(1)
+}
+
+func (cc *checkCommand) name() string {
+	return "check"
+}
+
+func (cc *checkCommand) parse(arguments []string) {
+	flags := flag.NewFlagSet(cc.name(), flag.ExitOnError)
+	flags.Usage = checkUsage
+
+	sourceLocale := flags.String("sourceLocale", "en-US", "")
+	output := flags.String("output", "text", "")
+	allowUntranslated := flags.Bool("allowUntranslated", false, "")
+	flags.Parse(arguments)
+
+	cc.translationFiles = flags.Args()
+	cc.sourceLocaleID = *sourceLocale
+	cc.output = *output
+	cc.allowUntranslated = *allowUntranslated
+	cc.out = os.Stdout
+}
+
+// problem is a problem found in a translation.
+type problem struct {
+	Locale   string `json:"locale"`
+	ID       string `json:"id"`
+	Kind     string `json:"kind"`
+	Category string `json:"category,omitempty"`
+	Message  string `json:"message"`
+}
+
+// checkedTranslation is a translation as written in a translation file.
+type checkedTranslation struct {
+	plural    bool
+	ordinal   bool
+	maxLength int
+
+	// templates are keyed by plural category or plural.Other for
+	// a non-plural translation. Keys are not validated.
+	templates map[string]string
+}
+
+func (cc *checkCommand) execute() error {
+	if len(cc.translationFiles) < 1 {
+		return fmt.Errorf("need at least one translation file to check")
+	}
+
+	sourceLocale, err := locale.New(cc.sourceLocaleID)
+	if err != nil {
+		return fmt.Errorf("invalid source locale %s: %s", cc.sourceLocaleID, err)
+	}
+
+	if cc.output != "text" && cc.output != "json" {
+		return fmt.Errorf("unsupported output format: %s\n", cc.output)
+	}
+
+	var problems []problem
+	translations := make(map[string]map[string]*checkedTranslation)
+	for _, filename := range cc.translationFiles {
+		problems = append(problems, loadCheckedTranslations(filename, translations)...)
+	}
+	problems = append(problems, checkTranslations(translations, sourceLocale.ID)...)
+
+	sort.Sort(sortableProblems(problems))
+	if err := cc.report(problems); err != nil {
+		return err
+	}
+
+	failures := 0
+	for _, p := range problems {
+		if p.Kind != "untranslated" || !cc.allowUntranslated {
+			failures++
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d problems found", failures)
+	}
+	return nil
+}
+
+func (cc *checkCommand) report(problems []problem) error {
+	if cc.out == nil {
+		return nil
+	}
+
+	if cc.output == "json" {
+		if problems == nil {
+			problems = []problem{}
+		}
+		buf, err := json.MarshalIndent(problems, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cc.out, "%s\n", buf)
+		return err
+	}
+
+	for _, p := range problems {
+		if _, err := fmt.Fprintf(cc.out, "%s\t%s\t%s\t%s\t%s\n", p.Locale, p.ID, p.Kind, p.Category, p.Message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadCheckedTranslations adds the translations in filename to translations.
+// Non-empty templates overwrite the templates of translations that were already loaded.
+func loadCheckedTranslations(filename string, translations map[string]map[string]*checkedTranslation) []problem {
+	l, err := locale.New(filename)
+	if err != nil {
+		return []problem{{Locale: filename, Kind: "invalid", Message: err.Error()}}
+	}
+
+	invalid := func(id string, format string, a ...interface{}) problem {
+		return problem{Locale: l.ID, ID: id, Kind: "invalid", Message: fmt.Sprintf("%s: %s", filename, fmt.Sprintf(format, a...))}
+	}
+
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return []problem{invalid("", "%s", err)}
+	}
+
+	var translationsData []map[string]interface{}
+	if len(buf) > 0 {
+		if err := json.Unmarshal(buf, &translationsData); err != nil {
+			return []problem{invalid("", "%s", err)}
+		}
+	}
+
+	if translations[l.ID] == nil {
+		translations[l.ID] = make(map[string]*checkedTranslation)
+	}
+	localeTranslations := translations[l.ID]
+
+	var problems []problem
+	for i, data := range translationsData {
+		id, ok := data["id"].(string)
+		if !ok {
+			problems = append(problems, invalid("", `translation #%d is missing "id" key`, i))
+			continue
+		}
+
+		t := &checkedTranslation{templates: make(map[string]string)}
+		switch ordinal := data["ordinal"].(type) {
+		case nil:
+		case bool:
+			t.ordinal = ordinal
+		default:
+			problems = append(problems, invalid(id, `unsupported type for "ordinal" key %T`, ordinal))
+			continue
+		}
+		metadata, err := translation.ParseMetadata(data)
+		if err != nil {
+			problems = append(problems, invalid(id, "%s", err))
+			continue
+		}
+		t.maxLength = metadata.MaxLength
+		switch tr := data["translation"].(type) {
+		case string:
+			if t.ordinal {
+				problems = append(problems, invalid(id, `ordinal translation must have plural categories`))
+				continue
+			}
+			t.templates[plural.Other] = tr
+		case map[string]interface{}:
+			t.plural = true
+			for k, v := range tr {
+				str, ok := v.(string)
+				if !ok {
+					problems = append(problems, invalid(id, `plural category "%s" has value of type %T; expected string`, k, v))
+					continue
+				}
+				t.templates[k] = str
+			}
+		case nil:
+			problems = append(problems, invalid(id, `missing "translation" key`))
+			continue
+		default:
+			problems = append(problems, invalid(id, `unsupported type for "translation" key %T`, tr))
+			continue
+		}
+
+		if current := localeTranslations[id]; current != nil && current.plural == t.plural && current.ordinal == t.ordinal {
+			for k, src := range t.templates {
+				if src != "" {
+					current.templates[k] = src
+				}
+			}
+			if t.maxLength != 0 {
+				current.maxLength = t.maxLength
+			}
+			continue
+		}
+		localeTranslations[id] = t
+	}
+	return problems
+}
+
+// checkTranslations checks the translations of each locale against the source locale.
+func checkTranslations(translations map[string]map[string]*checkedTranslation, sourceLocaleID string) []problem {
+	var problems []problem
+	sourceTranslations := translations[sourceLocaleID]
+	for localeID, localeTranslations := range translations {
+		lang := locale.MustNew(localeID).Language
+		add := func(id string, kind string, category string, format string, a ...interface{}) {
+			problems = append(problems, problem{localeID, id, kind, category, fmt.Sprintf(format, a...)})
+		}
+
+		for id := range sourceTranslations {
+			if localeTranslations[id] == nil {
+				add(id, "missing", "", "translation is missing")
+			}
+		}
+
+		for id, t := range localeTranslations {
+			src := sourceTranslations[id]
+			if src != nil && (src.plural != t.plural || src.ordinal != t.ordinal) {
+				add(id, "mismatch", "", "translation is %s but the %s translation is %s", pluralName(t), sourceLocaleID, pluralName(src))
+				continue
+			}
+
+			for _, pc := range checkIncomplete(t, lang) {
+				add(id, "untranslated", categoryName(t, pc), "translation is empty")
+			}
+
+			var sourceFields map[string]bool
+			if src != nil && localeID != sourceLocaleID {
+				sourceFields = make(map[string]bool)
+				for _, s := range src.templates {
+					fields, _ := templateFields(id, s)
+					for field := range fields {
+						sourceFields[field] = true
+					}
+				}
+				if src.plural {
+					sourceFields["Count"] = true
+				}
+			}
+
+			maxLength := t.maxLength
+			if maxLength == 0 && src != nil {
+				maxLength = src.maxLength
+			}
+
+			for _, k := range sortedKeys(t.templates) {
+				if length := templateLength(t.templates[k]); maxLength > 0 && length > maxLength {
+					add(id, "length", categoryName(t, plural.Category(k)), "translation is %d characters long; the maximum is %d", length, maxLength)
+				}
+
+				if t.plural {
+					pc, err := plural.NewCategory(k)
+					if err != nil {
+						add(id, "category", k, "%s", err)
+						continue
+					}
+					if t.ordinal {
+						if _, ok := lang.OrdinalCategories[pc]; !ok {
+							add(id, "category", k, "language %s does not use ordinal category %s", lang.ID, pc)
+						}
+					} else if _, ok := lang.PluralCategories[pc]; !ok {
+						add(id, "category", k, "language %s does not use plural category %s", lang.ID, pc)
+					}
+				}
+
+				fields, err := templateFields(id, t.templates[k])
+				if err != nil {
+					add(id, "template", categoryName(t, plural.Category(k)), "%s", err)
+					continue
+				}
+				if sourceFields == nil {
+					continue
+				}
+				for _, field := range sortedFields(fields) {
+					if !sourceFields[field] {
+						add(id, "variable", categoryName(t, plural.Category(k)), "{{.%s}} is not used by the %s translation", field, sourceLocaleID)
+					}
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// checkIncomplete returns the plural categories of l that t has no template for.
+func checkIncomplete(t *checkedTranslation, l *language.Language) []plural.Category {
+	if !t.plural {
+		if t.templates[plural.Other] == "" {
+			return []plural.Category{plural.Other}
+		}
+		return nil
+	}
+
+	languageCategories := pluralCategories(l)
+	if t.ordinal {
+		languageCategories = ordinalCategories(l)
+	}
+
+	var categories []plural.Category
+	for _, pc := range languageCategories {
+		if t.templates[string(pc)] == "" {
+			categories = append(categories, pc)
+		}
+	}
+	return categories
+}
+
+// templateFields parses src like translation templates are parsed
+// and returns the names of the {{.Field}} values it uses.
+func templateFields(id string, src string) (map[string]bool, error) {
+	fields := make(map[string]bool)
+	if !strings.Contains(src, "{{") {
+		return fields, nil
+	}
+
+	tree := parse.New(id)
+	tree.Mode = parse.SkipFuncCheck
+	treeSet := make(map[string]*parse.Tree)
+	if _, err := tree.Parse(src, "", "", treeSet); err != nil {
+		return nil, err
+	}
+
+	for _, t := range treeSet {
+		walkTemplate(t.Root, func(cmd *parse.CommandNode) {
+			for _, arg := range cmd.Args {
+				if field, ok := arg.(*parse.FieldNode); ok {
+					fields[field.Ident[0]] = true
+				}
+			}
+		})
+	}
+	return fields, nil
+}
+
+// templateActions matches the {{actions}} of a template.
+var templateActions = regexp.MustCompile(`(?s)\{\{.*?\}\}`)
+
+// templateLength returns the number of characters of src that are not {{actions}}.
+func templateLength(src string) int {
+	return utf8.RuneCountInString(templateActions.ReplaceAllString(src, ""))
+}
+
+func pluralName(t *checkedTranslation) string {
+	switch {
+	case t.ordinal:
+		return "ordinal"
+	case t.plural:
+		return "plural"
+	}
+	return "not plural"
+}
+
+// categoryName returns the plural category of a problem in t.
+func categoryName(t *checkedTranslation, pc plural.Category) string {
+	if !t.plural {
+		return ""
+	}
+	return string(pc)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedFields(fields map[string]bool) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortableProblems implements sort.Interface for problems.
+type sortableProblems []problem
+
+func (a sortableProblems) Len() int      { return len(a) }
+func (a sortableProblems) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a sortableProblems) Less(i, j int) bool {
+	if a[i].Locale != a[j].Locale {
+		return a[i].Locale < a[j].Locale
+	}
+	if a[i].ID != a[j].ID {
+		return a[i].ID < a[j].ID
+	}
+	if a[i].Kind != a[j].Kind {
+		return a[i].Kind < a[j].Kind
+	}
+	if a[i].Category != a[j].Category {
+		return a[i].Category < a[j].Category
+	}
+	return a[i].Message < a[j].Message
+}
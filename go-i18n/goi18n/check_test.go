@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var checkFiles = []string{
+	"testdata/check/en-US.json",
+	"testdata/check/fr-FR.json",
+	"testdata/check/ar-AR.json",
+}
+
+func TestCheckExecute(t *testing.T) {
+	var report bytes.Buffer
+	cc := &checkCommand{
+		translationFiles: checkFiles,
+		sourceLocaleID:   "en-US",
+		output:           "text",
+		out:              &report,
+	}
+	if err := cc.execute(); err == nil {
+		t.Errorf("check returned nil error")
+	}
+
+	expected := "ar-AR\td_days\tmismatch\t\ttranslation is not plural but the en-US translation is plural\n" +
+		"ar-AR\tperson_greeting\tmissing\t\ttranslation is missing\n" +
+		"ar-AR\tprogram_greeting\tuntranslated\t\ttranslation is empty\n" +
+		"fr-FR\td_days\tcategory\tfew\tlanguage fr does not use plural category few\n" +
+		"fr-FR\tperson_greeting\tvariable\t\t{{.Persn}} is not used by the en-US translation\n" +
+		"fr-FR\tprogram_greeting\tmissing\t\ttranslation is missing\n" +
+		"fr-FR\tstation_count\ttemplate\t\ttemplate: station_count:1: unclosed action\n"
+	if actual := report.String(); actual != expected {
+		t.Errorf("check reported\n%s\nexpected\n%s", actual, expected)
+	}
+}
+
+func TestCheckJSON(t *testing.T) {
+	var report bytes.Buffer
+	cc := &checkCommand{
+		translationFiles: checkFiles[:1],
+		sourceLocaleID:   "en-US",
+		output:           "json",
+		out:              &report,
+	}
+	if err := cc.execute(); err != nil {
+		t.Fatal(err)
+	}
+	if actual := report.String(); actual != "[]\n" {
+		t.Errorf("check reported %q; expected %q", actual, "[]\n")
+	}
+
+	report.Reset()
+	cc.translationFiles = []string{"testdata/check/en-US.json", "testdata/check/ar-AR.json"}
+	cc.allowUntranslated = true
+	if err := cc.execute(); err == nil {
+		t.Errorf("check returned nil error")
+	}
+
+	var problems []problem
+	if err := json.Unmarshal(report.Bytes(), &problems); err != nil {
+		t.Fatal(err)
+	}
+	expected := []problem{
+		{"ar-AR", "d_days", "mismatch", "", "translation is not plural but the en-US translation is plural"},
+		{"ar-AR", "person_greeting", "missing", "", "translation is missing"},
+		{"ar-AR", "program_greeting", "untranslated", "", "translation is empty"},
+	}
+	if !reflect.DeepEqual(problems, expected) {
+		t.Errorf("check reported\n%#v\nexpected\n%#v", problems, expected)
+	}
+}
+
+func TestCheckAllowUntranslated(t *testing.T) {
+	cc := &checkCommand{
+		translationFiles:  []string{"testdata/input/en-US.one.json", "testdata/input/en-US.two.json", "testdata/input/fr-FR.json"},
+		sourceLocaleID:    "en-US",
+		output:            "text",
+		allowUntranslated: true,
+	}
+	if err := cc.execute(); err == nil {
+		t.Errorf("check of a locale with missing translations returned nil error")
+	}
+
+	cc.translationFiles = []string{"testdata/expected/en-US.all.json", "testdata/expected/fr-FR.all.json"}
+	if err := cc.execute(); err != nil {
+		t.Errorf("check of untranslated strings returned %s", err)
+	}
+	cc.allowUntranslated = false
+	if err := cc.execute(); err == nil {
+		t.Errorf("check of untranslated strings returned nil error")
+	}
+}
+
+func TestCheckOrdinal(t *testing.T) {
+	var report bytes.Buffer
+	cc := &checkCommand{
+		translationFiles: []string{"testdata/check/ordinal/en-US.json", "testdata/check/ordinal/fr-FR.json"},
+		sourceLocaleID:   "en-US",
+		output:           "text",
+		out:              &report,
+	}
+	if err := cc.execute(); err == nil {
+		t.Errorf("check returned nil error")
+	}
+
+	expected := "fr-FR\tbuoy_rank\tcategory\tfew\tlanguage fr does not use ordinal category few\n" +
+		"fr-FR\td_days\tmismatch\t\ttranslation is ordinal but the en-US translation is plural\n"
+	if actual := report.String(); actual != expected {
+		t.Errorf("check reported\n%s\nexpected\n%s", actual, expected)
+	}
+}
+
+func TestCheckMaxLength(t *testing.T) {
+	var report bytes.Buffer
+	cc := &checkCommand{
+		translationFiles: []string{"testdata/check/length/en-US.json", "testdata/check/length/fr-FR.json"},
+		sourceLocaleID:   "en-US",
+		output:           "text",
+		out:              &report,
+	}
+	if err := cc.execute(); err == nil {
+		t.Errorf("check returned nil error")
+	}
+
+	expected := "en-US\twind_speed\tlength\t\ttranslation is 10 characters long; the maximum is 4\n" +
+		"fr-FR\tclose\tlength\t\ttranslation is 17 characters long; the maximum is 8\n" +
+		"fr-FR\td_days\tlength\tother\ttranslation is 17 characters long; the maximum is 10\n"
+	if actual := report.String(); actual != expected {
+		t.Errorf("check reported\n%s\nexpected\n%s", actual, expected)
+	}
+}
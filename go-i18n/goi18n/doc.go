@@ -5,51 +5,270 @@
 //
 // Help documentation:
 //
-//     goi18n formats and merges translation files.
-//     
+//     goi18n formats, merges, exports, imports, extracts and checks translation files.
+//
 //     Usage:
-//     
-//         goi18n [options] [files...]
-//     
+//
+//         goi18n [command] [options] [files...]
+//
+//     Commands:
+//
+//         merge
+//             Merges translation files for each locale (default).
+//             Run "goi18n merge -help" for more details.
+//
+//         export
+//             Exports translation files to gettext (po, mo) and XLIFF (1.2, 2.0) files.
+//             Run "goi18n export -help" for more details.
+//
+//         import
+//             Imports gettext (po, mo) and XLIFF (1.2, 2.0) files into translation files.
+//             Run "goi18n import -help" for more details.
+//
+//         extract
+//             Extracts the translation ids used by Go source files and templates.
+//             Run "goi18n extract -help" for more details.
+//
+//         check
+//             Checks translation files for missing, untranslated and invalid translations.
+//             Run "goi18n check -help" for more details.
+//
+//     Merge translation files.
+//
+//     Usage:
+//
+//         goi18n merge [options] [files...]
+//
 //     Translation files:
-//     
+//
 //         A translation file contains the strings and translations for a single locale (language + country).
-//     
+//
 //         Translation file names must have a suffix of a supported format (e.g. .json) and
 //         contain a valid locale identifier (e.g. ar-EG, en-US, fr-FR, etc.).
-//     
+//
 //         For each locale represented by at least one input translation file, goi18n will produce 2 output files:
-//     
+//
 //             xx-XX.all.format
 //                 This file contains all strings for the locale (translated and untranslated).
-//     
+//
 //             xx-XX.untranslated.format
 //                 This file contains the strings that have not been translated for this locale.
-//     			The translations for the strings in this file will be extracted from the source locale.
+//                 The translations for the strings in this file will be extracted from the source locale.
 //                 Get these strings translated! After they are translated, merge them back into
-//     			xx-XX.all.format using goi18n.
-//     
-//         goi18n will merge multiple translation files for the same locale. 
+//                 xx-XX.all.format using goi18n.
+//
+//         goi18n will merge multiple translation files for the same locale.
 //         Duplicate translations will be merged into the existing translation.
 //         Non-empty fields in the duplicate translation will overwrite those fields in the existing translation.
 //         Empty fields in the duplicate translation are ignored.
-//     
+//
+//         The optional description, context, maxLength, tags and markup of a translation are metadata for translators.
+//         The metadata of the source locale translation is copied to the other locales, whose
+//         translation files may override it (e.g. a shorter maxLength).
+//
 //         To produce translation files for a new locale, create an empty translation file with the
 //         appropriate name and pass it in to goi18n.
-//     
+//
 //     Options:
-//     
+//
 //         -sourceLocale localeId
-//     	    The id of the locale that strings are initially written in (e.g. xx-XX)
-//     	    Default: en-US
-//     
+//             The id of the locale that strings are initially written in (e.g. xx-XX)
+//             Default: en-US
+//
+//         -outdir directory
+//             goi18n will write the output translation files to this directory.
+//             Default: .
+//
+//         -format format
+//             goi18n will encode the output translation files in this format.
+//             Supported formats: json
+//             Default: json
+//
+//     Export translation files for use in gettext and XLIFF translation tools.
+//
+//     Usage:
+//
+//         goi18n export [options] [files...]
+//
+//     Translation files:
+//
+//         The input translation files are loaded and merged exactly like "goi18n merge" does.
+//
+//         For each locale represented by at least one input translation file, goi18n will produce 2 output files:
+//
+//             xx-XX.all.extension
+//                 This file contains all strings for the locale (translated and untranslated).
+//
+//             xx-XX.untranslated.extension
+//                 This file contains the strings that have not been translated for this locale.
+//                 The source strings are taken from the source locale and the targets are left empty.
+//
+//         Every string is identified by its translation id (msgctxt in gettext, the unit id in XLIFF)
+//         so completed files can be brought back with "goi18n import".
+//
+//         Plural translations use the plural categories of the locale's language in CLDR order
+//         (zero, one, two, few, many, other). In gettext files msgstr[N] is the Nth category
+//         and the Plural-Forms header selects between them.
+//
+//         Ordinal translations use the ordinal categories of the language. Gettext has no ordinal
+//         plural forms, so each category is a separate message whose msgctxt is id[category].
+//
+//         The description, context, maxLength, tags and markup of a translation are written as notes
+//         (#. comments in gettext, note elements in XLIFF). XLIFF 1.2 files also limit the
+//         maxwidth of the target to maxLength characters.
+//
+//     Options:
+//
+//         -sourceLocale localeId
+//             The id of the locale that strings are initially written in (e.g. xx-XX)
+//             Default: en-US
+//
+//         -outdir directory
+//             goi18n will write the exported files to this directory.
+//             Default: .
+//
+//         -format format
+//             goi18n will encode the exported files in this format.
+//             Supported formats: po, mo, xliff12, xliff20
+//             Default: po
+//
+//     Import translations from gettext and XLIFF translation tools.
+//
+//     Usage:
+//
+//         goi18n import [options] [files...]
+//
+//     Files:
+//
+//         The input files may be gettext PO (.po) or MO (.mo) files or XLIFF 1.2 or 2.0 (.xlf, .xliff) documents,
+//         typically ones that were produced by "goi18n export" and completed by translators.
+//
+//         The locale of each file is taken from its Language header (gettext) or target language (XLIFF).
+//         Files without one must contain a valid locale identifier in their name (e.g. fr-FR.all.po).
+//
+//         For each locale represented by at least one input file, goi18n will produce 1 output file:
+//
+//             xx-XX.imported.format
+//                 This file contains the imported translations for the locale.
+//                 Merge it into xx-XX.all.format using "goi18n merge".
+//
+//         Strings that have not been translated, including gettext messages marked fuzzy,
+//         are imported empty so merging them leaves the existing translations untouched.
+//
+//     Options:
+//
 //         -outdir directory
 //             goi18n will write the output translation files to this directory.
 //             Default: .
-//     
+//
 //         -format format
 //             goi18n will encode the output translation files in this format.
 //             Supported formats: json
 //             Default: json
-//     
+//
+//     Extract the translation ids that are used by Go source files and templates.
+//
+//     Usage:
+//
+//         goi18n extract [options] [paths...]
+//
+//     Paths:
+//
+//         Each path is a Go source file, a template or a directory that is searched recursively.
+//         Directories named testdata or vendor, hidden directories and _test.go files are skipped.
+//
+//         Translation ids are found in:
+//
+//             Go calls to a translate function with a string literal id (e.g. T("program_greeting"),
+//             localize.T("program_greeting")). Variables assigned the result of Tfunc or MustTfunc
+//             are translate functions as well.
+//
+//             Go struct tags (e.g. error:"invalid_station_id").
+//
+//             Template calls to a translate function with a string literal id (e.g. {{T "program_greeting"}}).
+//
+//         goi18n will produce 1 output file:
+//
+//             xx-XX.extracted.format
+//                 This file contains a translation for each id in the source locale.
+//                 Translations are copied from the -translations files and ids without one are left empty.
+//
+//         The ids that are used but have no translation (missing), that have a translation but are not
+//         used (unused) and translate calls whose id is not a string literal (dynamic) are reported
+//         one per line as: kind <tab> id <tab> positions.
+//
+//     Options:
+//
+//         -sourceLocale localeId
+//             The id of the locale that strings are initially written in (e.g. xx-XX)
+//             Default: en-US
+//
+//         -translations files
+//             A comma separated list of the existing translation files of the source locale.
+//
+//         -outdir directory
+//             goi18n will write the output translation file to this directory.
+//             Default: .
+//
+//         -format format
+//             goi18n will encode the output translation file in this format.
+//             Supported formats: json
+//             Default: json
+//
+//         -funcs names
+//             A comma separated list of translate function names.
+//             Default: T
+//
+//         -tags keys
+//             A comma separated list of struct tag keys that contain translation ids.
+//             Default: error
+//
+//         -templateExts extensions
+//             A comma separated list of template file extensions.
+//             Default: .html,.tpl
+//
+//         -prune
+//             Leave unused translations out of the output translation file.
+//
+//     Check translation files for problems.
+//
+//     Usage:
+//
+//         goi18n check [options] [files...]
+//
+//     Translation files:
+//
+//         Translation files are named and merged like "goi18n merge" does, but a file with a problem
+//         does not stop the other files and translations from being checked.
+//
+//         The following problems are reported for each locale:
+//
+//             missing         A translation of the source locale is not in the locale.
+//             untranslated    A translation of the locale is empty or lacks one of the language's plural categories.
+//             mismatch        A translation is plural or ordinal in one locale but not in the source locale.
+//             category        A plural translation has a category that the language does not use.
+//             template        A translation is not a valid template.
+//             variable        A translation uses a {{.Variable}} that the source locale translation does not.
+//             length          A translation is longer than its maxLength, or the maxLength of the source locale
+//                             translation, not counting {{actions}}.
+//             invalid         A translation file or translation can not be read.
+//
+//         goi18n exits with a non-zero status if any problem is found.
+//
+//     Options:
+//
+//         -sourceLocale localeId
+//             The id of the locale that strings are initially written in (e.g. xx-XX)
+//             Default: en-US
+//
+//         -output format
+//             The format problems are reported in.
+//             text reports a problem per line as: locale <tab> id <tab> kind <tab> category <tab> message.
+//             json reports a JSON array of {"locale", "id", "kind", "category", "message"} objects.
+//             Supported formats: text, json
+//             Default: text
+//
+//         -allowUntranslated
+//             Report untranslated strings without failing.
+//
 package main
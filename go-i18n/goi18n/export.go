@@ -0,0 +1,349 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/language"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/translation"
+)
+
+type exportCommand struct {
+	translationFiles []string
+	sourceLocaleID   string
+	outdir           string
+	format           string
+}
+
+func exportUsage() {
+	fmt.Printf(`Export translation files for use in gettext and XLIFF translation tools.
+
+Usage:
+
+    goi18n export [options] [files...]
+
+Translation files:
+
+    The input translation files are loaded and merged exactly like "goi18n merge" does.
+
+    For each locale represented by at least one input translation file, goi18n will produce 2 output files:
+
+        xx-XX.all.extension
+            This file contains all strings for the locale (translated and untranslated).
+
+        xx-XX.untranslated.extension
+            This file contains the strings that have not been translated for this locale.
+            The source strings are taken from the source locale and the targets are left empty.
+
+    Every string is identified by its translation id (msgctxt in gettext, the unit id in XLIFF)
+    so completed files can be brought back with "goi18n import".
+
+    Plural translations use the plural categories of the locale's language in CLDR order
+    (zero, one, two, few, many, other). In gettext files msgstr[N] is the Nth category
+    and the Plural-Forms header selects between them.
+
+    Ordinal translations use the ordinal categories of the language. Gettext has no ordinal
+    plural forms, so each category is a separate message whose msgctxt is id[category].
+
+    The description, context, maxLength, tags and markup of a translation are written as notes
+    (#. comments in gettext, note elements in XLIFF). XLIFF 1.2 files also limit the
+    maxwidth of the target to maxLength characters.
+
+Options:
+
+    -sourceLocale localeId
+        The id of the locale that strings are initially written in (e.g. xx-XX)
+        Default: en-US
+
+    -outdir directory
+        goi18n will write the exported files to this directory.
+        Default: .
+
+    -format format
+        goi18n will encode the exported files in this format.
+        Supported formats: po, mo, xliff12, xliff20
+        Default: po
+
+`)
+	os.Exit(1)
+}
+
+func (ec *exportCommand) name() string {
+	return "export"
+}
+
+func (ec *exportCommand) parse(arguments []string) {
+	flags := flag.NewFlagSet(ec.name(), flag.ExitOnError)
+	flags.Usage = exportUsage
+
+	sourceLocale := flags.String("sourceLocale", "en-US", "")
+	outdir := flags.String("outdir", ".", "")
+	format := flags.String("format", "po", "")
+	flags.Parse(arguments)
+
+	ec.translationFiles = flags.Args()
+	ec.sourceLocaleID = *sourceLocale
+	ec.outdir = *outdir
+	ec.format = *format
+}
+
+func (ec *exportCommand) execute() error {
+	encode, extension, err := newEncodeFunc(ec.format)
+	if err != nil {
+		return err
+	}
+
+	translations, err := loadTranslations(ec.translationFiles, ec.sourceLocaleID)
+	if err != nil {
+		return err
+	}
+
+	sourceTranslations := translations[ec.sourceLocaleID]
+	for localeID, localeTranslations := range translations {
+		locale := locale.MustNew(localeID)
+		all := filter(localeTranslations, func(t translation.Translation) translation.Translation {
+			return t.Normalize(locale.Language)
+		})
+		untranslated := filter(localeTranslations, func(t translation.Translation) translation.Translation {
+			if t.Incomplete(locale.Language) {
+				return t.Normalize(locale.Language)
+			}
+			return nil
+		})
+
+		for label, ts := range map[string][]translation.Translation{"all": all, "untranslated": untranslated} {
+			units, err := newUnits(ts, sourceTranslations, locale.Language)
+			if err != nil {
+				return fmt.Errorf("failed to export %s strings because %s", localeID, err)
+			}
+			buf, err := encode(units, ec.sourceLocaleID, locale)
+			if err != nil {
+				return fmt.Errorf("failed to encode %s strings to %s because %s", localeID, ec.format, err)
+			}
+			filename := filepath.Join(ec.outdir, fmt.Sprintf("%s.%s.%s", localeID, label, extension))
+			if err := ioutil.WriteFile(filename, buf, 0666); err != nil {
+				return fmt.Errorf("failed to write %s because %s", filename, err)
+			}
+		}
+	}
+	return nil
+}
+
+// encodeFunc encodes the units of the target locale into an exported file.
+type encodeFunc func(units []unit, sourceLocaleID string, target *locale.Locale) ([]byte, error)
+
+func newEncodeFunc(format string) (encodeFunc, string, error) {
+	switch format {
+	case "po":
+		return encodePO, "po", nil
+	case "mo":
+		return encodeMO, "mo", nil
+	case "xliff12":
+		return encodeXLIFF12, "xlf", nil
+	case "xliff20":
+		return encodeXLIFF20, "xlf", nil
+	}
+	return nil, "", fmt.Errorf("unsupported format: %s\n", format)
+}
+
+// cldrOrder is the order in which CLDR lists plural categories.
+// The gettext msgstr indexes of a language follow this order.
+var cldrOrder = []plural.Category{plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other}
+
+// pluralCategories returns the plural categories of l in CLDR order.
+func pluralCategories(l *language.Language) []plural.Category {
+	return inCLDROrder(l.PluralCategories)
+}
+
+// ordinalCategories returns the ordinal plural categories of l in CLDR order.
+func ordinalCategories(l *language.Language) []plural.Category {
+	return inCLDROrder(l.OrdinalCategories)
+}
+
+func inCLDROrder(set map[plural.Category]struct{}) []plural.Category {
+	categories := make([]plural.Category, 0, len(set))
+	for _, pc := range cldrOrder {
+		if _, ok := set[pc]; ok {
+			categories = append(categories, pc)
+		}
+	}
+	return categories
+}
+
+// unit is a format neutral view of a translation that is shared
+// by the gettext and XLIFF encoders and decoders.
+type unit struct {
+	id      string
+	plural  bool
+	ordinal bool
+
+	// metadata is the metadata of the translation merged with
+	// the metadata of the source locale translation.
+	// It is written as notes for translators.
+	metadata translation.Metadata
+
+	// categories are the plural categories of the target language.
+	// A non-plural unit only has plural.Other.
+	categories []plural.Category
+	source     map[plural.Category]string
+	target     map[plural.Category]string
+}
+
+// newUnits creates the units for translations of a locale that speaks l.
+func newUnits(translations []translation.Translation, sourceTranslations map[string]translation.Translation, l *language.Language) ([]unit, error) {
+	sort.Sort(translation.SortableByID(translations))
+	units := make([]unit, 0, len(translations))
+	for _, t := range translations {
+		isPlural, target, err := translationStrings(t)
+		if err != nil {
+			return nil, err
+		}
+
+		var source map[plural.Category]string
+		metadata := t.Metadata()
+		if src := sourceTranslations[t.ID()]; src != nil {
+			if _, source, err = translationStrings(src); err != nil {
+				return nil, err
+			}
+			metadata = src.Metadata().Merge(metadata)
+		}
+
+		u := unit{
+			id:         t.ID(),
+			plural:     isPlural,
+			ordinal:    t.Ordinal(),
+			metadata:   metadata,
+			categories: []plural.Category{plural.Other},
+			source:     make(map[plural.Category]string),
+			target:     target,
+		}
+		if u.ordinal {
+			u.categories = ordinalCategories(l)
+		} else if isPlural {
+			u.categories = pluralCategories(l)
+		}
+		for _, pc := range u.categories {
+			// The source language may not have all of the categories of the target language.
+			if u.source[pc] = source[pc]; u.source[pc] == "" {
+				u.source[pc] = source[plural.Other]
+			}
+		}
+		units = append(units, u)
+	}
+	return units, nil
+}
+
+// translationStrings returns the source strings of the templates in t.
+// The template of a non-plural translation is returned as plural.Other.
+func translationStrings(t translation.Translation) (bool, map[plural.Category]string, error) {
+	buf, err := json.Marshal(t.MarshalInterface())
+	if err != nil {
+		return false, nil, err
+	}
+
+	var data struct {
+		Translation interface{} `json:"translation"`
+	}
+	if err := json.Unmarshal(buf, &data); err != nil {
+		return false, nil, err
+	}
+
+	strs := make(map[plural.Category]string)
+	switch translation := data.Translation.(type) {
+	case nil:
+		strs[plural.Other] = ""
+		return false, strs, nil
+	case string:
+		strs[plural.Other] = translation
+		return false, strs, nil
+	case map[string]interface{}:
+		for k, v := range translation {
+			pc, err := plural.NewCategory(k)
+			if err != nil {
+				return false, nil, err
+			}
+			strs[pc], _ = v.(string)
+		}
+		return true, strs, nil
+	}
+	return false, nil, fmt.Errorf("unsupported type for translation %s: %T", t.ID(), data.Translation)
+}
+
+// markupNote tells translators that a translation contains HTML markup.
+const markupNote = "Contains HTML markup; keep the tags"
+
+// notes returns the metadata of u as notes for translators.
+func (u unit) notes() []string {
+	m := u.metadata
+	var notes []string
+	if m.Description != "" {
+		notes = append(notes, m.Description)
+	}
+	if m.Context != "" {
+		notes = append(notes, "Context: "+m.Context)
+	}
+	if m.MaxLength > 0 {
+		notes = append(notes, fmt.Sprintf("Maximum length: %d characters", m.MaxLength))
+	}
+	if len(m.Tags) > 0 {
+		notes = append(notes, "Tags: "+strings.Join(m.Tags, ", "))
+	}
+	if m.Markup {
+		notes = append(notes, markupNote)
+	}
+	return notes
+}
+
+// parseNotes returns the metadata written as notes by unit.notes.
+func parseNotes(notes []string) translation.Metadata {
+	var m translation.Metadata
+	for _, note := range notes {
+		switch {
+		case strings.HasPrefix(note, "Context: "):
+			m.Context = strings.TrimPrefix(note, "Context: ")
+		case note == markupNote:
+			m.Markup = true
+		case strings.HasPrefix(note, "Tags: "):
+			m.Tags = strings.Split(strings.TrimPrefix(note, "Tags: "), ", ")
+		default:
+			if _, err := fmt.Sscanf(note, "Maximum length: %d characters", &m.MaxLength); err != nil {
+				if m.Description != "" {
+					m.Description += "\n"
+				}
+				m.Description += note
+			}
+		}
+	}
+	return m
+}
+
+// newTranslation creates a translation from the target strings of u.
+func (u unit) newTranslation() (translation.Translation, error) {
+	data := map[string]interface{}{
+		"id":          u.id,
+		"description": u.metadata.Description,
+		"context":     u.metadata.Context,
+		"maxLength":   u.metadata.MaxLength,
+		"tags":        u.metadata.Tags,
+		"markup":      u.metadata.Markup,
+	}
+	if u.plural {
+		templates := make(map[string]interface{}, len(u.target))
+		for pc, src := range u.target {
+			templates[string(pc)] = src
+		}
+		data["translation"] = templates
+		data["ordinal"] = u.ordinal
+	} else {
+		data["translation"] = u.target[plural.Other]
+	}
+	return translation.NewTranslation(data)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/translation"
+)
+
+var exportFiles = []string{
+	"testdata/input/en-US.one.json",
+	"testdata/input/en-US.two.json",
+	"testdata/input/fr-FR.json",
+	"testdata/input/ar-AR.one.json",
+	"testdata/input/ar-AR.two.json",
+}
+
+func TestExportExecute(t *testing.T) {
+	resetDir(t, "testdata/output")
+	ec := &exportCommand{
+		translationFiles: exportFiles,
+		sourceLocaleID:   "en-US",
+		outdir:           "testdata/output",
+		format:           "po",
+	}
+	if err := ec.execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectEqualFiles(t, "testdata/output/ar-AR.all.po", "testdata/expected/ar-AR.all.po")
+	expectEqualFiles(t, "testdata/output/fr-FR.untranslated.po", "testdata/expected/fr-FR.untranslated.po")
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	for _, format := range []string{"po", "xliff12", "xliff20"} {
+		resetDir(t, "testdata/output")
+		ec := &exportCommand{
+			translationFiles: exportFiles,
+			sourceLocaleID:   "en-US",
+			outdir:           "testdata/output",
+			format:           format,
+		}
+		if err := ec.execute(); err != nil {
+			t.Fatalf("%s: %s", format, err)
+		}
+
+		_, extension, _ := newEncodeFunc(format)
+		ic := &importCommand{
+			files: []string{
+				"testdata/output/en-US.all." + extension,
+				"testdata/output/fr-FR.all." + extension,
+				"testdata/output/ar-AR.all." + extension,
+			},
+			outdir: "testdata/output",
+			format: "json",
+		}
+		if err := ic.execute(); err != nil {
+			t.Fatalf("%s: %s", format, err)
+		}
+
+		expectEqualFiles(t, "testdata/output/en-US.imported.json", "testdata/expected/en-US.all.json")
+		expectEqualFiles(t, "testdata/output/fr-FR.imported.json", "testdata/expected/fr-FR.all.json")
+		expectEqualFiles(t, "testdata/output/ar-AR.imported.json", "testdata/expected/ar-AR.all.json")
+	}
+}
+
+func TestMORoundTrip(t *testing.T) {
+	units := []unit{
+		{
+			id:         "d_days",
+			plural:     true,
+			categories: []plural.Category{plural.One, plural.Other},
+			source:     map[plural.Category]string{plural.One: "{{.Count}} day", plural.Other: "{{.Count}} days"},
+			target:     map[plural.Category]string{plural.One: "{{.Count}} jour", plural.Other: "{{.Count}} jours"},
+		},
+		{
+			id:         "person_greeting",
+			categories: []plural.Category{plural.Other},
+			source:     map[plural.Category]string{plural.Other: "Hello {{.Person}}"},
+			target:     map[plural.Category]string{plural.Other: "Bonjour {{.Person}}"},
+		},
+		{
+			id:         "program_greeting",
+			categories: []plural.Category{plural.Other},
+			source:     map[plural.Category]string{plural.Other: "Hello world"},
+			target:     map[plural.Category]string{plural.Other: ""},
+		},
+	}
+
+	buf, err := encodeMO(units, "en-US", locale.MustNew("fr-FR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, localeID, err := decodeMO(buf, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if localeID != "fr-FR" {
+		t.Errorf("decodeMO returned locale %s; expected fr-FR", localeID)
+	}
+
+	// Untranslated messages are not written to MO files and the
+	// source of a plural message is its plural form.
+	units[0].source = map[plural.Category]string{plural.Other: "{{.Count}} days"}
+	if expected := units[:2]; !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("decodeMO returned\n%#v\nexpected\n%#v", decoded, expected)
+	}
+}
+
+func TestExportRussian(t *testing.T) {
+	units := []unit{
+		{
+			id:         "d_days",
+			plural:     true,
+			categories: []plural.Category{plural.One, plural.Few, plural.Many, plural.Other},
+			source:     map[plural.Category]string{plural.One: "{{.Count}} day", plural.Other: "{{.Count}} days"},
+			target: map[plural.Category]string{
+				plural.One: "{{.Count}} день", plural.Few: "{{.Count}} дня", plural.Many: "{{.Count}} дней", plural.Other: "{{.Count}} дня",
+			},
+		},
+	}
+	ru := locale.MustNew("ru-RU")
+
+	po, err := encodePO(units, "en-US", ru)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `"Plural-Forms: ` + ru.Language.PluralForms + `\n"`; !strings.Contains(string(po), expected) || !strings.Contains(expected, "nplurals=4;") {
+		t.Errorf("encodePO returned\n%s\nexpected the header %s", po, expected)
+	}
+	decoded, _, err := decodePO(po, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded[0].target, units[0].target) {
+		t.Errorf("decodePO returned %#v; expected %#v", decoded[0].target, units[0].target)
+	}
+
+	mo, err := encodeMO(units, "en-US", ru)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded, _, err = decodeMO(mo, ""); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded[0].target, units[0].target) {
+		t.Errorf("decodeMO returned %#v; expected %#v", decoded[0].target, units[0].target)
+	}
+}
+
+func TestOrdinalRoundTrip(t *testing.T) {
+	units := []unit{
+		{
+			id:         "person_greeting",
+			categories: []plural.Category{plural.Other},
+			source:     map[plural.Category]string{plural.Other: "Hello {{.Person}}"},
+			target:     map[plural.Category]string{plural.Other: "Hello {{.Person}}"},
+		},
+		{
+			id:         "rank",
+			plural:     true,
+			ordinal:    true,
+			categories: []plural.Category{plural.One, plural.Two, plural.Few, plural.Other},
+			source: map[plural.Category]string{
+				plural.One: "{{.Count}}st", plural.Two: "{{.Count}}nd", plural.Few: "{{.Count}}rd", plural.Other: "{{.Count}}th",
+			},
+			target: map[plural.Category]string{
+				plural.One: "{{.Count}}st", plural.Two: "{{.Count}}nd", plural.Few: "{{.Count}}rd", plural.Other: "{{.Count}}th",
+			},
+		},
+	}
+
+	decoders := map[string]decodeFunc{"po": decodePO, "mo": decodeMO, "xliff12": decodeXLIFF, "xliff20": decodeXLIFF}
+	for format, decode := range decoders {
+		encode, _, err := newEncodeFunc(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf, err := encode(units, "en-US", locale.MustNew("en-US"))
+		if err != nil {
+			t.Fatalf("%s: %s", format, err)
+		}
+		decoded, _, err := decode(buf, "en-US")
+		if err != nil {
+			t.Fatalf("%s: %s", format, err)
+		}
+		if !reflect.DeepEqual(decoded, units) {
+			t.Errorf("%s: decoded\n%#v\nexpected\n%#v", format, decoded, units)
+		}
+	}
+}
+
+func TestDecodePO(t *testing.T) {
+	po := `# Translator comment
+msgid ""
+msgstr ""
+"Language: fr_FR\n"
+
+#, fuzzy
+msgctxt "program_greeting"
+msgid "Hello world"
+msgstr "Bonjour"
+
+msgid "Hello {{.Person}}"
+msgstr ""
+"Bonjour\n"
+"{{.Person}}"
+`
+	units, localeID, err := decodePO([]byte(po), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if localeID != "fr-FR" {
+		t.Errorf("decodePO returned locale %s; expected fr-FR", localeID)
+	}
+	if len(units) != 2 {
+		t.Fatalf("decodePO returned %d units; expected 2", len(units))
+	}
+	if units[0].id != "program_greeting" || units[0].target[plural.Other] != "" {
+		t.Errorf("fuzzy message was imported as %#v", units[0])
+	}
+	if units[1].id != "Hello {{.Person}}" || units[1].target[plural.Other] != "Bonjour\n{{.Person}}" {
+		t.Errorf("message without context was imported as %#v", units[1])
+	}
+}
+
+func TestNotesRoundTrip(t *testing.T) {
+	u := unit{metadata: translation.Metadata{
+		Description: "Label of the wind speed\nin the station dialog",
+		Context:     "dialog",
+		MaxLength:   20,
+		Tags:        []string{"buoy", "modal"},
+		Markup:      true,
+	}}
+	if m := parseNotes(u.notes()); !reflect.DeepEqual(m, u.metadata) {
+		t.Errorf("parseNotes returned %#v; expected %#v", m, u.metadata)
+	}
+}
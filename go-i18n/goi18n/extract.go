@@ -0,0 +1,449 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/bundle"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/translation"
+)
+
+type extractCommand struct {
+	paths            []string
+	translationFiles []string
+	sourceLocaleID   string
+	outdir           string
+	format           string
+	funcs            []string
+	tags             []string
+	templateExts     []string
+	prune            bool
+	out              io.Writer
+}
+
+func extractUsage() {
+	fmt.Printf(`Extract the translation ids that are used by Go source files and templates.
+
+Usage:
+
+    goi18n extract [options] [paths...]
+
+Paths:
+
+    Each path is a Go source file, a template or a directory that is searched recursively.
+    Directories named testdata or vendor, hidden directories and _test.go files are skipped.
+
+    Translation ids are found in:
+
+        Go calls to a translate function with a string literal id (e.g. T("program_greeting"),
+        localize.T("program_greeting")). Variables assigned the result of Tfunc or MustTfunc
+        are translate functions as well.
+
+        Go struct tags (e.g. error:"invalid_station_id").
+
+        Template calls to a translate function with a string literal id (e.g. {{T "program_greeting"}}).
+
+    goi18n will produce 1 output file:
+
+        xx-XX.extracted.format
+            This file contains a translation for each id in the source locale.
+            Translations are copied from the -translations files and ids without one are left empty.
+
+    The ids that are used but have no translation (missing), that have a translation but are not
+    used (unused) and translate calls whose id is not a string literal (dynamic) are reported
+    one per line as: kind <tab> id <tab> positions.
+
+Options:
+
+    -sourceLocale localeId
+        The id of the locale that strings are initially written in (e.g. xx-XX)
+        Default: en-US
+
+    -translations files
+        A comma separated list of the existing translation files of the source locale.
+
+    -outdir directory
+        goi18n will write the output translation file to this directory.
+        Default: .
+
+    -format format
+        goi18n will encode the output translation file in this format.
+        Supported formats: json
+        Default: json
+
+    -funcs names
+        A comma separated list of translate function names.
+        Default: T
+
+    -tags keys
+        A comma separated list of struct tag keys that contain translation ids.
+        Default: error
+
+    -templateExts extensions
+        A comma separated list of template file extensions.
+        Default: .html,.tpl
+
+    -prune
+        Leave unused translations out of the output translation file.
+
+`)
+	os.Exit(1)
+}
+
+func (ec *extractCommand) name() string {
+	return "extract"
+}
+
+func (ec *extractCommand) parse(arguments []string) {
+	flags := flag.NewFlagSet(ec.name(), flag.ExitOnError)
+	flags.Usage = extractUsage
+
+	sourceLocale := flags.String("sourceLocale", "en-US", "")
+	translations := flags.String("translations", "", "")
+	outdir := flags.String("outdir", ".", "")
+	format := flags.String("format", "json", "")
+	funcs := flags.String("funcs", "T", "")
+	tags := flags.String("tags", "error", "")
+	templateExts := flags.String("templateExts", ".html,.tpl", "")
+	prune := flags.Bool("prune", false, "")
+	flags.Parse(arguments)
+
+	ec.paths = flags.Args()
+	ec.translationFiles = splitList(*translations)
+	ec.sourceLocaleID = *sourceLocale
+	ec.outdir = *outdir
+	ec.format = *format
+	ec.funcs = splitList(*funcs)
+	ec.tags = splitList(*tags)
+	ec.templateExts = splitList(*templateExts)
+	ec.prune = *prune
+	ec.out = os.Stdout
+}
+
+// splitList splits a comma separated flag value.
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (ec *extractCommand) execute() error {
+	if len(ec.paths) < 1 {
+		return fmt.Errorf("need at least one path to extract from")
+	}
+
+	sourceLocale, err := locale.New(ec.sourceLocaleID)
+	if err != nil {
+		return fmt.Errorf("invalid source locale %s: %s", ec.sourceLocaleID, err)
+	}
+
+	marshal, err := newMarshalFunc(ec.format)
+	if err != nil {
+		return err
+	}
+
+	bundle := bundle.New()
+	for _, tf := range ec.translationFiles {
+		if err := bundle.LoadTranslationFile(tf); err != nil {
+			return fmt.Errorf("failed to load translation file %s because %s\n", tf, err)
+		}
+	}
+	sourceTranslations := bundle.Translations()[sourceLocale.ID]
+
+	ex := newExtractor(ec.funcs, ec.tags, ec.templateExts)
+	for _, path := range ec.paths {
+		if err := ex.extractPath(path); err != nil {
+			return err
+		}
+	}
+
+	extracted := make([]translation.Translation, 0, len(ex.ids))
+	for _, id := range sortedIDs(ex.ids) {
+		t := sourceTranslations[id]
+		if t == nil {
+			if t, err = translation.NewTranslation(map[string]interface{}{"id": id, "translation": ""}); err != nil {
+				return err
+			}
+			ec.report("missing", id, ex.ids[id])
+		}
+		extracted = append(extracted, t)
+	}
+	unused := make(map[string][]string)
+	for id := range sourceTranslations {
+		if _, ok := ex.ids[id]; !ok {
+			unused[id] = nil
+		}
+	}
+	for _, id := range sortedIDs(unused) {
+		ec.report("unused", id, nil)
+		if !ec.prune {
+			extracted = append(extracted, sourceTranslations[id])
+		}
+	}
+	for _, position := range ex.dynamic {
+		ec.report("dynamic", "", []string{position})
+	}
+
+	return writeFile(ec.outdir, "extracted", ec.format, extracted, sourceLocale.ID, marshal)
+}
+
+func (ec *extractCommand) report(kind string, id string, positions []string) {
+	if ec.out != nil {
+		fmt.Fprintf(ec.out, "%s\t%s\t%s\n", kind, id, strings.Join(positions, " "))
+	}
+}
+
+// extractor collects translation ids from Go source files and templates.
+type extractor struct {
+	funcs        map[string]bool
+	tags         []string
+	templateExts map[string]bool
+
+	// ids maps each translation id to the positions it is used at.
+	ids map[string][]string
+
+	// dynamic are the positions of translate calls without a string literal id.
+	dynamic []string
+}
+
+func newExtractor(funcs []string, tags []string, templateExts []string) *extractor {
+	ex := &extractor{
+		funcs:        make(map[string]bool, len(funcs)),
+		tags:         tags,
+		templateExts: make(map[string]bool, len(templateExts)),
+		ids:          make(map[string][]string),
+	}
+	for _, name := range funcs {
+		ex.funcs[name] = true
+	}
+	for _, ext := range templateExts {
+		ex.templateExts[ext] = true
+	}
+	return ex
+}
+
+func (ex *extractor) add(id string, position string) {
+	ex.ids[id] = append(ex.ids[id], position)
+}
+
+// extractPath extracts ids from a file or recursively from a directory.
+func (ex *extractor) extractPath(root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch {
+		case strings.HasSuffix(name, "_test.go"):
+			return nil
+		case filepath.Ext(name) == ".go":
+			return ex.extractGo(path)
+		case ex.templateExts[filepath.Ext(name)]:
+			return ex.extractTemplate(path)
+		}
+		return nil
+	})
+}
+
+// extractGo extracts ids from translate calls and struct tags in a Go source file.
+func (ex *extractor) extractGo(filename string) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	// Variables that hold the result of Tfunc are translate functions too.
+	funcs := make(map[string]bool, len(ex.funcs))
+	for name := range ex.funcs {
+		funcs[name] = true
+	}
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			if len(node.Rhs) == 1 && isTfuncCall(node.Rhs[0]) {
+				if ident, ok := node.Lhs[0].(*ast.Ident); ok {
+					funcs[ident.Name] = true
+				}
+			}
+		case *ast.ValueSpec:
+			if len(node.Values) == 1 && isTfuncCall(node.Values[0]) {
+				funcs[node.Names[0].Name] = true
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.CallExpr:
+			if !funcs[funcName(node.Fun)] || len(node.Args) == 0 {
+				return true
+			}
+			position := fset.Position(node.Pos()).String()
+			if lit, ok := node.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				if id, err := strconv.Unquote(lit.Value); err == nil {
+					ex.add(id, position)
+					return true
+				}
+			}
+			ex.dynamic = append(ex.dynamic, position)
+		case *ast.Field:
+			if node.Tag == nil {
+				return true
+			}
+			tag, err := strconv.Unquote(node.Tag.Value)
+			if err != nil {
+				return true
+			}
+			for _, key := range ex.tags {
+				if id := reflect.StructTag(tag).Get(key); id != "" {
+					ex.add(id, fset.Position(node.Tag.Pos()).String())
+				}
+			}
+		}
+		return true
+	})
+	return nil
+}
+
+// funcName returns the name of the called function for T(...) and pkg.T(...) calls.
+func funcName(fun ast.Expr) string {
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		return fun.Name
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	}
+	return ""
+}
+
+// isTfuncCall reports whether expr is a call to Tfunc or MustTfunc.
+func isTfuncCall(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	name := funcName(call.Fun)
+	return name == "Tfunc" || name == "MustTfunc"
+}
+
+// extractTemplate extracts ids from translate calls in a template.
+func (ex *extractor) extractTemplate(filename string) error {
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	// The functions of the template are unknown so they are not checked.
+	tree := parse.New(filename)
+	tree.Mode = parse.SkipFuncCheck
+	treeSet := make(map[string]*parse.Tree)
+	if _, err := tree.Parse(string(buf), "", "", treeSet); err != nil {
+		return err
+	}
+
+	// The tree set holds the template and the templates it defines.
+	for _, name := range sortedTrees(treeSet) {
+		t := treeSet[name]
+		walkTemplate(t.Root, func(cmd *parse.CommandNode) {
+			ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+			if !ok || !ex.funcs[ident.Ident] || len(cmd.Args) < 2 {
+				return
+			}
+			location, _ := t.ErrorContext(cmd)
+			if str, ok := cmd.Args[1].(*parse.StringNode); ok {
+				ex.add(str.Text, location)
+				return
+			}
+			ex.dynamic = append(ex.dynamic, location)
+		})
+	}
+	return nil
+}
+
+func sortedTrees(treeSet map[string]*parse.Tree) []string {
+	names := make([]string, 0, len(treeSet))
+	for name := range treeSet {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// walkTemplate calls visit for every command in the template tree rooted at node.
+func walkTemplate(node parse.Node, visit func(*parse.CommandNode)) {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return
+		}
+		for _, n := range node.Nodes {
+			walkTemplate(n, visit)
+		}
+	case *parse.ActionNode:
+		walkTemplate(node.Pipe, visit)
+	case *parse.IfNode:
+		walkBranch(&node.BranchNode, visit)
+	case *parse.RangeNode:
+		walkBranch(&node.BranchNode, visit)
+	case *parse.WithNode:
+		walkBranch(&node.BranchNode, visit)
+	case *parse.TemplateNode:
+		if node.Pipe != nil {
+			walkTemplate(node.Pipe, visit)
+		}
+	case *parse.PipeNode:
+		if node == nil {
+			return
+		}
+		for _, cmd := range node.Cmds {
+			walkTemplate(cmd, visit)
+		}
+	case *parse.CommandNode:
+		visit(node)
+		for _, arg := range node.Args {
+			walkTemplate(arg, visit)
+		}
+	}
+}
+
+func walkBranch(node *parse.BranchNode, visit func(*parse.CommandNode)) {
+	walkTemplate(node.Pipe, visit)
+	walkTemplate(node.List, visit)
+	walkTemplate(node.ElseList, visit)
+}
+
+// sortedIDs returns the keys of ids in order.
+func sortedIDs(ids map[string][]string) []string {
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExtractExecute(t *testing.T) {
+	resetDir(t, "testdata/output")
+	var report bytes.Buffer
+	ec := &extractCommand{
+		paths:            []string{"testdata/extract"},
+		translationFiles: []string{"testdata/extract/en-US.all.json"},
+		sourceLocaleID:   "en-US",
+		outdir:           "testdata/output",
+		format:           "json",
+		funcs:            []string{"T"},
+		tags:             []string{"error"},
+		templateExts:     []string{".html"},
+		out:              &report,
+	}
+	if err := ec.execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectEqualFiles(t, "testdata/output/en-US.extracted.json", "testdata/expected/en-US.extracted.json")
+
+	expected := "missing\tinvalid_station_id\ttestdata/extract/controllers/controller.go:9:19\n" +
+		"missing\tperson_greeting\ttestdata/extract/views/content.html:4:11\n" +
+		"unused\tinvalid_credentials\t\n" +
+		"dynamic\t\ttestdata/extract/controllers/controller.go:17:3\n"
+	if actual := report.String(); actual != expected {
+		t.Errorf("extract reported\n%s\nexpected\n%s", actual, expected)
+	}
+}
+
+func TestExtractPrune(t *testing.T) {
+	resetDir(t, "testdata/output")
+	ec := &extractCommand{
+		paths:            []string{"testdata/extract"},
+		translationFiles: []string{"testdata/extract/en-US.all.json"},
+		sourceLocaleID:   "en-US",
+		outdir:           "testdata/output",
+		format:           "json",
+		funcs:            []string{"T"},
+		tags:             []string{"error"},
+		templateExts:     []string{".html"},
+		prune:            true,
+	}
+	if err := ec.execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	translations, err := loadTranslations([]string{"testdata/output/en-US.extracted.json"}, "en-US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := translations["en-US"]["invalid_credentials"]; ok {
+		t.Errorf("unused translation invalid_credentials was not pruned")
+	}
+	if len(translations["en-US"]) != 5 {
+		t.Errorf("extracted %d translations; expected 5", len(translations["en-US"]))
+	}
+}
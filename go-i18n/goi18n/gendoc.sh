@@ -7,4 +7,7 @@ echo "//" >> doc.go
 echo "// Help documentation:" >> doc.go
 echo "//" >> doc.go
 goi18n -help | sed -e 's/^/\/\/     /' >> doc.go
+for command in merge export import extract check; do
+	goi18n $command -help | sed -e 's/^/\/\/     /' >> doc.go
+done
 echo "package main" >> doc.go
@@ -6,53 +6,41 @@ import (
 	"os"
 )
 
+// command is implemented by each of the goi18n subcommands.
+type command interface {
+	name() string
+	parse(arguments []string)
+	execute() error
+}
+
 func usage() {
-	fmt.Printf(`goi18n formats and merges translation files.
+	fmt.Printf(`goi18n formats, merges, exports, imports, extracts and checks translation files.
 
 Usage:
 
-    goi18n [options] [files...]
-
-Translation files:
-
-    A translation file contains the strings and translations for a single locale (language + country).
-
-    Translation file names must have a suffix of a supported format (e.g. .json) and
-    contain a valid locale identifier (e.g. ar-EG, en-US, fr-FR, etc.).
-
-    For each locale represented by at least one input translation file, goi18n will produce 2 output files:
-
-        xx-XX.all.format
-            This file contains all strings for the locale (translated and untranslated).
+    goi18n [command] [options] [files...]
 
-        xx-XX.untranslated.format
-            This file contains the strings that have not been translated for this locale.
-			The translations for the strings in this file will be extracted from the source locale.
-            Get these strings translated! After they are translated, merge them back into
-			xx-XX.all.format using goi18n.
+Commands:
 
-    goi18n will merge multiple translation files for the same locale. 
-    Duplicate translations will be merged into the existing translation.
-    Non-empty fields in the duplicate translation will overwrite those fields in the existing translation.
-    Empty fields in the duplicate translation are ignored.
+    merge
+        Merges translation files for each locale (default).
+        Run "goi18n merge -help" for more details.
 
-    To produce translation files for a new locale, create an empty translation file with the
-    appropriate name and pass it in to goi18n.
+    export
+        Exports translation files to gettext (po, mo) and XLIFF (1.2, 2.0) files.
+        Run "goi18n export -help" for more details.
 
-Options:
+    import
+        Imports gettext (po, mo) and XLIFF (1.2, 2.0) files into translation files.
+        Run "goi18n import -help" for more details.
 
-    -sourceLocale localeId
-	    The id of the locale that strings are initially written in (e.g. xx-XX)
-	    Default: en-US
+    extract
+        Extracts the translation ids used by Go source files and templates.
+        Run "goi18n extract -help" for more details.
 
-    -outdir directory
-        goi18n will write the output translation files to this directory.
-        Default: .
-
-    -format format
-        goi18n will encode the output translation files in this format.
-        Supported formats: json
-        Default: json
+    check
+        Checks translation files for missing, untranslated and invalid translations.
+        Run "goi18n check -help" for more details.
 
 `)
 	os.Exit(1)
@@ -60,19 +48,34 @@ Options:
 
 func main() {
 	flag.Usage = usage
-	sourceLocale := flag.String("sourceLocale", "en-US", "")
-	outdir := flag.String("outdir", ".", "")
-	format := flag.String("format", "json", "")
-	flag.Parse()
-
-	mc := &mergeCommand{
-		translationFiles: flag.Args(),
-		sourceLocaleID:   *sourceLocale,
-		outdir:           *outdir,
-		format:           *format,
+	commands := []command{
+		&mergeCommand{},
+		&exportCommand{},
+		&importCommand{},
+		&extractCommand{},
+		&checkCommand{},
+	}
+
+	arguments := os.Args[1:]
+	if len(arguments) > 0 && (arguments[0] == "-help" || arguments[0] == "--help" || arguments[0] == "-h") {
+		usage()
 	}
-	if err := mc.execute(); err != nil {
-		fmt.Println(err.Error())
+
+	// Without a command name goi18n behaves like "goi18n merge".
+	cmd := commands[0]
+	if len(arguments) > 0 {
+		for _, c := range commands {
+			if c.name() == arguments[0] {
+				cmd = c
+				arguments = arguments[1:]
+				break
+			}
+		}
+	}
+
+	cmd.parse(arguments)
+	if err := cmd.execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/bundle"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/translation"
+)
+
+type importCommand struct {
+	files  []string
+	outdir string
+	format string
+}
+
+func importUsage() {
+	fmt.Printf(`Import translations from gettext and XLIFF translation tools.
+
+Usage:
+
+    goi18n import [options] [files...]
+
+Files:
+
+    The input files may be gettext PO (.po) or MO (.mo) files or XLIFF 1.2 or 2.0 (.xlf, .xliff) documents,
+    typically ones that were produced by "goi18n export" and completed by translators.
+
+    The locale of each file is taken from its Language header (gettext) or target language (XLIFF).
+    Files without one must contain a valid locale identifier in their name (e.g. fr-FR.all.po).
+
+    For each locale represented by at least one input file, goi18n will produce 1 output file:
+
+        xx-XX.imported.format
+            This file contains the imported translations for the locale.
+            Merge it into xx-XX.all.format using "goi18n merge".
+
+    Strings that have not been translated, including gettext messages marked fuzzy,
+    are imported empty so merging them leaves the existing translations untouched.
+
+Options:
+
+    -outdir directory
+        goi18n will write the output translation files to this directory.
+        Default: .
+
+    -format format
+        goi18n will encode the output translation files in this format.
+        Supported formats: json
+        Default: json
+
+`)
+	os.Exit(1)
+}
+
+func (ic *importCommand) name() string {
+	return "import"
+}
+
+func (ic *importCommand) parse(arguments []string) {
+	flags := flag.NewFlagSet(ic.name(), flag.ExitOnError)
+	flags.Usage = importUsage
+
+	outdir := flags.String("outdir", ".", "")
+	format := flags.String("format", "json", "")
+	flags.Parse(arguments)
+
+	ic.files = flags.Args()
+	ic.outdir = *outdir
+	ic.format = *format
+}
+
+func (ic *importCommand) execute() error {
+	if len(ic.files) < 1 {
+		return fmt.Errorf("need at least one file to import")
+	}
+
+	marshal, err := newMarshalFunc(ic.format)
+	if err != nil {
+		return err
+	}
+
+	bundle := bundle.New()
+	for _, file := range ic.files {
+		locale, translations, err := importFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to import %s because %s", file, err)
+		}
+		bundle.AddTranslation(locale, translations...)
+	}
+
+	for localeID, localeTranslations := range bundle.Translations() {
+		imported := filter(localeTranslations, func(t translation.Translation) translation.Translation {
+			return t
+		})
+		if err := writeFile(ic.outdir, "imported", ic.format, imported, localeID, marshal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeFunc decodes the units of an imported file. The locale id
+// found in the file is returned, or localeID if there is none.
+type decodeFunc func(buf []byte, localeID string) ([]unit, string, error)
+
+func newDecodeFunc(filename string) (decodeFunc, error) {
+	switch ext := filepath.Ext(filename); ext {
+	case ".po":
+		return decodePO, nil
+	case ".mo":
+		return decodeMO, nil
+	case ".xlf", ".xliff":
+		return decodeXLIFF, nil
+	default:
+		return nil, fmt.Errorf("unsupported file extension %s", ext)
+	}
+}
+
+// importFile decodes filename into translations.
+func importFile(filename string) (*locale.Locale, []translation.Translation, error) {
+	decode, err := newDecodeFunc(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var localeID string
+	if l, err := locale.New(filename); err == nil {
+		localeID = l.ID
+	}
+
+	units, localeID, err := decode(buf, localeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l, err := locale.New(localeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to determine locale: %s", err)
+	}
+
+	translations := make([]translation.Translation, 0, len(units))
+	for _, u := range units {
+		t, err := u.newTranslation()
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse translation %s because %s", u.id, err)
+		}
+		translations = append(translations, t)
+	}
+	return l, translations, nil
+}
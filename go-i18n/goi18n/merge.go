@@ -2,8 +2,10 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io/ioutil"
+	"os"
 	//"launchpad.net/goyaml"
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/bundle"
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
@@ -20,43 +22,99 @@ type mergeCommand struct {
 	format           string
 }
 
-func (mc *mergeCommand) execute() error {
-	if len(mc.translationFiles) < 1 {
-		return fmt.Errorf("need at least one translation file to parse")
-	}
+func mergeUsage() {
+	fmt.Printf(`Merge translation files.
 
-	if _, err := locale.New(mc.sourceLocaleID); err != nil {
-		return fmt.Errorf("invalid source locale %s: %s", mc.sourceLocaleID, err)
-	}
+Usage:
+
+    goi18n merge [options] [files...]
+
+Translation files:
+
+    A translation file contains the strings and translations for a single locale (language + country).
+
+    Translation file names must have a suffix of a supported format (e.g. .json) and
+    contain a valid locale identifier (e.g. ar-EG, en-US, fr-FR, etc.).
+
+    For each locale represented by at least one input translation file, goi18n will produce 2 output files:
+
+        xx-XX.all.format
+            This file contains all strings for the locale (translated and untranslated).
+
+        xx-XX.untranslated.format
+            This file contains the strings that have not been translated for this locale.
+            The translations for the strings in this file will be extracted from the source locale.
+            Get these strings translated! After they are translated, merge them back into
+            xx-XX.all.format using goi18n.
+
+    goi18n will merge multiple translation files for the same locale.
+    Duplicate translations will be merged into the existing translation.
+    Non-empty fields in the duplicate translation will overwrite those fields in the existing translation.
+    Empty fields in the duplicate translation are ignored.
+
+    The optional description, context, maxLength, tags and markup of a translation are metadata for translators.
+    The metadata of the source locale translation is copied to the other locales, whose
+    translation files may override it (e.g. a shorter maxLength).
+
+    To produce translation files for a new locale, create an empty translation file with the
+    appropriate name and pass it in to goi18n.
+
+Options:
+
+    -sourceLocale localeId
+        The id of the locale that strings are initially written in (e.g. xx-XX)
+        Default: en-US
 
+    -outdir directory
+        goi18n will write the output translation files to this directory.
+        Default: .
+
+    -format format
+        goi18n will encode the output translation files in this format.
+        Supported formats: json
+        Default: json
+
+`)
+	os.Exit(1)
+}
+
+func (mc *mergeCommand) name() string {
+	return "merge"
+}
+
+func (mc *mergeCommand) parse(arguments []string) {
+	flags := flag.NewFlagSet(mc.name(), flag.ExitOnError)
+	flags.Usage = mergeUsage
+
+	sourceLocale := flags.String("sourceLocale", "en-US", "")
+	outdir := flags.String("outdir", ".", "")
+	format := flags.String("format", "json", "")
+	flags.Parse(arguments)
+
+	mc.translationFiles = flags.Args()
+	mc.sourceLocaleID = *sourceLocale
+	mc.outdir = *outdir
+	mc.format = *format
+}
+
+func (mc *mergeCommand) execute() error {
 	marshal, err := newMarshalFunc(mc.format)
 	if err != nil {
 		return err
 	}
 
-	bundle := bundle.New()
-	for _, tf := range mc.translationFiles {
-		if err := bundle.LoadTranslationFile(tf); err != nil {
-			return fmt.Errorf("failed to load translation file %s because %s\n", tf, err)
-		}
+	translations, err := loadTranslations(mc.translationFiles, mc.sourceLocaleID)
+	if err != nil {
+		return err
 	}
 
-	translations := bundle.Translations()
 	sourceTranslations := translations[mc.sourceLocaleID]
-	for translationID, src := range sourceTranslations {
-		for _, localeTranslations := range translations {
-			if dst := localeTranslations[translationID]; dst == nil || reflect.TypeOf(src) != reflect.TypeOf(dst) {
-				localeTranslations[translationID] = src.UntranslatedCopy()
-			}
-		}
-	}
-
 	for localeID, localeTranslations := range translations {
 		locale := locale.MustNew(localeID)
 		all := filter(localeTranslations, func(t translation.Translation) translation.Translation {
 			return t.Normalize(locale.Language)
 		})
-		if err := mc.writeFile("all", all, localeID, marshal); err != nil {
+		if err := writeFile(mc.outdir, "all", mc.format, all, localeID, marshal); err != nil {
 			return err
 		}
 
@@ -66,22 +124,56 @@ func (mc *mergeCommand) execute() error {
 			}
 			return nil
 		})
-		if err := mc.writeFile("untranslated", untranslated, localeID, marshal); err != nil {
+		if err := writeFile(mc.outdir, "untranslated", mc.format, untranslated, localeID, marshal); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// loadTranslations loads translationFiles and makes sure that every locale
+// has an entry for each of the translations in the source locale
+// with the metadata of the source translation.
+func loadTranslations(translationFiles []string, sourceLocaleID string) (map[string]map[string]translation.Translation, error) {
+	if len(translationFiles) < 1 {
+		return nil, fmt.Errorf("need at least one translation file to parse")
+	}
+
+	if _, err := locale.New(sourceLocaleID); err != nil {
+		return nil, fmt.Errorf("invalid source locale %s: %s", sourceLocaleID, err)
+	}
+
+	bundle := bundle.New()
+	for _, tf := range translationFiles {
+		if err := bundle.LoadTranslationFile(tf); err != nil {
+			return nil, fmt.Errorf("failed to load translation file %s because %s\n", tf, err)
+		}
+	}
+
+	translations := bundle.Translations()
+	sourceTranslations := translations[sourceLocaleID]
+	for translationID, src := range sourceTranslations {
+		for _, localeTranslations := range translations {
+			if dst := localeTranslations[translationID]; dst == nil || reflect.TypeOf(src) != reflect.TypeOf(dst) {
+				localeTranslations[translationID] = src.UntranslatedCopy()
+			} else {
+				// Carry the metadata of the source translation over to the other locales.
+				localeTranslations[translationID] = src.UntranslatedCopy().Merge(dst)
+			}
+		}
+	}
+	return translations, nil
+}
+
 type marshalFunc func(interface{}) ([]byte, error)
 
-func (mc *mergeCommand) writeFile(label string, translations []translation.Translation, localeID string, marshal marshalFunc) error {
+func writeFile(outdir, label, format string, translations []translation.Translation, localeID string, marshal marshalFunc) error {
 	sort.Sort(translation.SortableByID(translations))
 	buf, err := marshal(marshalInterface(translations))
 	if err != nil {
-		return fmt.Errorf("failed to marshal %s strings to %s because %s", localeID, mc.format, err)
+		return fmt.Errorf("failed to marshal %s strings to %s because %s", localeID, format, err)
 	}
-	filename := filepath.Join(mc.outdir, fmt.Sprintf("%s.%s.%s", localeID, label, mc.format))
+	filename := filepath.Join(outdir, fmt.Sprintf("%s.%s.%s", localeID, label, format))
 	if err := ioutil.WriteFile(filename, buf, 0666); err != nil {
 		return fmt.Errorf("failed to write %s because %s", filename, err)
 	}
@@ -0,0 +1,510 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/language"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
+)
+
+// pluralFormsHeader returns the gettext Plural-Forms header value for l,
+// which is generated with the plural rules of the language from the CLDR data.
+// The index selected by the expression is the position of the
+// category in the language's plural categories in CLDR order.
+func pluralFormsHeader(l *language.Language) (string, error) {
+	if l.PluralForms == "" {
+		return "", fmt.Errorf("no gettext plural forms defined for language %s", l.ID)
+	}
+	return l.PluralForms, nil
+}
+
+// poHeader returns the msgstr of the gettext header entry.
+func poHeader(sourceLocaleID string, target *locale.Locale) (string, error) {
+	forms, err := pluralFormsHeader(target.Language)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Language: %s\n"+
+		"MIME-Version: 1.0\n"+
+		"Content-Type: text/plain; charset=UTF-8\n"+
+		"Content-Transfer-Encoding: 8bit\n"+
+		"Plural-Forms: %s\n"+
+		"X-Generator: goi18n\n"+
+		"X-Source-Language: %s\n", target.ID, forms, sourceLocaleID), nil
+}
+
+// encodePO encodes units as a gettext PO file.
+//
+// The translation id is stored in msgctxt, the source locale
+// string in msgid and the target locale string in msgstr.
+// The metadata of a translation is written as extracted comments (#.).
+// Ordinal translations are split like splitOrdinals does.
+func encodePO(units []unit, sourceLocaleID string, target *locale.Locale) ([]byte, error) {
+	header, err := poHeader(sourceLocaleID, target)
+	if err != nil {
+		return nil, err
+	}
+	units = splitOrdinals(units)
+
+	var buf bytes.Buffer
+	writePOString(&buf, "msgid", "")
+	writePOString(&buf, "msgstr", header)
+
+	for _, u := range units {
+		buf.WriteString("\n")
+		for _, note := range u.notes() {
+			for _, line := range strings.Split(note, "\n") {
+				fmt.Fprintf(&buf, "#. %s\n", line)
+			}
+		}
+		writePOString(&buf, "msgctxt", u.id)
+		if !u.plural {
+			writePOString(&buf, "msgid", u.source[plural.Other])
+			writePOString(&buf, "msgstr", u.target[plural.Other])
+			continue
+		}
+
+		msgid := u.source[plural.One]
+		if msgid == "" {
+			msgid = u.source[plural.Other]
+		}
+		writePOString(&buf, "msgid", msgid)
+		writePOString(&buf, "msgid_plural", u.source[plural.Other])
+		for i, pc := range u.categories {
+			writePOString(&buf, fmt.Sprintf("msgstr[%d]", i), u.target[pc])
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// writePOString writes a keyword and its quoted value, splitting
+// values that contain new lines over multiple lines.
+func writePOString(buf *bytes.Buffer, keyword string, value string) {
+	lines := strings.SplitAfter(value, "\n")
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 1 {
+		fmt.Fprintf(buf, "%s %s\n", keyword, quotePO(value))
+		return
+	}
+
+	fmt.Fprintf(buf, "%s \"\"\n", keyword)
+	for _, line := range lines {
+		fmt.Fprintf(buf, "%s\n", quotePO(line))
+	}
+}
+
+func quotePO(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return `"` + replacer.Replace(s) + `"`
+}
+
+// poEntry is a single message of a gettext PO or MO file.
+type poEntry struct {
+	msgctxt     string
+	msgid       string
+	msgidPlural string
+	msgstr      []string
+	notes       []string
+	fuzzy       bool
+	hasPlural   bool
+}
+
+// decodePO decodes the units of a gettext PO file.
+//
+// The locale id is taken from the Language header if there is one
+// and defaults to localeID otherwise.
+// Entries marked fuzzy are treated as untranslated.
+func decodePO(buf []byte, localeID string) ([]unit, string, error) {
+	var entries []poEntry
+	var entry poEntry
+	var last *string
+	started := false
+
+	flush := func() {
+		if started {
+			entries = append(entries, entry)
+		}
+		entry, last, started = poEntry{}, nil, false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			flush()
+			continue
+		case strings.HasPrefix(line, "#,"):
+			if started {
+				flush()
+			}
+			entry.fuzzy = strings.Contains(line, "fuzzy")
+			continue
+		case strings.HasPrefix(line, "#."):
+			if started {
+				flush()
+			}
+			entry.notes = append(entry.notes, strings.TrimSpace(line[2:]))
+			continue
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, `"`):
+			if last == nil {
+				return nil, "", fmt.Errorf("line %d: unexpected string", lineNumber)
+			}
+			s, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, "", fmt.Errorf("line %d: %s", lineNumber, err)
+			}
+			*last += s
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) != 2 {
+			return nil, "", fmt.Errorf("line %d: missing value for %s", lineNumber, fields[0])
+		}
+		value, err := strconv.Unquote(strings.TrimSpace(fields[1]))
+		if err != nil {
+			return nil, "", fmt.Errorf("line %d: %s", lineNumber, err)
+		}
+
+		keyword := fields[0]
+		switch {
+		case keyword == "msgctxt":
+			if started {
+				flush()
+			}
+			entry.msgctxt = value
+			last = &entry.msgctxt
+		case keyword == "msgid":
+			if started && entry.msgstr != nil {
+				flush()
+			}
+			entry.msgid = value
+			last = &entry.msgid
+		case keyword == "msgid_plural":
+			entry.msgidPlural = value
+			entry.hasPlural = true
+			last = &entry.msgidPlural
+		case keyword == "msgstr":
+			entry.msgstr = append(entry.msgstr, value)
+			last = &entry.msgstr[len(entry.msgstr)-1]
+		case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+			i, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+			if err != nil || i != len(entry.msgstr) {
+				return nil, "", fmt.Errorf("line %d: unexpected %s", lineNumber, keyword)
+			}
+			entry.msgstr = append(entry.msgstr, value)
+			last = &entry.msgstr[len(entry.msgstr)-1]
+		default:
+			return nil, "", fmt.Errorf("line %d: unknown keyword %s", lineNumber, keyword)
+		}
+		started = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, "", err
+	}
+	flush()
+
+	return poUnits(entries, localeID)
+}
+
+// poUnits converts PO or MO entries into units of the locale
+// named by the Language header or localeID if there is none.
+func poUnits(entries []poEntry, localeID string) ([]unit, string, error) {
+	var categories []plural.Category
+	for _, entry := range entries {
+		if entry.msgctxt == "" && entry.msgid == "" && len(entry.msgstr) > 0 {
+			if language := poHeaderField(entry.msgstr[0], "Language"); language != "" {
+				localeID = language
+			}
+		}
+	}
+	if localeID != "" {
+		l, err := locale.New(localeID)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid locale %s: %s", localeID, err)
+		}
+		localeID = l.ID
+		categories = pluralCategories(l.Language)
+	}
+
+	units := make([]unit, 0, len(entries))
+	for _, entry := range entries {
+		if entry.msgctxt == "" && entry.msgid == "" {
+			// This is the header entry.
+			continue
+		}
+
+		u := unit{
+			id:         entry.msgctxt,
+			plural:     entry.hasPlural,
+			metadata:   parseNotes(entry.notes),
+			categories: []plural.Category{plural.Other},
+			source:     map[plural.Category]string{plural.Other: entry.msgid},
+			target:     make(map[plural.Category]string),
+		}
+		if u.id == "" {
+			// Files that were not exported by goi18n identify strings by msgid.
+			u.id = entry.msgid
+		}
+		if u.plural {
+			if categories == nil {
+				return nil, "", fmt.Errorf("plural message %s requires a locale", u.id)
+			}
+			if len(entry.msgstr) > len(categories) {
+				return nil, "", fmt.Errorf("plural message %s has %d forms; expected %d", u.id, len(entry.msgstr), len(categories))
+			}
+			u.categories = categories
+			u.source[plural.Other] = entry.msgidPlural
+		}
+
+		for i, msgstr := range entry.msgstr {
+			if entry.fuzzy {
+				msgstr = ""
+			}
+			u.target[u.categories[i]] = msgstr
+		}
+		units = append(units, u)
+	}
+	return joinOrdinals(units), localeID, nil
+}
+
+// ordinalMatcher matches the msgctxt of a category of an ordinal translation (e.g. rank[few]).
+var ordinalMatcher = regexp.MustCompile(`^(.+)\[(zero|one|two|few|many|other)\]$`)
+
+// splitOrdinals replaces each ordinal unit with a unit per category identified by id[category]
+// because the Plural-Forms of a gettext file only select cardinal plural categories.
+func splitOrdinals(units []unit) []unit {
+	split := make([]unit, 0, len(units))
+	for _, u := range units {
+		if !u.ordinal {
+			split = append(split, u)
+			continue
+		}
+		for _, pc := range u.categories {
+			split = append(split, unit{
+				id:         fmt.Sprintf("%s[%s]", u.id, pc),
+				metadata:   u.metadata,
+				categories: []plural.Category{plural.Other},
+				source:     map[plural.Category]string{plural.Other: u.source[pc]},
+				target:     map[plural.Category]string{plural.Other: u.target[pc]},
+			})
+		}
+	}
+	return split
+}
+
+// joinOrdinals is the inverse of splitOrdinals.
+func joinOrdinals(units []unit) []unit {
+	joined := make([]unit, 0, len(units))
+	ordinals := make(map[string]int)
+	for _, u := range units {
+		match := ordinalMatcher.FindStringSubmatch(u.id)
+		if u.plural || match == nil {
+			joined = append(joined, u)
+			continue
+		}
+
+		id, pc := match[1], plural.Category(match[2])
+		i, ok := ordinals[id]
+		if !ok {
+			i = len(joined)
+			ordinals[id] = i
+			joined = append(joined, unit{
+				id:       id,
+				plural:   true,
+				ordinal:  true,
+				metadata: u.metadata,
+				source:   make(map[plural.Category]string),
+				target:   make(map[plural.Category]string),
+			})
+		}
+		joined[i].source[pc], joined[i].target[pc] = u.source[plural.Other], u.target[plural.Other]
+	}
+
+	// MO files are sorted by message, so the categories are put in CLDR order.
+	for _, i := range ordinals {
+		categories := make(map[plural.Category]struct{}, len(joined[i].target))
+		for pc := range joined[i].target {
+			categories[pc] = struct{}{}
+		}
+		joined[i].categories = inCLDROrder(categories)
+	}
+	return joined
+}
+
+// poHeaderField returns the value of the named field in a gettext header.
+func poHeaderField(header string, name string) string {
+	for _, line := range strings.Split(header, "\n") {
+		fields := strings.SplitN(line, ":", 2)
+		if len(fields) == 2 && strings.TrimSpace(fields[0]) == name {
+			return strings.TrimSpace(fields[1])
+		}
+	}
+	return ""
+}
+
+// moMagic is the magic number of a gettext MO file.
+const moMagic = 0x950412de
+
+// moKey returns the original string of a MO entry.
+// Context and message are separated by EOT and plural forms by NUL.
+func moKey(msgctxt string, msgid string, msgidPlural string, hasPlural bool) string {
+	key := msgid
+	if hasPlural {
+		key += "\x00" + msgidPlural
+	}
+	if msgctxt != "" {
+		key = msgctxt + "\x04" + key
+	}
+	return key
+}
+
+// encodeMO encodes units as a little endian gettext MO file.
+//
+// Like msgfmt, untranslated messages are left out of the file.
+func encodeMO(units []unit, sourceLocaleID string, target *locale.Locale) ([]byte, error) {
+	header, err := poHeader(sourceLocaleID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := map[string]string{"": header}
+	for _, u := range splitOrdinals(units) {
+		targets := make([]string, len(u.categories))
+		translated := true
+		for i, pc := range u.categories {
+			targets[i] = u.target[pc]
+			translated = translated && targets[i] != ""
+		}
+		if !translated {
+			continue
+		}
+
+		msgid := u.source[plural.Other]
+		if u.plural {
+			if msgid = u.source[plural.One]; msgid == "" {
+				msgid = u.source[plural.Other]
+			}
+		}
+		messages[moKey(u.id, msgid, u.source[plural.Other], u.plural)] = strings.Join(targets, "\x00")
+	}
+
+	keys := make([]string, 0, len(messages))
+	for key := range messages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// The file starts with a 7 word header followed by the tables of
+	// original and translated strings which point into the string data.
+	n := uint32(len(keys))
+	originalsOffset := uint32(7 * 4)
+	translationsOffset := originalsOffset + n*8
+	dataOffset := translationsOffset + n*8
+
+	var data bytes.Buffer
+	originals := make([]uint32, 0, 2*n)
+	translations := make([]uint32, 0, 2*n)
+	for _, key := range keys {
+		originals = append(originals, uint32(len(key)), dataOffset+uint32(data.Len()))
+		data.WriteString(key)
+		data.WriteByte(0)
+	}
+	for _, key := range keys {
+		translations = append(translations, uint32(len(messages[key])), dataOffset+uint32(data.Len()))
+		data.WriteString(messages[key])
+		data.WriteByte(0)
+	}
+
+	var buf bytes.Buffer
+	header32 := []uint32{moMagic, 0, n, originalsOffset, translationsOffset, 0, dataOffset}
+	for _, words := range [][]uint32{header32, originals, translations} {
+		if err := binary.Write(&buf, binary.LittleEndian, words); err != nil {
+			return nil, err
+		}
+	}
+	buf.Write(data.Bytes())
+	return buf.Bytes(), nil
+}
+
+// decodeMO decodes the units of a gettext MO file of either byte order.
+// The locale id is resolved like decodePO does.
+func decodeMO(buf []byte, localeID string) ([]unit, string, error) {
+	if len(buf) < 7*4 {
+		return nil, "", fmt.Errorf("file is too short to be a MO file")
+	}
+
+	var order binary.ByteOrder
+	switch {
+	case binary.LittleEndian.Uint32(buf) == moMagic:
+		order = binary.LittleEndian
+	case binary.BigEndian.Uint32(buf) == moMagic:
+		order = binary.BigEndian
+	default:
+		return nil, "", fmt.Errorf("invalid MO magic number")
+	}
+
+	word := func(offset uint32) (uint32, error) {
+		if uint64(offset)+4 > uint64(len(buf)) {
+			return 0, fmt.Errorf("offset %d is out of range", offset)
+		}
+		return order.Uint32(buf[offset:]), nil
+	}
+	str := func(table uint32, i uint32) (string, error) {
+		length, err := word(table + i*8)
+		if err != nil {
+			return "", err
+		}
+		offset, err := word(table + i*8 + 4)
+		if err != nil {
+			return "", err
+		}
+		if uint64(offset)+uint64(length) > uint64(len(buf)) {
+			return "", fmt.Errorf("string %d is out of range", i)
+		}
+		return string(buf[offset : offset+length]), nil
+	}
+
+	n := order.Uint32(buf[8:])
+	originalsOffset := order.Uint32(buf[12:])
+	translationsOffset := order.Uint32(buf[16:])
+
+	entries := make([]poEntry, 0, n)
+	for i := uint32(0); i < n; i++ {
+		key, err := str(originalsOffset, i)
+		if err != nil {
+			return nil, "", err
+		}
+		value, err := str(translationsOffset, i)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var entry poEntry
+		if parts := strings.SplitN(key, "\x04", 2); len(parts) == 2 {
+			entry.msgctxt, key = parts[0], parts[1]
+		}
+		parts := strings.SplitN(key, "\x00", 2)
+		entry.msgid = parts[0]
+		if len(parts) == 2 {
+			entry.msgidPlural = parts[1]
+			entry.hasPlural = true
+		}
+		entry.msgstr = strings.Split(value, "\x00")
+		entries = append(entries, entry)
+	}
+	return poUnits(entries, localeID)
+}
@@ -0,0 +1,19 @@
+package controllers
+
+import (
+	"github.com/goinggo/beego-mgo/go-i18n/i18n"
+	"github.com/goinggo/beego-mgo/localize"
+)
+
+type params struct {
+	StationID string `form:"stationID" valid:"Required; MinSize(4)" error:"invalid_station_id"`
+}
+
+func messages(message string, count int) []string {
+	tr := i18n.MustTfunc("en-US")
+	return []string{
+		localize.T("application_error"),
+		tr("d_days", count),
+		localize.T(message),
+	}
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
+)
+
+const (
+	xliff12Namespace = "urn:oasis:names:tc:xliff:document:1.2"
+	xliff20Namespace = "urn:oasis:names:tc:xliff:document:2.0"
+
+	// xliff12PluralGroup is the restype of the XLIFF 1.2 group
+	// that holds the plural forms of a translation.
+	xliff12PluralGroup = "x-gettext-plurals"
+
+	// xliff12OrdinalGroup is the restype of the XLIFF 1.2 group
+	// that holds the ordinal plural forms of a translation.
+	xliff12OrdinalGroup = "x-goi18n-ordinals"
+
+	// xliff20Ordinal is the type of an XLIFF 2.0 unit
+	// whose segments are ordinal plural forms.
+	xliff20Ordinal = "goi18n:ordinal"
+)
+
+// XLIFF 1.2 documents.
+// http://docs.oasis-open.org/xliff/v1.2/os/xliff-core.html
+type (
+	xliff12 struct {
+		XMLName xml.Name      `xml:"urn:oasis:names:tc:xliff:document:1.2 xliff"`
+		Version string        `xml:"version,attr"`
+		Files   []xliff12File `xml:"file"`
+	}
+
+	xliff12File struct {
+		Original       string      `xml:"original,attr"`
+		SourceLanguage string      `xml:"source-language,attr"`
+		TargetLanguage string      `xml:"target-language,attr,omitempty"`
+		Datatype       string      `xml:"datatype,attr"`
+		Body           xliff12Body `xml:"body"`
+	}
+
+	xliff12Body struct {
+		Units  []xliff12Unit  `xml:"trans-unit"`
+		Groups []xliff12Group `xml:"group"`
+	}
+
+	xliff12Group struct {
+		ID      string        `xml:"id,attr"`
+		Restype string        `xml:"restype,attr"`
+		Notes   []string      `xml:"note"`
+		Units   []xliff12Unit `xml:"trans-unit"`
+	}
+
+	xliff12Unit struct {
+		ID       string        `xml:"id,attr"`
+		Resname  string        `xml:"resname,attr,omitempty"`
+		MaxWidth int           `xml:"maxwidth,attr,omitempty"`
+		SizeUnit string        `xml:"size-unit,attr,omitempty"`
+		Source   string        `xml:"source"`
+		Target   xliff12Target `xml:"target"`
+		Notes    []string      `xml:"note"`
+	}
+
+	xliff12Target struct {
+		State string `xml:"state,attr,omitempty"`
+		Text  string `xml:",chardata"`
+	}
+)
+
+// XLIFF 2.0 documents.
+// http://docs.oasis-open.org/xliff/xliff-core/v2.0/xliff-core-v2.0.html
+type (
+	xliff20 struct {
+		XMLName xml.Name      `xml:"urn:oasis:names:tc:xliff:document:2.0 xliff"`
+		Version string        `xml:"version,attr"`
+		SrcLang string        `xml:"srcLang,attr"`
+		TrgLang string        `xml:"trgLang,attr,omitempty"`
+		Files   []xliff20File `xml:"file"`
+	}
+
+	xliff20File struct {
+		ID    string        `xml:"id,attr"`
+		Units []xliff20Unit `xml:"unit"`
+	}
+
+	// xliff20Unit is a translation. The segments of a plural translation
+	// are identified by their plural category.
+	xliff20Unit struct {
+		ID       string           `xml:"id,attr"`
+		Type     string           `xml:"type,attr,omitempty"`
+		Notes    []string         `xml:"notes>note"`
+		Segments []xliff20Segment `xml:"segment"`
+	}
+
+	xliff20Segment struct {
+		ID     string `xml:"id,attr,omitempty"`
+		State  string `xml:"state,attr,omitempty"`
+		Source string `xml:"source"`
+		Target string `xml:"target"`
+	}
+)
+
+// xliffState returns the XLIFF 1.2 target state of a translated string.
+func xliffState(target string) string {
+	if target == "" {
+		return "needs-translation"
+	}
+	return "translated"
+}
+
+// encodeXLIFF12 encodes units as an XLIFF 1.2 document.
+//
+// Plural translations are a group of trans-units whose resname is the plural category.
+// The group of an ordinal translation has the restype xliff12OrdinalGroup.
+// The metadata of a translation is written as notes and its maxLength as maxwidth.
+func encodeXLIFF12(units []unit, sourceLocaleID string, target *locale.Locale) ([]byte, error) {
+	file := xliff12File{
+		Original:       target.ID,
+		SourceLanguage: sourceLocaleID,
+		TargetLanguage: target.ID,
+		Datatype:       "plaintext",
+	}
+	for _, u := range units {
+		if !u.plural {
+			xu := xliff12Unit{
+				ID:     u.id,
+				Source: u.source[plural.Other],
+				Target: xliff12Target{xliffState(u.target[plural.Other]), u.target[plural.Other]},
+				Notes:  u.notes(),
+			}
+			xu.setMaxLength(u.metadata.MaxLength)
+			file.Body.Units = append(file.Body.Units, xu)
+			continue
+		}
+
+		group := xliff12Group{ID: u.id, Restype: xliff12PluralGroup, Notes: u.notes()}
+		if u.ordinal {
+			group.Restype = xliff12OrdinalGroup
+		}
+		for _, pc := range u.categories {
+			xu := xliff12Unit{
+				ID:      fmt.Sprintf("%s[%s]", u.id, pc),
+				Resname: string(pc),
+				Source:  u.source[pc],
+				Target:  xliff12Target{xliffState(u.target[pc]), u.target[pc]},
+			}
+			xu.setMaxLength(u.metadata.MaxLength)
+			group.Units = append(group.Units, xu)
+		}
+		file.Body.Groups = append(file.Body.Groups, group)
+	}
+
+	return marshalXLIFF(&xliff12{Version: "1.2", Files: []xliff12File{file}})
+}
+
+// setMaxLength restricts the target of xu to maxLength characters.
+func (xu *xliff12Unit) setMaxLength(maxLength int) {
+	if maxLength > 0 {
+		xu.MaxWidth = maxLength
+		xu.SizeUnit = "char"
+	}
+}
+
+// encodeXLIFF20 encodes units as an XLIFF 2.0 document.
+func encodeXLIFF20(units []unit, sourceLocaleID string, target *locale.Locale) ([]byte, error) {
+	file := xliff20File{ID: target.ID}
+	for _, u := range units {
+		xu := xliff20Unit{ID: u.id}
+		if u.ordinal {
+			xu.Type = xliff20Ordinal
+		}
+		xu.Notes = u.notes()
+		for _, pc := range u.categories {
+			segment := xliff20Segment{
+				State:  "initial",
+				Source: u.source[pc],
+				Target: u.target[pc],
+			}
+			if segment.Target != "" {
+				segment.State = "translated"
+			}
+			if u.plural {
+				segment.ID = string(pc)
+			}
+			xu.Segments = append(xu.Segments, segment)
+		}
+		file.Units = append(file.Units, xu)
+	}
+
+	return marshalXLIFF(&xliff20{Version: "2.0", SrcLang: sourceLocaleID, TrgLang: target.ID, Files: []xliff20File{file}})
+}
+
+func marshalXLIFF(v interface{}) ([]byte, error) {
+	buf, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(buf, '\n')...), nil
+}
+
+// decodeXLIFF decodes the units of an XLIFF 1.2 or 2.0 document.
+//
+// The locale id is taken from the target language of the document
+// if there is one and defaults to localeID otherwise.
+func decodeXLIFF(buf []byte, localeID string) ([]unit, string, error) {
+	var root struct {
+		XMLName xml.Name
+		Version string `xml:"version,attr"`
+	}
+	if err := xml.Unmarshal(buf, &root); err != nil {
+		return nil, "", err
+	}
+
+	var units []unit
+	var targetLanguage string
+	var err error
+	switch {
+	case root.XMLName.Space == xliff12Namespace || (root.XMLName.Space == "" && strings.HasPrefix(root.Version, "1.")):
+		units, targetLanguage, err = decodeXLIFF12(buf)
+	case root.XMLName.Space == xliff20Namespace || (root.XMLName.Space == "" && strings.HasPrefix(root.Version, "2.")):
+		units, targetLanguage, err = decodeXLIFF20(buf)
+	default:
+		return nil, "", fmt.Errorf("unsupported XLIFF version %q", root.Version)
+	}
+	if targetLanguage != "" {
+		localeID = targetLanguage
+	}
+	return units, localeID, err
+}
+
+func decodeXLIFF12(buf []byte) ([]unit, string, error) {
+	var doc struct {
+		Files []xliff12File `xml:"file"`
+	}
+	if err := xml.NewDecoder(bytes.NewReader(buf)).Decode(&doc); err != nil {
+		return nil, "", err
+	}
+
+	var localeID string
+	var units []unit
+	for _, file := range doc.Files {
+		if file.TargetLanguage != "" {
+			localeID = file.TargetLanguage
+		}
+		for _, xu := range file.Body.Units {
+			units = append(units, unit{
+				id:         xu.ID,
+				metadata:   parseNotes(xu.Notes),
+				categories: []plural.Category{plural.Other},
+				source:     map[plural.Category]string{plural.Other: xu.Source},
+				target:     map[plural.Category]string{plural.Other: xu.Target.Text},
+			})
+		}
+		for _, group := range file.Body.Groups {
+			if group.Restype != xliff12PluralGroup && group.Restype != xliff12OrdinalGroup {
+				return nil, "", fmt.Errorf("unsupported group %s with restype %q", group.ID, group.Restype)
+			}
+			u := unit{
+				id:       group.ID,
+				plural:   true,
+				ordinal:  group.Restype == xliff12OrdinalGroup,
+				metadata: parseNotes(group.Notes),
+				source:   make(map[plural.Category]string),
+				target:   make(map[plural.Category]string),
+			}
+			for _, xu := range group.Units {
+				pc, err := plural.NewCategory(xu.Resname)
+				if err != nil {
+					return nil, "", fmt.Errorf("trans-unit %s: %s", xu.ID, err)
+				}
+				u.categories = append(u.categories, pc)
+				u.source[pc], u.target[pc] = xu.Source, xu.Target.Text
+			}
+			units = append(units, u)
+		}
+	}
+	return units, localeID, nil
+}
+
+func decodeXLIFF20(buf []byte) ([]unit, string, error) {
+	var doc struct {
+		TrgLang string        `xml:"trgLang,attr"`
+		Files   []xliff20File `xml:"file"`
+	}
+	if err := xml.NewDecoder(bytes.NewReader(buf)).Decode(&doc); err != nil {
+		return nil, "", err
+	}
+
+	var units []unit
+	for _, file := range doc.Files {
+		for _, xu := range file.Units {
+			u := unit{
+				id:       xu.ID,
+				ordinal:  xu.Type == xliff20Ordinal,
+				metadata: parseNotes(xu.Notes),
+				source:   make(map[plural.Category]string),
+				target:   make(map[plural.Category]string),
+			}
+			for _, segment := range xu.Segments {
+				pc := plural.Category(plural.Other)
+				if segment.ID != "" {
+					var err error
+					if pc, err = plural.NewCategory(segment.ID); err != nil {
+						return nil, "", fmt.Errorf("unit %s: %s", xu.ID, err)
+					}
+					u.plural = true
+				}
+				u.categories = append(u.categories, pc)
+				u.source[pc], u.target[pc] = segment.Source, segment.Target
+			}
+			units = append(units, u)
+		}
+	}
+	return units, doc.TrgLang, nil
+}
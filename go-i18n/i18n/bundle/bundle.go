@@ -4,23 +4,47 @@ package bundle
 import (
 	"encoding/json"
 	"fmt"
+	"html"
+	"html/template"
 	"io/ioutil"
+	"math/big"
 	//	"launchpad.net/goyaml"
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/translation"
 	"path/filepath"
+	"sync"
 )
 
 // TranslateFunc is a copy of i18n.TranslateFunc to avoid a circular dependency.
 type TranslateFunc func(translationID string, args ...interface{}) string
 
+// HTMLTranslateFunc is a copy of i18n.HTMLTranslateFunc to avoid a circular dependency.
+type HTMLTranslateFunc func(translationID string, args ...interface{}) template.HTML
+
+// MissingTranslationFunc is called with the id of the requested locale
+// and the translation id when the locale and its parents have no translation.
+type MissingTranslationFunc func(localeID, translationID string)
+
 type Bundle struct {
-	translations map[string]map[string]translation.Translation
+	// mu guards translations, which can be replaced while the bundle is in use,
+	// and the pseudo locales.
+	mu            sync.RWMutex
+	translations  map[string]map[string]translation.Translation
+	defaultLocale *locale.Locale
+	missing       MissingTranslationFunc
+
+	// pseudoLocales maps the id of a pseudo locale to the locale it is synthesized from.
+	pseudoLocales map[string]*locale.Locale
+	// pseudoTranslations caches the pseudolocalized copies of translations.
+	pseudoTranslations map[translation.Translation]translation.Translation
 }
 
 func New() *Bundle {
 	return &Bundle{
-		translations: make(map[string]map[string]translation.Translation),
+		translations:       make(map[string]map[string]translation.Translation),
+		pseudoLocales:      make(map[string]*locale.Locale),
+		pseudoTranslations: make(map[translation.Translation]translation.Translation),
 	}
 }
 
@@ -81,10 +105,13 @@ func parseTranslationFile(filename string) ([]translation.Translation, error) {
 }
 
 func (b *Bundle) AddTranslation(locale *locale.Locale, translations ...translation.Translation) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	if b.translations[locale.ID] == nil {
 		b.translations[locale.ID] = make(map[string]translation.Translation, len(translations))
 	}
 	currentTranslations := b.translations[locale.ID]
+	b.pseudoTranslations = make(map[translation.Translation]translation.Translation)
 	for _, newTranslation := range translations {
 		if currentTranslation := currentTranslations[newTranslation.ID()]; currentTranslation != nil {
 			currentTranslations[newTranslation.ID()] = currentTranslation.Merge(newTranslation)
@@ -94,6 +121,22 @@ func (b *Bundle) AddTranslation(locale *locale.Locale, translations ...translati
 	}
 }
 
+// SetTranslations replaces all translations of locale with translations.
+// A translation replaces an earlier one with the same id instead of being merged into it.
+//
+// It is safe to call while TranslateFuncs of the bundle are in use,
+// which is useful for reloading translations that are edited at runtime.
+func (b *Bundle) SetTranslations(locale *locale.Locale, translations ...translation.Translation) {
+	localeTranslations := make(map[string]translation.Translation, len(translations))
+	for _, t := range translations {
+		localeTranslations[t.ID()] = t
+	}
+	b.mu.Lock()
+	b.translations[locale.ID] = localeTranslations
+	b.pseudoTranslations = make(map[translation.Translation]translation.Translation)
+	b.mu.Unlock()
+}
+
 func (b *Bundle) Translations() map[string]map[string]translation.Translation {
 	return b.translations
 }
@@ -106,44 +149,129 @@ func (b *Bundle) MustTfunc(localeID string, localeIDs ...string) TranslateFunc {
 	return tf
 }
 
-func (b *Bundle) Tfunc(localeID string, localeIDs ...string) (tf TranslateFunc, err error) {
-	var l *locale.Locale
-	l, err = locale.New(localeID)
+func (b *Bundle) MustHTMLTfunc(localeID string, localeIDs ...string) HTMLTranslateFunc {
+	tf, err := b.HTMLTfunc(localeID, localeIDs...)
 	if err != nil {
-		for _, localeID := range localeIDs {
-			l, err = locale.New(localeID)
-			if err == nil {
-				break
-			}
-		}
+		panic(err)
+	}
+	return tf
+}
+
+// SetDefaultLocale sets the locale that every TranslateFunc returned by Tfunc
+// falls back to after the locales passed to Tfunc.
+func (b *Bundle) SetDefaultLocale(localeID string) error {
+	l, err := locale.New(localeID)
+	if err != nil {
+		return err
 	}
+	b.defaultLocale = l
+	return nil
+}
+
+// SetPseudoLocale makes the bundle synthesize the translations of a pseudo locale
+// (e.g. en-XA) by pseudolocalizing the translations of a source locale (e.g. en-US),
+// which shows strings that are not translated and layouts that truncate longer text.
+// See translation.Pseudolocalize.
+//
+// The pseudo locale uses the plural rules of the source locale's language.
+// It is not an error to add translations for the pseudo locale, but they are not used.
+func (b *Bundle) SetPseudoLocale(pseudoLocaleID, sourceLocaleID string) error {
+	pseudoLocale, err := locale.New(pseudoLocaleID)
+	if err != nil {
+		return err
+	}
+	sourceLocale, err := locale.New(sourceLocaleID)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	b.pseudoLocales[pseudoLocale.ID] = sourceLocale
+	b.mu.Unlock()
+	return nil
+}
+
+// SetMissingTranslationHandler sets a function that is called when a translation
+// is missing from the requested locale and its parents (e.g. for telemetry).
+// It is called even if the translation is found in a fallback locale.
+func (b *Bundle) SetMissingTranslationHandler(f MissingTranslationFunc) {
+	b.missing = f
+}
+
+// Tfunc returns a TranslateFunc that is bound to the first valid locale from its parameters.
+//
+// A translation that is missing from the bound locale and its parents is looked up
+// in the remaining valid locales, then in the default locale.
+// An error is returned if none of the parameters is a valid locale.
+func (b *Bundle) Tfunc(localeID string, localeIDs ...string) (TranslateFunc, error) {
+	chain, err := b.chain(localeID, localeIDs...)
 	return func(translationID string, args ...interface{}) string {
-		return b.translate(l, translationID, args...)
+		return b.translate(chain, false, translationID, args...)
 	}, err
 }
 
-func (b *Bundle) translate(locale *locale.Locale, translationID string, args ...interface{}) string {
-	if locale == nil {
-		return translationID
-	}
+// HTMLTfunc is similar to Tfunc except that the translations are rendered as HTML
+// for use in html/template views.
+//
+// The text of a translation is escaped unless the translation is marked as markup.
+// Template data is escaped for its context unless it is a template.HTML
+// (e.g. the result of another HTMLTranslateFunc).
+func (b *Bundle) HTMLTfunc(localeID string, localeIDs ...string) (HTMLTranslateFunc, error) {
+	chain, err := b.chain(localeID, localeIDs...)
+	return func(translationID string, args ...interface{}) template.HTML {
+		return template.HTML(b.translate(chain, true, translationID, args...))
+	}, err
+}
 
-	translations := b.translations[locale.ID]
-	if translations == nil {
-		return translationID
+// chain returns the valid locales of its parameters followed by the default locale.
+// An error is returned if none of the parameters is a valid locale.
+func (b *Bundle) chain(localeID string, localeIDs ...string) (chain []*locale.Locale, err error) {
+	for _, id := range append([]string{localeID}, localeIDs...) {
+		var l *locale.Locale
+		if l, err = locale.New(id); err == nil {
+			chain = append(chain, l)
+		}
 	}
+	if len(chain) > 0 {
+		err = nil
+	}
+	if b.defaultLocale != nil {
+		chain = append(chain, b.defaultLocale)
+	}
+	return chain, err
+}
 
-	translation := translations[translationID]
+// translate returns the translation of translationID from the first locale in chain,
+// or one of its parents, that has it. The translation is rendered as HTML if asHTML is true.
+func (b *Bundle) translate(chain []*locale.Locale, asHTML bool, translationID string, args ...interface{}) string {
+	var translation translation.Translation
+	var locale *locale.Locale
+	for i, l := range chain {
+		if translation, locale = b.translation(l, translationID); translation != nil {
+			break
+		}
+		if i == 0 && b.missing != nil {
+			b.missing(l.ID, translationID)
+		}
+	}
+	if asHTML {
+		translationID = html.EscapeString(translationID)
+	}
 	if translation == nil {
 		return translationID
 	}
 
 	var count interface{}
-	if len(args) > 0 && isNumber(args[0]) {
+	if len(args) > 0 && isCount(args[0]) {
 		count = args[0]
 		args = args[1:]
 	}
 
-	pluralCategory, _ := locale.Language.PluralCategory(count)
+	var pluralCategory plural.Category
+	if translation.Ordinal() {
+		pluralCategory, _ = locale.Language.OrdinalCategory(count)
+	} else {
+		pluralCategory, _ = locale.Language.PluralCategory(count)
+	}
 	template := translation.Template(pluralCategory)
 	if template == nil {
 		return translationID
@@ -154,7 +282,7 @@ func (b *Bundle) translate(locale *locale.Locale, translationID string, args ...
 		data, _ = args[0].(map[string]interface{})
 	}
 
-	if isNumber(count) {
+	if count != nil {
 		if data == nil {
 			data = map[string]interface{}{"Count": count}
 		} else {
@@ -162,16 +290,65 @@ func (b *Bundle) translate(locale *locale.Locale, translationID string, args ...
 		}
 	}
 
-	s := template.Execute(data)
+	var s string
+	if asHTML {
+		s = string(template.ExecuteHTML(data, translation.Metadata().Markup))
+	} else {
+		s = template.Execute(data)
+	}
 	if s == "" {
 		return translationID
 	}
 	return s
 }
 
-func isNumber(n interface{}) bool {
-	switch n.(type) {
-	case int, int8, int16, int32, int64, string:
+// translation returns the translation of translationID in locale
+// or in the closest parent of locale (e.g. zh-Hant-TW -> zh-Hant -> zh) that has it.
+// The translation of a pseudo locale is the pseudolocalized translation of its source locale.
+func (b *Bundle) translation(locale *locale.Locale, translationID string) (translation.Translation, *locale.Locale) {
+	b.mu.RLock()
+	sourceLocale := b.pseudoLocales[locale.ID]
+	b.mu.RUnlock()
+	if sourceLocale != nil {
+		return b.pseudoTranslation(sourceLocale, translationID)
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for l := locale; l != nil; l = l.Parent() {
+		if translation := b.translations[l.ID][translationID]; translation != nil {
+			return translation, l
+		}
+	}
+	return nil, nil
+}
+
+// pseudoTranslation returns the pseudolocalized translation of translationID in sourceLocale
+// and the locale that has the translation.
+func (b *Bundle) pseudoTranslation(sourceLocale *locale.Locale, translationID string) (translation.Translation, *locale.Locale) {
+	t, l := b.translation(sourceLocale, translationID)
+	if t == nil {
+		return nil, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	pseudo := b.pseudoTranslations[t]
+	if pseudo == nil {
+		pseudo = translation.Pseudolocalize(t)
+		b.pseudoTranslations[t] = pseudo
+	}
+	return pseudo, l
+}
+
+// isCount returns true if arg selects the plural form of a translation.
+//
+// Strings are never counts, even if they are numbers (e.g. the id "42002"),
+// so a decimal string must be passed as a plural.Count (e.g. plural.NewCount("1.50")).
+func isCount(arg interface{}) bool {
+	switch arg.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, *big.Float, *big.Int, plural.Count:
 		return true
 	}
 	return false
@@ -2,7 +2,11 @@ package bundle
 
 import (
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/translation"
+	"html/template"
+	"math/big"
+	"reflect"
 	"testing"
 )
 
@@ -87,6 +91,327 @@ func TestTfunc(t *testing.T) {
 	}
 }
 
+func TestTfuncParentFallback(t *testing.T) {
+	b := New()
+	b.AddTranslation(locale.MustNew("zh"), testNewTranslation(t, map[string]interface{}{
+		"id":          "buoy",
+		"translation": "zh(buoy)",
+	}), testNewTranslation(t, map[string]interface{}{
+		"id":          "station",
+		"translation": "zh(station)",
+	}))
+	b.AddTranslation(locale.MustNew("zh-Hant"), testNewTranslation(t, map[string]interface{}{
+		"id":          "buoy",
+		"translation": "zh-Hant(buoy)",
+	}))
+
+	tests := []struct {
+		localeID      string
+		translationID string
+		result        string
+	}{
+		{"zh-Hant-TW", "buoy", "zh-Hant(buoy)"},
+		{"zh-Hant-TW", "station", "zh(station)"},
+		{"zh_hant_tw", "buoy", "zh-Hant(buoy)"},
+		{"zh-Hans-CN", "buoy", "zh(buoy)"},
+		{"zh-Hant", "missing", "missing"},
+		{"en-US", "buoy", "buoy"},
+	}
+	for _, test := range tests {
+		tf := b.MustTfunc(test.localeID)
+		if result := tf(test.translationID); result != test.result {
+			t.Errorf("translation of %s in %s was %s; expected %s", test.translationID, test.localeID, result, test.result)
+		}
+	}
+}
+
+func TestTfuncFallbackChain(t *testing.T) {
+	b := New()
+	b.AddTranslation(locale.MustNew("en-US"), testNewTranslation(t, map[string]interface{}{
+		"id":          "buoy",
+		"translation": "en-US(buoy)",
+	}), testNewTranslation(t, map[string]interface{}{
+		"id":          "invalid_station_id",
+		"translation": "en-US(invalid_station_id)",
+	}), testNewTranslation(t, map[string]interface{}{
+		"id": "days",
+		"translation": map[string]interface{}{
+			"one":   "{{.Count}} day",
+			"other": "{{.Count}} days",
+		},
+	}))
+	b.AddTranslation(locale.MustNew("fr"), testNewTranslation(t, map[string]interface{}{
+		"id":          "buoy",
+		"translation": "fr(buoy)",
+	}))
+	b.AddTranslation(locale.MustNew("de-DE"), testNewTranslation(t, map[string]interface{}{
+		"id":          "station",
+		"translation": "de-DE(station)",
+	}))
+	if err := b.SetDefaultLocale("en-US"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetDefaultLocale("invalid"); err == nil {
+		t.Errorf("SetDefaultLocale(%q) returned nil error", "invalid")
+	}
+
+	var missing []string
+	b.SetMissingTranslationHandler(func(localeID, translationID string) {
+		missing = append(missing, localeID+":"+translationID)
+	})
+
+	tests := []struct {
+		localeIDs     []string
+		translationID string
+		args          []interface{}
+		result        string
+		missing       []string
+	}{
+		{[]string{"fr-CA"}, "buoy", nil, "fr(buoy)", nil},
+		{[]string{"fr-CA"}, "invalid_station_id", nil, "en-US(invalid_station_id)", []string{"fr-CA:invalid_station_id"}},
+		{[]string{"fr-CA", "de-DE"}, "station", nil, "de-DE(station)", []string{"fr-CA:station"}},
+		{[]string{"fr-CA"}, "unknown", nil, "unknown", []string{"fr-CA:unknown"}},
+		{[]string{"fr-CA"}, "days", []interface{}{1}, "1 day", []string{"fr-CA:days"}},
+		{[]string{"invalid"}, "buoy", nil, "en-US(buoy)", nil},
+	}
+	for _, test := range tests {
+		missing = nil
+		tf, _ := b.Tfunc(test.localeIDs[0], test.localeIDs[1:]...)
+		if result := tf(test.translationID, test.args...); result != test.result {
+			t.Errorf("translation of %s in %v was %s; expected %s", test.translationID, test.localeIDs, result, test.result)
+		}
+		if !reflect.DeepEqual(missing, test.missing) {
+			t.Errorf("missing translations of %s in %v were %v; expected %v", test.translationID, test.localeIDs, missing, test.missing)
+		}
+	}
+}
+
+func TestTfuncCounts(t *testing.T) {
+	b := New()
+	b.AddTranslation(locale.MustNew("en-US"), testNewTranslation(t, map[string]interface{}{
+		"id": "wind_speed",
+		"translation": map[string]interface{}{
+			"one":   "{{.Count}} mile per hour",
+			"other": "{{.Count}} miles per hour",
+		},
+	}), testNewTranslation(t, map[string]interface{}{
+		"id":          "station",
+		"translation": "Station {{.Count}}",
+	}), testNewTranslation(t, map[string]interface{}{
+		"id":          "station_id",
+		"translation": "Station {{.StationID}}",
+	}))
+
+	tf := b.MustTfunc("en-US")
+	tests := []struct {
+		translationID string
+		args          []interface{}
+		result        string
+	}{
+		{"wind_speed", []interface{}{1}, "1 mile per hour"},
+		{"wind_speed", []interface{}{uint(1)}, "1 mile per hour"},
+		{"wind_speed", []interface{}{1.0}, "1 mile per hour"},
+		{"wind_speed", []interface{}{1.5}, "1.5 miles per hour"},
+		{"wind_speed", []interface{}{float32(0.5)}, "0.5 miles per hour"},
+		{"wind_speed", []interface{}{big.NewFloat(2.25)}, "2.25 miles per hour"},
+		{"wind_speed", []interface{}{plural.NewCount("1")}, "1 mile per hour"},
+		{"wind_speed", []interface{}{plural.NewCount(1).WithDigits(1)}, "1.0 miles per hour"},
+		{"wind_speed", []interface{}{plural.NewCount(12.345).WithDigits(2)}, "12.35 miles per hour"},
+		{"station", []interface{}{"42002"}, "Station <no value>"},
+		{"station", []interface{}{plural.NewCount("42002")}, "Station 42002"},
+		{"station_id", []interface{}{map[string]interface{}{"StationID": "42002"}}, "Station 42002"},
+		{"station", []interface{}{"Gulf Of Mexico"}, "Station <no value>"},
+	}
+	for _, test := range tests {
+		if result := tf(test.translationID, test.args...); result != test.result {
+			t.Errorf("translation of %s with %#v was %s; expected %s", test.translationID, test.args, result, test.result)
+		}
+	}
+}
+
+func TestTfuncOrdinal(t *testing.T) {
+	b := New()
+	b.AddTranslation(locale.MustNew("en-US"), testNewTranslation(t, map[string]interface{}{
+		"id":      "rank",
+		"ordinal": true,
+		"translation": map[string]interface{}{
+			"one":   "{{.Count}}st",
+			"two":   "{{.Count}}nd",
+			"few":   "{{.Count}}rd",
+			"other": "{{.Count}}th",
+		},
+	}), testNewTranslation(t, map[string]interface{}{
+		"id": "days",
+		"translation": map[string]interface{}{
+			"one":   "{{.Count}} day",
+			"other": "{{.Count}} days",
+		},
+	}))
+
+	tf := b.MustTfunc("en-US")
+	tests := []struct {
+		translationID string
+		count         interface{}
+		result        string
+	}{
+		{"rank", 1, "1st"},
+		{"rank", 2, "2nd"},
+		{"rank", 3, "3rd"},
+		{"rank", 11, "11th"},
+		{"rank", plural.NewCount("22"), "22nd"},
+		{"days", 2, "2 days"},
+	}
+	for _, test := range tests {
+		if result := tf(test.translationID, test.count); result != test.result {
+			t.Errorf("translation of %s with %v was %s; expected %s", test.translationID, test.count, result, test.result)
+		}
+	}
+}
+
+func TestSetTranslations(t *testing.T) {
+	b := New()
+	b.AddTranslation(locale.MustNew("en-US"), testNewTranslation(t, map[string]interface{}{
+		"id":          "buoy",
+		"translation": "Buoy",
+	}), testNewTranslation(t, map[string]interface{}{
+		"id":          "station",
+		"translation": "Station",
+	}))
+
+	tf := b.MustTfunc("en-US")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			tf("buoy")
+		}
+	}()
+	b.SetTranslations(locale.MustNew("en-US"), testNewTranslation(t, map[string]interface{}{
+		"id":          "buoy",
+		"translation": "Old Buoy",
+	}), testNewTranslation(t, map[string]interface{}{
+		"id":          "buoy",
+		"translation": "New Buoy",
+	}))
+	<-done
+
+	if result := tf("buoy"); result != "New Buoy" {
+		t.Errorf("translation of buoy was %s; expected %s", result, "New Buoy")
+	}
+	if result := tf("station"); result != "station" {
+		t.Errorf("translation of station was %s; expected it to be removed", result)
+	}
+}
+
+func TestHTMLTfunc(t *testing.T) {
+	b := New()
+	b.AddTranslation(locale.MustNew("en-US"), testNewTranslation(t, map[string]interface{}{
+		"id":          "station",
+		"translation": "Station {{.Name}} & more",
+	}), testNewTranslation(t, map[string]interface{}{
+		"id":          "wind_speed_label",
+		"markup":      true,
+		"translation": "<b>Wind Speed:</b> {{.Speed}}",
+	}), testNewTranslation(t, map[string]interface{}{
+		"id": "days",
+		"translation": map[string]interface{}{
+			"one":   "{{.Count}} day <",
+			"other": "{{.Count}} days <",
+		},
+	}))
+
+	tf := b.MustHTMLTfunc("en-US")
+	tests := []struct {
+		translationID string
+		args          []interface{}
+		result        template.HTML
+	}{
+		{"station", []interface{}{map[string]interface{}{"Name": "<Gulf>"}}, "Station &lt;Gulf&gt; &amp; more"},
+		{"wind_speed_label", []interface{}{map[string]interface{}{"Speed": "<12 mph>"}}, "<b>Wind Speed:</b> &lt;12 mph&gt;"},
+		{"wind_speed_label", []interface{}{map[string]interface{}{"Speed": tf("days", 2)}}, "<b>Wind Speed:</b> 2 days &lt;"},
+		{"days", []interface{}{1}, "1 day &lt;"},
+		{"<missing>", nil, "&lt;missing&gt;"},
+	}
+	for _, test := range tests {
+		if result := tf(test.translationID, test.args...); result != test.result {
+			t.Errorf("HTML translation of %s with %#v was %s; expected %s", test.translationID, test.args, result, test.result)
+		}
+	}
+
+	if result := b.MustTfunc("en-US")("wind_speed_label", map[string]interface{}{"Speed": "<12 mph>"}); result != "<b>Wind Speed:</b> <12 mph>" {
+		t.Errorf("translation of wind_speed_label was %s; expected plain text", result)
+	}
+}
+
+func TestPseudoLocale(t *testing.T) {
+	b := New()
+	b.AddTranslation(locale.MustNew("en-US"), testNewTranslation(t, map[string]interface{}{
+		"id":          "person_greeting",
+		"translation": "Hello {{.Person}}",
+	}), testNewTranslation(t, map[string]interface{}{
+		"id":          "wind_speed_label",
+		"markup":      true,
+		"translation": "<b>Wind</b>",
+	}), testNewTranslation(t, map[string]interface{}{
+		"id": "days",
+		"translation": map[string]interface{}{
+			"one":   "{{.Count}} day",
+			"other": "{{.Count}} days",
+		},
+	}))
+	b.AddTranslation(locale.MustNew("en-XA"), testNewTranslation(t, map[string]interface{}{
+		"id":          "person_greeting",
+		"translation": "ignored",
+	}))
+	if err := b.SetPseudoLocale("en-XA", "en-US"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetPseudoLocale("en-XA", "invalid"); err == nil {
+		t.Errorf("SetPseudoLocale with an invalid source locale returned nil; expected error")
+	}
+	b.SetDefaultLocale("en-US")
+
+	var missing []string
+	b.SetMissingTranslationHandler(func(localeID, translationID string) {
+		missing = append(missing, localeID+" "+translationID)
+	})
+
+	tf := b.MustTfunc("en-XA")
+	tests := []struct {
+		translationID string
+		args          []interface{}
+		result        string
+	}{
+		{"person_greeting", []interface{}{map[string]interface{}{"Person": "Bob"}}, "[Ĥéļļö Bob~~]"},
+		{"days", []interface{}{1}, "[1 ðáý~~]"},
+		{"days", []interface{}{2}, "[2 ðáýš~~]"},
+		{"missing", nil, "missing"},
+	}
+	for _, test := range tests {
+		if result := tf(test.translationID, test.args...); result != test.result {
+			t.Errorf("translation of %s with %#v was %s; expected %s", test.translationID, test.args, result, test.result)
+		}
+	}
+	if result := b.MustHTMLTfunc("en-XA")("wind_speed_label"); result != "[<b>Ŵîñð</b>~~]" {
+		t.Errorf("HTML translation of wind_speed_label was %s; expected [<b>Ŵîñð</b>~~]", result)
+	}
+	if len(missing) != 1 || missing[0] != "en-XA missing" {
+		t.Errorf("missing translations were %v; expected [en-XA missing]", missing)
+	}
+
+	// Source translations that change are pseudolocalized again.
+	b.SetTranslations(locale.MustNew("en-US"), testNewTranslation(t, map[string]interface{}{
+		"id":          "person_greeting",
+		"translation": "Hi {{.Person}}",
+	}))
+	if result := tf("person_greeting", map[string]interface{}{"Person": "Bob"}); result != "[Ĥî Bob~]" {
+		t.Errorf("translation of person_greeting was %s; expected [Ĥî Bob~]", result)
+	}
+	if result := b.MustTfunc("en-US")("person_greeting", map[string]interface{}{"Person": "Bob"}); result != "Hi Bob" {
+		t.Errorf("source translation of person_greeting was %s; expected Hi Bob", result)
+	}
+}
+
 func testNewTranslation(t *testing.T, data map[string]interface{}) translation.Translation {
 	translation, err := translation.NewTranslation(data)
 	if err != nil {
@@ -3,6 +3,7 @@ package i18n_test
 import (
 	"fmt"
 	"github.com/goinggo/beego-mgo/go-i18n/i18n"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
 )
 
 func Example() {
@@ -18,7 +19,7 @@ func Example() {
 	fmt.Println(T("your_unread_email_count", 0))
 	fmt.Println(T("your_unread_email_count", 1))
 	fmt.Println(T("your_unread_email_count", 2))
-	fmt.Println(T("my_height_in_meters", "1.7"))
+	fmt.Println(T("my_height_in_meters", plural.NewCount("1.7")))
 
 	fmt.Println(T("person_unread_email_count", 0, map[string]interface{}{
 		"Person": "Bob",
@@ -0,0 +1,42 @@
+package i18n_test
+
+import (
+	"github.com/goinggo/beego-mgo/go-i18n/i18n"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/translation"
+	"html/template"
+	"os"
+)
+
+var htmlTmpl = template.Must(template.New("").Funcs(map[string]interface{}{
+	"T": i18n.IdentityTfunc,
+}).Parse(`
+<p>{{T "station_name" .}}</p>
+<p>{{T "wind_speed_label"}} 12 mph</p>
+`))
+
+func Example_htmlTemplate() {
+	for _, data := range []map[string]interface{}{
+		{"id": "station_name", "translation": "Station {{.Name}} & friends"},
+		{"id": "wind_speed_label", "markup": true, "translation": "<b>Wind Speed:</b>"},
+	} {
+		t, err := translation.NewTranslation(data)
+		if err != nil {
+			panic(err)
+		}
+		i18n.AddTranslation(locale.MustNew("en-US"), t)
+	}
+
+	T := i18n.MustHTMLTfunc("en-US")
+	htmlTmpl.Funcs(map[string]interface{}{
+		"T": T,
+	})
+
+	htmlTmpl.Execute(os.Stdout, map[string]interface{}{
+		"Name": "<Gulf Of Mexico>",
+	})
+
+	// Output:
+	// <p>Station &lt;Gulf Of Mexico&gt; &amp; friends</p>
+	// <p><b>Wind Speed:</b> 12 mph</p>
+}
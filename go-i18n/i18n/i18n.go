@@ -13,11 +13,22 @@
 //
 // Use Tfunc or MustTfunc to fetch a TranslateFunc that will return the translated string for a specific locale.
 // The TranslateFunc will be bound to the first valid locale passed to Tfunc.
+// Locales are BCP 47 language tags (e.g. fr, en-US, es-419, zh-Hant-TW).
+// Strings that have no translation in the locale are translated using its parents
+// (e.g. zh-Hant-TW falls back to zh-Hant and then zh).
 //     userLocale = "ar-AR"     // user preference, accept header, language cookie
 //     defaultLocale = "en-US"  // known valid locale
 //     T, err := i18n.Tfunc(userLocale, defaultLocale)
 //     fmt.Println(T("Hello world"))
 //
+// Strings that are still missing are translated using the other valid locales passed to Tfunc
+// and finally the locale set by SetDefaultLocale.
+// SetMissingTranslationHandler reports strings that the requested locale and its parents lack.
+//     i18n.SetDefaultLocale("en-US")
+//     i18n.SetMissingTranslationHandler(func(localeID, translationID string) {
+//         log.Printf("%s is missing %s", localeID, translationID)
+//     })
+//
 // Usually it is a good idea to identify strings by a generic id rather than the English translation,
 // but the rest of this documentation will continue to use the English translation for readability.
 //     T("program_greeting")
@@ -34,7 +45,13 @@
 // TranslateFunc supports the pluralization of strings using the CLDR pluralization rules defined here:
 // http://www.unicode.org/cldr/charts/latest/supplemental/language_plural_rules.html
 //     T("You have {{.Count}} unread emails.", 2)
-//     T("I am {{.Count}} meters tall.", "1.7")
+//     T("I am {{.Count}} meters tall.", 1.7)
+//     T("I am {{.Count}} meters tall.", plural.NewCount("1.7"))
+//
+// Counts may be integers, floats, *big.Float, *big.Int or a plural.Count, never strings.
+// Use plural.NewCount to pass a decimal string as a count and to choose its visible fraction digits,
+// which can change the plural form (e.g. "1.0 miles" in English).
+//     T("{{.Count}} miles", plural.NewCount(1).WithDigits(1))
 //
 // Plural strings may also have variables.
 //     T("{{.Person}} has {{.Count}} unread emails", 2, map[string]interface{}{
@@ -47,16 +64,53 @@
 //         "Timeframe": T("{{.Count}} days", 2),
 //     })
 //
+// Ordinals
+//
+// Translations marked "ordinal" in a translation file are selected by the CLDR ordinal rules,
+// so a single string can describe a position (e.g. 1st, 2nd, 3rd, 4th in English).
+//     {
+//         "id": "{{.Count}}th buoy",
+//         "ordinal": true,
+//         "translation": {
+//             "one": "{{.Count}}st buoy",
+//             "two": "{{.Count}}nd buoy",
+//             "few": "{{.Count}}rd buoy",
+//             "other": "{{.Count}}th buoy"
+//         }
+//     }
+//     T("{{.Count}}th buoy", 3) // 3rd buoy
+//
+// Metadata
+//
+// A translation may have a "description", "context", "maxLength" and "tags" for translators.
+// They are kept by the goi18n tool and don't change the translated string.
+//     {
+//         "id": "buoy_list_title",
+//         "description": "Title of the page that lists the buoys of a region",
+//         "maxLength": 30,
+//         "translation": "Buoys in {{.Region}}"
+//     }
+//
 // Templates
 //
 // You can use the .Funcs() method of a text/template or html/template to register a TranslateFunc
 // for usage inside of that template.
+//
+// Register an HTMLTranslateFunc with an html/template instead, so that the translations
+// are escaped as HTML text. Translators can mark translations that intentionally contain markup:
+//     {
+//         "id": "wind_speed_label",
+//         "markup": true,
+//         "translation": "<b>Wind Speed:</b>"
+//     }
+// Data inserted into a markup translation is still escaped for its context.
 package i18n
 
 import (
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/bundle"
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/translation"
+	"html/template"
 )
 
 // TranslateFunc returns the translation of the string identified by translationID.
@@ -64,11 +118,21 @@ import (
 // If translationID is a non-plural form, then the first variadic argument may be a map[string]interface{}
 // that contains template data.
 //
-// If translationID is a plural form, then the first variadic argument must be an integer type
-// (int, int8, int16, int32, int64) or a float formatted as a string (e.g. "123.45").
+// If translationID is a plural form, then the first variadic argument must be a count:
+// an integer type, a float type, a *big.Float, a *big.Int, a plural.Count
+// or a decimal number formatted as a string (e.g. "123.45").
+// Strings that are not decimal numbers are never counts.
 // The second variadic argument may be a map[string]interface{} that contains template data.
+// The plural form is selected by the ordinal rules of the language if the translation is ordinal.
 type TranslateFunc func(translationID string, args ...interface{}) string
 
+// HTMLTranslateFunc is similar to TranslateFunc except that it returns the translation
+// as HTML for use in html/template views.
+//
+// The text of a translation is escaped unless the translation is marked "markup": true.
+// Template data is escaped for its context unless it is a template.HTML.
+type HTMLTranslateFunc func(translationID string, args ...interface{}) template.HTML
+
 // IdentityTfunc returns a TranslateFunc that always returns the translationID passed to it.
 //
 // It is a useful placeholder when parsing a text/template or html/template
@@ -103,13 +167,60 @@ func AddTranslation(locale *locale.Locale, translations ...translation.Translati
 	defaultBundle.AddTranslation(locale, translations...)
 }
 
+// SetTranslations replaces all translations of a locale.
+//
+// It is safe to call while translate functions are in use, so translations
+// that are edited at runtime (e.g. in a database) can be reloaded.
+func SetTranslations(locale *locale.Locale, translations ...translation.Translation) {
+	defaultBundle.SetTranslations(locale, translations...)
+}
+
+// SetDefaultLocale sets the locale that every TranslateFunc falls back to
+// when a translation is missing from the locales passed to Tfunc.
+func SetDefaultLocale(localeID string) error {
+	return defaultBundle.SetDefaultLocale(localeID)
+}
+
+// SetPseudoLocale synthesizes the translations of a pseudo locale (e.g. en-XA)
+// from the translations of a source locale (e.g. en-US) by accenting their letters,
+// making them about 30% longer and wrapping them in brackets, so that
+// "Hello {{.Person}}" becomes "[Ĥéļļö {{.Person}}~~]".
+//
+// Testing a program in the pseudo locale shows strings that are not translated
+// and layouts that break when translations are longer than the source strings.
+func SetPseudoLocale(pseudoLocaleID, sourceLocaleID string) error {
+	return defaultBundle.SetPseudoLocale(pseudoLocaleID, sourceLocaleID)
+}
+
+// SetMissingTranslationHandler sets a function that is called with the requested locale
+// and the translation id when the locale and its parents have no translation.
+//
+// It is useful for reporting missing translations.
+func SetMissingTranslationHandler(f func(localeID, translationID string)) {
+	defaultBundle.SetMissingTranslationHandler(f)
+}
+
 // MustTfunc is similar to Tfunc except it panics if an error happens.
 func MustTfunc(localeID string, localeIDs ...string) TranslateFunc {
 	return TranslateFunc(defaultBundle.MustTfunc(localeID, localeIDs...))
 }
 
 // Tfunc returns a TranslateFunc that will be bound to the first valid locale from its parameters.
+//
+// Translations missing from that locale and its parents are looked up
+// in the remaining valid locales and then in the default locale.
 func Tfunc(localeID string, localeIDs ...string) (TranslateFunc, error) {
 	tf, err := defaultBundle.Tfunc(localeID, localeIDs...)
 	return TranslateFunc(tf), err
 }
+
+// MustHTMLTfunc is similar to HTMLTfunc except it panics if an error happens.
+func MustHTMLTfunc(localeID string, localeIDs ...string) HTMLTranslateFunc {
+	return HTMLTranslateFunc(defaultBundle.MustHTMLTfunc(localeID, localeIDs...))
+}
+
+// HTMLTfunc is similar to Tfunc except it returns an HTMLTranslateFunc.
+func HTMLTfunc(localeID string, localeIDs ...string) (HTMLTranslateFunc, error) {
+	tf, err := defaultBundle.HTMLTfunc(localeID, localeIDs...)
+	return HTMLTranslateFunc(tf), err
+}
@@ -0,0 +1,288 @@
+// Command codegen generates the plural rules of the language package
+// from the CLDR plurals.xml and ordinals.xml supplemental data files.
+//
+// It is run by go generate in the language package:
+//
+//	go generate github.com/goinggo/beego-mgo/go-i18n/i18n/language
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `codegen generates Go code that implements CLDR plural rules.
+
+Usage:
+
+    codegen [options]
+
+Options:
+
+`)
+	flag.PrintDefaults()
+	os.Exit(1)
+}
+
+func main() {
+	flag.Usage = usage
+	plurals := flag.String("plurals", "testdata/plurals.xml", "the CLDR file of cardinal plural rules")
+	ordinals := flag.String("ordinals", "testdata/ordinals.xml", "the CLDR file of ordinal plural rules")
+	cout := flag.String("cout", "plural_gen.go", "the code output file")
+	tout := flag.String("tout", "plural_gen_test.go", "the test output file")
+	flag.Parse()
+
+	if err := generate(*plurals, *ordinals, *cout, *tout); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+// rules are the cardinal and ordinal plural rules of all languages.
+type rules struct {
+	Cardinals []group
+	Ordinals  []group
+}
+
+// group is a PluralGroup prepared for the templates.
+type group struct {
+	Locales     []string
+	Categories  []string
+	PluralForms string
+	Rules       []rule
+}
+
+type rule struct {
+	Category    string
+	Condition   string
+	GoCondition string
+	Integers    []string
+	Decimals    []string
+}
+
+func generate(plurals, ordinals, cout, tout string) error {
+	var r rules
+	var err error
+	if r.Cardinals, err = readGroups(plurals, "cardinal"); err != nil {
+		return err
+	}
+	if r.Ordinals, err = readGroups(ordinals, "ordinal"); err != nil {
+		return err
+	}
+
+	// Ordinal rules are added to the languages that the cardinal rules register.
+	cardinalLocales := make(map[string]bool)
+	for _, g := range r.Cardinals {
+		for _, locale := range g.Locales {
+			cardinalLocales[locale] = true
+		}
+	}
+	for _, g := range r.Ordinals {
+		for _, locale := range g.Locales {
+			if !cardinalLocales[locale] {
+				return fmt.Errorf("%s: locale %s has no cardinal plural rules", ordinals, locale)
+			}
+		}
+	}
+
+	if err := writeTemplate(cout, codeTemplate, r); err != nil {
+		return err
+	}
+	return writeTemplate(tout, testTemplate, r)
+}
+
+// readGroups reads the plural groups of filename, which must contain plural rules of pluralType.
+func readGroups(filename, pluralType string) ([]group, error) {
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var data SupplementalData
+	if err := xml.Unmarshal(buf, &data); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	if data.Plurals.Type != pluralType {
+		return nil, fmt.Errorf("%s: plural rules are %s; expected %s", filename, data.Plurals.Type, pluralType)
+	}
+
+	pluralGroups := data.Plurals.PluralGroups
+	if pluralType == "cardinal" {
+		pluralGroups = applyOverrides(pluralGroups, cardinalOverrides)
+	}
+
+	groups := make([]group, 0, len(pluralGroups))
+	for _, pg := range pluralGroups {
+		g := group{Locales: pg.SplitLocales(), Categories: pg.Categories()}
+		if pluralType == "cardinal" {
+			if g.PluralForms, err = pg.GettextPluralForms(); err != nil {
+				return nil, fmt.Errorf("%s: %s", filename, err)
+			}
+		}
+		for _, pr := range pg.PluralRules {
+			goCondition, err := pr.GoCondition()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", filename, err)
+			}
+			r := rule{Category: pr.Count, Condition: pr.Condition(), GoCondition: goCondition}
+			r.Integers, r.Decimals = pr.Samples()
+			g.Rules = append(g.Rules, r)
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// cardinalOverrides are the cardinal rules that go-i18n uses instead of the CLDR rules
+// of their locales, so that they survive replacing the CLDR data with a new release.
+//
+// pt keeps the European Portuguese rule and pt-BR the Brazilian rule of CLDR 24,
+// which is what translations written for go-i18n expect.
+var cardinalOverrides = []PluralGroup{
+	{
+		Locales: "pt",
+		PluralRules: []PluralRule{
+			{Count: "one", Rule: "i = 1 and v = 0 @integer 1"},
+			{Count: "other", Rule: " @integer 0, 2~16, 100, 1000, 10000, 100000, 1000000, … @decimal 0.0~1.5, 10.0, 100.0, 1000.0, 10000.0, 100000.0, 1000000.0, …"},
+		},
+	},
+	{
+		Locales: "pt_BR",
+		PluralRules: []PluralRule{
+			{Count: "one", Rule: "i = 1 and v = 0 or i = 0 and t = 1 @integer 1 @decimal 0.1, 0.01, 0.10, 0.001, 0.010, 0.100"},
+			{Count: "other", Rule: " @integer 0, 2~16, 100, 1000, 10000, 100000, 1000000, … @decimal 0.0, 0.2~1.5, 10.0, 100.0, 1000.0, 10000.0, 100000.0, 1000000.0, …"},
+		},
+	},
+}
+
+// applyOverrides removes the locales of overrides from the CLDR groups,
+// drops the groups that are left without locales and appends overrides.
+func applyOverrides(groups, overrides []PluralGroup) []PluralGroup {
+	overridden := make(map[string]bool)
+	for _, pg := range overrides {
+		for _, locale := range strings.Fields(pg.Locales) {
+			overridden[locale] = true
+		}
+	}
+
+	result := make([]PluralGroup, 0, len(groups)+len(overrides))
+	for _, pg := range groups {
+		var locales []string
+		for _, locale := range strings.Fields(pg.Locales) {
+			if !overridden[locale] {
+				locales = append(locales, locale)
+			}
+		}
+		if len(locales) == 0 {
+			continue
+		}
+		pg.Locales = strings.Join(locales, " ")
+		result = append(result, pg)
+	}
+	return append(result, overrides...)
+}
+
+func writeTemplate(filename string, t *template.Template, r rules) error {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, r); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to format %s because %s", filename, err)
+	}
+	return ioutil.WriteFile(filename, src, 0666)
+}
+
+var funcs = template.FuncMap{
+	"category": func(c string) string {
+		return "plural." + strings.Title(c)
+	},
+	"categories": func(categories []string) string {
+		names := make([]string, len(categories))
+		for i, c := range categories {
+			names[i] = "plural." + strings.Title(c)
+		}
+		return strings.Join(names, ", ")
+	},
+	"usesMath": func(r rules) bool {
+		for _, groups := range [][]group{r.Cardinals, r.Ordinals} {
+			for _, g := range groups {
+				for _, rule := range g.Rules {
+					if strings.Contains(rule.GoCondition, "math.") {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	},
+}
+
+var codeTemplate = template.Must(template.New("code").Funcs(funcs).Parse(`// This file is generated by i18n/language/codegen; DO NOT EDIT.
+
+package language
+
+import (
+{{- if usesMath .}}
+	"math"
+{{end}}
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
+)
+
+func init() {
+{{- range .Cardinals}}
+	registerPluralRules({{printf "%#v" .Locales}}, newSet({{categories .Categories}}), {{printf "%q" .PluralForms}}, {{template "func" .}})
+{{- end}}
+{{range .Ordinals}}
+	registerOrdinalRules({{printf "%#v" .Locales}}, newSet({{categories .Categories}}), {{template "func" .}})
+{{- end}}
+}
+
+{{define "func"}}func(ops *plural.Operands) plural.Category {
+{{- range .Rules}}{{if .GoCondition}}
+	// {{.Condition}}
+	if {{.GoCondition}} {
+		return {{category .Category}}
+	}
+{{- end}}{{end}}
+	return plural.Other
+}{{end}}
+`))
+
+var testTemplate = template.Must(template.New("test").Funcs(funcs).Parse(`// This file is generated by i18n/language/codegen; DO NOT EDIT.
+
+package language
+
+import (
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
+)
+
+var cardinalSampleTests = []sampleTest{
+{{- range .Cardinals}}{{template "samples" .}}{{end}}
+}
+
+var ordinalSampleTests = []sampleTest{
+{{- range .Ordinals}}{{template "samples" .}}{{end}}
+}
+
+{{define "samples"}}{{$locales := .Locales}}{{range .Rules}}
+	{
+		locales:  {{printf "%#v" $locales}},
+		category: {{category .Category}},
+		{{- if .Integers}}
+		integers: {{printf "%#v" .Integers}},
+		{{- end}}
+		{{- if .Decimals}}
+		decimals: {{printf "%#v" .Decimals}},
+		{{- end}}
+	},{{end}}{{end}}
+`))
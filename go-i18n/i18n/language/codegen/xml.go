@@ -0,0 +1,276 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SupplementalData is the root element of the CLDR plurals.xml and ordinals.xml files.
+type SupplementalData struct {
+	XMLName xml.Name `xml:"supplementalData"`
+	Plurals Plurals  `xml:"plurals"`
+}
+
+// Plurals are the cardinal or ordinal plural rules of all languages.
+type Plurals struct {
+	Type         string        `xml:"type,attr"`
+	PluralGroups []PluralGroup `xml:"pluralRules"`
+}
+
+// PluralGroup is a group of locales that share the same plural rules.
+type PluralGroup struct {
+	Locales     string       `xml:"locales,attr"`
+	PluralRules []PluralRule `xml:"pluralRule"`
+}
+
+// SplitLocales returns the ids of the locales in the group
+// in the form used by the language package (e.g. pt-PT).
+func (pg *PluralGroup) SplitLocales() []string {
+	locales := strings.Fields(pg.Locales)
+	for i, locale := range locales {
+		locales[i] = strings.Replace(locale, "_", "-", -1)
+	}
+	return locales
+}
+
+// Categories returns the plural categories of the group in CLDR order.
+func (pg *PluralGroup) Categories() []string {
+	categories := make([]string, 0, len(pg.PluralRules))
+	for _, pr := range pg.PluralRules {
+		categories = append(categories, pr.Count)
+	}
+	return categories
+}
+
+// GettextPluralForms returns the gettext Plural-Forms header of the rules of the group.
+// The index selected by the expression is the position of the category in CLDR order.
+//
+// Gettext only selects with integers, so i is n, v, w, f and t are 0
+// and categories that are only used by decimals (e.g. cs many) are never selected.
+func (pg *PluralGroup) GettextPluralForms() (string, error) {
+	expr := strconv.Itoa(len(pg.PluralRules) - 1)
+	for i := len(pg.PluralRules) - 1; i >= 0; i-- {
+		condition, err := pg.PluralRules[i].GettextCondition()
+		if err != nil {
+			return "", err
+		}
+		switch condition {
+		case "", "0":
+		case "1":
+			expr = strconv.Itoa(i)
+		default:
+			expr = fmt.Sprintf("%s ? %d : %s", condition, i, expr)
+		}
+	}
+	if strings.Contains(expr, "?") {
+		expr = "(" + expr + ")"
+	}
+	return fmt.Sprintf("nplurals=%d; plural=%s;", len(pg.PluralRules), expr), nil
+}
+
+// PluralRule is the rule of a single plural category.
+//
+// The rule is a condition followed by @integer and @decimal samples:
+//
+//	i = 1 and v = 0 @integer 1
+type PluralRule struct {
+	Count string `xml:"count,attr"`
+	Rule  string `xml:",innerxml"`
+}
+
+// Condition returns the condition of the rule or "" for the "other" rule.
+func (pr *PluralRule) Condition() string {
+	condition := pr.Rule
+	if i := strings.Index(condition, "@"); i >= 0 {
+		condition = condition[:i]
+	}
+	return strings.TrimSpace(condition)
+}
+
+// Samples returns the @integer and @decimal samples of the rule.
+// Ranges (e.g. 0~15) are not expanded and the trailing ellipsis is removed.
+func (pr *PluralRule) Samples() (integers []string, decimals []string) {
+	for _, part := range strings.Split(pr.Rule, "@")[1:] {
+		fields := strings.SplitN(strings.TrimSpace(part), " ", 2)
+		if len(fields) < 2 {
+			continue
+		}
+		var samples []string
+		for _, sample := range strings.Split(fields[1], ",") {
+			if sample = strings.TrimSpace(sample); sample != "" && sample != "…" {
+				samples = append(samples, sample)
+			}
+		}
+		switch fields[0] {
+		case "integer":
+			integers = samples
+		case "decimal":
+			decimals = samples
+		}
+	}
+	return integers, decimals
+}
+
+// relationMatcher matches a relation of a condition (e.g. n % 100 != 11..19).
+var relationMatcher = regexp.MustCompile(`^([nivwft])\s*(?:%\s*([0-9]+))?\s*(!=|=)\s*([0-9.,]+)$`)
+
+// GoCondition returns the condition of the rule as a Go expression
+// of the plural.Operands ops or "" if the rule has no condition.
+func (pr *PluralRule) GoCondition() (string, error) {
+	if pr.Condition() == "" {
+		return "", nil
+	}
+	var ors []string
+	for _, or := range strings.Split(pr.Condition(), " or ") {
+		var ands []string
+		for _, relation := range strings.Split(or, " and ") {
+			expr, err := goRelation(strings.TrimSpace(relation))
+			if err != nil {
+				return "", fmt.Errorf("%s: %s", pr.Condition(), err)
+			}
+			ands = append(ands, expr)
+		}
+		ors = append(ors, strings.Join(ands, " && "))
+	}
+	return strings.Join(ors, " ||\n"), nil
+}
+
+// goRelation returns a relation as a Go expression.
+//
+// Ranges of the operand n only contain integers,
+// so n = 0..1 is true for 1.0 but not for 0.5.
+func goRelation(relation string) (string, error) {
+	parts := relationMatcher.FindStringSubmatch(relation)
+	if parts == nil {
+		return "", fmt.Errorf("unsupported relation %q", relation)
+	}
+	operand, mod, op, values := parts[1], parts[2], parts[3], parts[4]
+
+	lhs := "ops." + strings.ToUpper(operand)
+	if mod != "" {
+		if operand == "n" {
+			lhs = fmt.Sprintf("math.Mod(%s, %s)", lhs, mod)
+		} else {
+			lhs = fmt.Sprintf("%s%%%s", lhs, mod)
+		}
+	}
+
+	if op == "!=" && !strings.ContainsAny(values, ",.") {
+		return fmt.Sprintf("%s != %s", lhs, values), nil
+	}
+
+	var matches []string
+	for _, value := range strings.Split(values, ",") {
+		bounds := strings.SplitN(value, "..", 2)
+		switch {
+		case len(bounds) == 1:
+			matches = append(matches, fmt.Sprintf("%s == %s", lhs, value))
+		case operand == "n":
+			matches = append(matches, fmt.Sprintf("nInRange(%s, %s, %s)", lhs, bounds[0], bounds[1]))
+		default:
+			matches = append(matches, fmt.Sprintf("%s >= %s && %s <= %s", lhs, bounds[0], lhs, bounds[1]))
+		}
+	}
+
+	if len(matches) == 1 && op == "=" {
+		return matches[0], nil
+	}
+	if len(matches) == 1 && operand == "n" {
+		return "!" + matches[0], nil
+	}
+	expr := "(" + strings.Join(matches, " || ") + ")"
+	if op == "!=" {
+		expr = "!" + expr
+	}
+	return expr, nil
+}
+
+// GettextCondition returns the condition of the rule as a C expression of the integer n
+// as gettext evaluates it, "1" or "0" if the condition is always or never true for integers,
+// or "" if the rule has no condition.
+func (pr *PluralRule) GettextCondition() (string, error) {
+	if pr.Condition() == "" {
+		return "", nil
+	}
+	var ors []string
+	for _, or := range strings.Split(pr.Condition(), " or ") {
+		var ands []string
+		for _, relation := range strings.Split(or, " and ") {
+			expr, err := gettextRelation(strings.TrimSpace(relation))
+			if err != nil {
+				return "", fmt.Errorf("%s: %s", pr.Condition(), err)
+			}
+			if expr == "0" {
+				ands = []string{"0"}
+				break
+			}
+			if expr != "1" {
+				ands = append(ands, expr)
+			}
+		}
+		switch {
+		case len(ands) == 0:
+			return "1", nil
+		case ands[0] != "0":
+			ors = append(ors, strings.Join(ands, " && "))
+		}
+	}
+	if len(ors) == 0 {
+		return "0", nil
+	}
+	return strings.Join(ors, " || "), nil
+}
+
+// gettextRelation returns a relation as a C expression of the integer n,
+// or "1" or "0" if the relation is about the fraction digits, which are always 0.
+func gettextRelation(relation string) (string, error) {
+	parts := relationMatcher.FindStringSubmatch(relation)
+	if parts == nil {
+		return "", fmt.Errorf("unsupported relation %q", relation)
+	}
+	operand, mod, op, values := parts[1], parts[2], parts[3], parts[4]
+
+	if strings.ContainsAny(operand, "vwft") {
+		matched := false
+		for _, value := range strings.Split(values, ",") {
+			bounds := strings.SplitN(value, "..", 2)
+			if bounds[0] == "0" {
+				matched = true
+			}
+		}
+		if matched == (op == "=") {
+			return "1", nil
+		}
+		return "0", nil
+	}
+
+	lhs := "n"
+	if mod != "" {
+		lhs += "%" + mod
+	}
+
+	var matches []string
+	for _, value := range strings.Split(values, ",") {
+		bounds := strings.SplitN(value, "..", 2)
+		if len(bounds) == 1 {
+			matches = append(matches, fmt.Sprintf("%s==%s", lhs, value))
+			continue
+		}
+		matches = append(matches, fmt.Sprintf("%s>=%s && %s<=%s", lhs, bounds[0], lhs, bounds[1]))
+	}
+
+	if len(matches) == 1 && op == "=" {
+		return matches[0], nil
+	}
+	if len(matches) == 1 && len(strings.Split(values, "..")) == 1 {
+		return fmt.Sprintf("%s!=%s", lhs, values), nil
+	}
+	expr := "(" + strings.Join(matches, " || ") + ")"
+	if op == "!=" {
+		expr = "!" + expr
+	}
+	return expr, nil
+}
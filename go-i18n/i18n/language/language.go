@@ -1,7 +1,12 @@
 // Package language defines languages that implement CLDR pluralization.
 package language
 
+//go:generate go run ./codegen -plurals testdata/plurals.xml -ordinals testdata/ordinals.xml -cout plural_gen.go -tout plural_gen_test.go
+
 import (
+	"math"
+	"strings"
+
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
 )
 
@@ -16,206 +21,45 @@ import (
 // A Language implements CLDR plural rules as defined here:
 // http://www.unicode.org/cldr/charts/latest/supplemental/language_plural_rules.html
 // http://unicode.org/reports/tr35/tr35-numbers.html#Operands
+//
+// The ordinal rules select the category of a position (e.g. 1st, 2nd, 3rd)
+// instead of a quantity.
+//
+// PluralForms is the gettext Plural-Forms header of the plural rules
+// (e.g. nplurals=2; plural=(n==1 ? 0 : 1);), which selects the
+// plural categories by their position in CLDR order.
+//
+// The rules of all CLDR languages are generated from the CLDR data
+// in testdata by running go generate.
 type Language struct {
-	ID               string
-	Name             string
-	PluralCategories map[plural.Category]struct{}
-	PluralFunc       func(*plural.Operands) plural.Category
+	ID                string
+	Name              string
+	PluralCategories  map[plural.Category]struct{}
+	PluralFunc        func(*plural.Operands) plural.Category
+	PluralForms       string
+	OrdinalCategories map[plural.Category]struct{}
+	OrdinalFunc       func(*plural.Operands) plural.Category
 }
 
-// Alphabetical by English name.
-var languages = map[string]*Language{
-	// Arabic
-	"ar": &Language{
-		ID:               "ar",
-		PluralCategories: newSet(plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.W == 0 {
-				// Integer case
-				switch ops.I {
-				case 0:
-					return plural.Zero
-				case 1:
-					return plural.One
-				case 2:
-					return plural.Two
-				default:
-					mod100 := ops.I % 100
-					if mod100 >= 3 && mod100 <= 10 {
-						return plural.Few
-					}
-					if mod100 >= 11 {
-						return plural.Many
-					}
-				}
-			}
-			return plural.Other
-		},
-	},
-
-	// Catalan
-	"ca": &Language{
-		ID:               "ca",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// Chinese
-	// There is no need to distinguish between simplified and traditional
-	// since they have the same pluralization.
-	"zh": &Language{
-		ID:               "zh",
-		PluralCategories: newSet(plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			return plural.Other
-		},
-	},
-
-	// Czech
-	"cs": &Language{
-		ID:               "cs",
-		PluralCategories: newSet(plural.One, plural.Few, plural.Many, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			if ops.I >= 2 && ops.I <= 4 && ops.V == 0 {
-				return plural.Few
-			}
-			if ops.V > 0 {
-				return plural.Many
-			}
-			return plural.Other
-		},
-	},
-
-	// Danish
-	"da": &Language{
-		ID:               "da",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 || (ops.I == 0 && ops.T != 0) {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// Dutch
-	"nl": &Language{
-		ID:               "nl",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// English
-	"en": &Language{
-		ID:               "en",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// French
-	"fr": &Language{
-		ID:               "fr",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 0 || ops.I == 1 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// German
-	"de": &Language{
-		ID:               "de",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// Italian
-	"it": &Language{
-		ID:               "it",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// Japanese
-	"ja": &Language{
-		ID:               "ja",
-		PluralCategories: newSet(plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			return plural.Other
-		},
-	},
-
-	// Portuguese (European)
-	"pt": &Language{
-		ID:               "pt",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// Portuguese (Brazilian)
-	"pt-BR": &Language{
-		ID:               "pt-BR",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if (ops.I == 1 && ops.V == 0) || (ops.I == 0 && ops.T == 1) {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// Spanish
-	"es": &Language{
-		ID:               "es",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.W == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-}
+var languages = make(map[string]*Language)
 
 // LanguageWithID returns the language identified by id
 // or nil if the language is not registered.
+//
+// If id is not registered, subtags are removed from the end of id
+// until a registered language is found (e.g. pt-AO uses pt).
 func LanguageWithID(id string) *Language {
-	return languages[id]
+	id = strings.Replace(id, "_", "-", -1)
+	for {
+		if l := languages[id]; l != nil {
+			return l
+		}
+		end := strings.LastIndex(id, "-")
+		if end == -1 {
+			return nil
+		}
+		id = id[:end]
+	}
 }
 
 // Register adds Language l to the collection of available languages.
@@ -233,10 +77,59 @@ func (l *Language) PluralCategory(number interface{}) (plural.Category, error) {
 	return l.PluralFunc(ops), nil
 }
 
+// OrdinalCategory returns the ordinal plural category for number (e.g. "few" for the 3 of 3rd in English)
+// as defined by the language's CLDR ordinal rules.
+// A language without ordinal rules always returns plural.Other.
+func (l *Language) OrdinalCategory(number interface{}) (plural.Category, error) {
+	ops, err := plural.NewOperands(number)
+	if err != nil {
+		return plural.Invalid, err
+	}
+	if l.OrdinalFunc == nil {
+		return plural.Other, nil
+	}
+	return l.OrdinalFunc(ops), nil
+}
+
 func (l *Language) String() string {
 	return l.ID
 }
 
+// registerPluralRules registers a language for each of ids with the cardinal plural rules
+// pluralCategories and pluralFunc and their gettext pluralForms. The languages have no
+// ordinal rules other than plural.Other until registerOrdinalRules is called.
+func registerPluralRules(ids []string, pluralCategories map[plural.Category]struct{}, pluralForms string, pluralFunc func(*plural.Operands) plural.Category) {
+	for _, id := range ids {
+		Register(&Language{
+			ID:                id,
+			PluralCategories:  pluralCategories,
+			PluralFunc:        pluralFunc,
+			PluralForms:       pluralForms,
+			OrdinalCategories: newSet(plural.Other),
+			OrdinalFunc:       otherFunc,
+		})
+	}
+}
+
+// registerOrdinalRules sets the ordinal plural rules of the registered languages ids.
+func registerOrdinalRules(ids []string, ordinalCategories map[plural.Category]struct{}, ordinalFunc func(*plural.Operands) plural.Category) {
+	for _, id := range ids {
+		l := languages[id]
+		l.OrdinalCategories = ordinalCategories
+		l.OrdinalFunc = ordinalFunc
+	}
+}
+
+func otherFunc(ops *plural.Operands) plural.Category {
+	return plural.Other
+}
+
+// nInRange reports whether n is an integer in the range from..to
+// as a range of the operand n in a CLDR plural rule is defined.
+func nInRange(n, from, to float64) bool {
+	return n == math.Trunc(n) && from <= n && n <= to
+}
+
 func newSet(pluralCategories ...plural.Category) map[plural.Category]struct{} {
 	set := make(map[plural.Category]struct{}, len(pluralCategories))
 	for _, pc := range pluralCategories {
@@ -3,6 +3,11 @@ package language
 import (
 	"fmt"
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
+	"go/token"
+	"go/types"
+	"math/big"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -216,6 +221,19 @@ func TestPortugueseBrazilian(t *testing.T) {
 	runTests(t, LanguageWithID("pt-BR"), tests)
 }
 
+func TestPortuguesePortugal(t *testing.T) {
+	tests := []pluralTest{
+		{0, plural.Other},
+		{"0.1", plural.Other},
+		{1, plural.One},
+		{"1.0", plural.Other},
+		{"1.1", plural.Other},
+		{2, plural.Other},
+	}
+	tests = appendFloatTests(tests, 2.0, 10.0, plural.Other)
+	runTests(t, LanguageWithID("pt-PT"), tests)
+}
+
 func TestSpanish(t *testing.T) {
 	tests := []pluralTest{
 		{0, plural.Other},
@@ -254,3 +272,265 @@ func runTests(t *testing.T, language *Language, tests []pluralTest) {
 		}
 	}
 }
+
+// sampleTest is a plural rule's samples from the CLDR data in testdata.
+// Samples may be ranges (e.g. 0~15 or 0.00~0.04).
+type sampleTest struct {
+	locales  []string
+	category plural.Category
+	integers []string
+	decimals []string
+}
+
+func TestCardinalSamples(t *testing.T) {
+	runSampleTests(t, cardinalSampleTests, func(l *Language) (map[plural.Category]struct{}, func(*plural.Operands) plural.Category) {
+		return l.PluralCategories, l.PluralFunc
+	})
+}
+
+func TestOrdinalSamples(t *testing.T) {
+	runSampleTests(t, ordinalSampleTests, func(l *Language) (map[plural.Category]struct{}, func(*plural.Operands) plural.Category) {
+		return l.OrdinalCategories, l.OrdinalFunc
+	})
+}
+
+func runSampleTests(t *testing.T, tests []sampleTest, rules func(*Language) (map[plural.Category]struct{}, func(*plural.Operands) plural.Category)) {
+	for _, test := range tests {
+		var nums []interface{}
+		for _, sample := range test.integers {
+			for _, s := range expandSample(t, sample) {
+				i, err := strconv.ParseInt(s, 10, 64)
+				if err != nil {
+					t.Fatal(err)
+				}
+				nums = append(nums, s, i)
+			}
+		}
+		for _, sample := range test.decimals {
+			for _, s := range expandSample(t, sample) {
+				nums = append(nums, s)
+			}
+		}
+
+		for _, id := range test.locales {
+			l := LanguageWithID(id)
+			if l == nil || l.ID != id {
+				t.Errorf("LanguageWithID(%q) returned %v", id, l)
+				continue
+			}
+			categories, pluralFunc := rules(l)
+			if _, ok := categories[test.category]; !ok {
+				t.Errorf("%s: %s is not one of the plural categories", id, test.category)
+			}
+			for _, num := range nums {
+				ops, err := plural.NewOperands(num)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if pc := pluralFunc(ops); pc != test.category {
+					t.Errorf("%s: %#v returned %s; expected %s", id, num, pc, test.category)
+				}
+			}
+		}
+	}
+}
+
+// expandSample returns the numbers of a CLDR sample, formatted
+// with the number of fraction digits of the first number in the range.
+func expandSample(t *testing.T, sample string) []string {
+	bounds := strings.SplitN(sample, "~", 2)
+	if len(bounds) == 1 {
+		return bounds
+	}
+	digits := 0
+	if i := strings.Index(bounds[0], "."); i >= 0 {
+		digits = len(bounds[0]) - i - 1
+	}
+	from, err := strconv.ParseInt(strings.Replace(bounds[0], ".", "", 1), 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := strconv.ParseInt(strings.Replace(bounds[1], ".", "", 1), 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var samples []string
+	for n := from; n <= to; n++ {
+		s := strconv.FormatInt(n, 10)
+		if digits > 0 {
+			s = fmt.Sprintf("%0*d", digits+1, n)
+			s = s[:len(s)-digits] + "." + s[len(s)-digits:]
+		}
+		samples = append(samples, s)
+	}
+	return samples
+}
+
+func TestLanguageWithID(t *testing.T) {
+	tests := []struct {
+		id     string
+		wantID string
+	}{
+		{"en", "en"},
+		{"en-US", "en"},
+		{"pt_BR", "pt-BR"},
+		{"pt-PT", "pt"},
+		{"pt-AO", "pt"},
+		{"zh-Hant-TW", "zh"},
+		{"ru", "ru"},
+		{"xx-YY", ""},
+	}
+	for _, test := range tests {
+		l := LanguageWithID(test.id)
+		if test.wantID == "" {
+			if l != nil {
+				t.Errorf("LanguageWithID(%q) returned %s; expected nil", test.id, l)
+			}
+			continue
+		}
+		if l == nil || l.ID != test.wantID {
+			t.Errorf("LanguageWithID(%q) returned %v; expected %s", test.id, l, test.wantID)
+		}
+	}
+}
+
+func TestPluralCategoryNumbers(t *testing.T) {
+	tests := []pluralTest{
+		{1.0, plural.One},
+		{1.5, plural.Other},
+		{float32(1), plural.One},
+		{big.NewFloat(1), plural.One},
+		{plural.NewCount(1), plural.One},
+		{plural.NewCount(1).WithDigits(1), plural.Other},
+		{plural.NewCount(1.0001).WithDigits(2), plural.Other},
+		{plural.NewCount(1.4).WithDigits(0), plural.One},
+	}
+	runTests(t, LanguageWithID("en"), tests)
+}
+
+func TestPluralCategoryLargeNumbers(t *testing.T) {
+	large, _ := new(big.Int).SetString("100000000000000000001", 10)
+	runTests(t, LanguageWithID("en"), []pluralTest{
+		{large, plural.Other},
+		{"100000000000000000001", plural.Other},
+		{1e30, plural.Other},
+	})
+	runTests(t, LanguageWithID("ru"), []pluralTest{
+		{large, plural.One},
+		{"100000000000000000011", plural.Many},
+		{"100000000000000000022", plural.Few},
+		{1e30, plural.Many},
+		{new(big.Int).Lsh(big.NewInt(1), 64), plural.Many},
+	})
+}
+
+func TestOrdinalCategory(t *testing.T) {
+	tests := []pluralTest{
+		{1, plural.One},
+		{2, plural.Two},
+		{3, plural.Few},
+		{4, plural.Other},
+		{11, plural.Other},
+		{12, plural.Other},
+		{13, plural.Other},
+		{"21", plural.One},
+		{"102", plural.Two},
+		{"1003", plural.Few},
+	}
+	en := LanguageWithID("en")
+	for _, test := range tests {
+		if pc, err := en.OrdinalCategory(test.num); pc != test.pc {
+			t.Errorf("OrdinalCategory(%#v) returned %s, %v; expected %s", test.num, pc, err, test.pc)
+		}
+	}
+
+	if pc, err := en.OrdinalCategory(true); err == nil {
+		t.Errorf("OrdinalCategory(true) returned %s, nil; expected an error", pc)
+	}
+
+	l := &Language{ID: "xx", PluralCategories: newSet(plural.Other), PluralFunc: otherFunc}
+	if pc, err := l.OrdinalCategory(1); pc != plural.Other || err != nil {
+		t.Errorf("OrdinalCategory(1) of a language without ordinal rules returned %s, %v; expected other", pc, err)
+	}
+}
+
+func TestPluralForms(t *testing.T) {
+	tests := []struct {
+		id          string
+		pluralForms string
+	}{
+		{"ja", "nplurals=1; plural=0;"},
+		{"en", "nplurals=2; plural=(n==1 ? 0 : 1);"},
+		{"fr", "nplurals=2; plural=((n==0 || n==1) ? 0 : 1);"},
+		{"pt-BR", "nplurals=2; plural=(n==1 ? 0 : 1);"},
+		{"ru", "nplurals=4; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && !(n%100>=12 && n%100<=14) ? 1 : n%10==0 || n%10>=5 && n%10<=9 || n%100>=11 && n%100<=14 ? 2 : 3);"},
+	}
+	for _, test := range tests {
+		if l := LanguageWithID(test.id); l.PluralForms != test.pluralForms {
+			t.Errorf("%s PluralForms = %q; expected %q", test.id, l.PluralForms, test.pluralForms)
+		}
+	}
+
+	// The gettext expression must select the category of the plural rules for every integer.
+	cldrOrder := []plural.Category{plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other}
+	for id, l := range languages {
+		var categories []plural.Category
+		for _, pc := range cldrOrder {
+			if _, ok := l.PluralCategories[pc]; ok {
+				categories = append(categories, pc)
+			}
+		}
+
+		var nplurals int
+		if _, err := fmt.Sscanf(l.PluralForms, "nplurals=%d;", &nplurals); err != nil || nplurals != len(categories) {
+			t.Errorf("%s PluralForms = %q; expected %d plurals", id, l.PluralForms, len(categories))
+			continue
+		}
+		expr := strings.TrimSuffix(l.PluralForms[strings.Index(l.PluralForms, "plural=")+len("plural="):], ";")
+
+		for n := int64(0); n <= 1000000; n = nextInteger(n) {
+			index := evalPluralForms(t, expr, n)
+			ops, _ := plural.NewOperands(n)
+			if pc := l.PluralFunc(ops); index >= len(categories) || categories[index] != pc {
+				t.Errorf("%s %s selects %d for %d; expected %s", id, l.PluralForms, index, n, pc)
+				break
+			}
+		}
+	}
+}
+
+// nextInteger returns every integer up to 200 and then a few larger ones.
+func nextInteger(n int64) int64 {
+	if n < 200 {
+		return n + 1
+	}
+	return n*10 + 1
+}
+
+// evalPluralForms returns the index that the gettext plural expression selects for n.
+// The expression is a chain of conditions (e.g. (n==1 ? 0 : n==2 ? 1 : 2)), which are Go expressions of n.
+func evalPluralForms(t *testing.T, expr string, n int64) int {
+	expr = strings.TrimSuffix(strings.TrimPrefix(expr, "("), ")")
+	num := strconv.FormatInt(n, 10)
+	for _, choice := range strings.Split(expr, " : ") {
+		parts := strings.Split(choice, " ? ")
+		if len(parts) == 1 {
+			index, err := strconv.Atoi(parts[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return index
+		}
+		tv, err := types.Eval(token.NewFileSet(), nil, token.NoPos, strings.Replace(parts[0], "n", num, -1))
+		if err != nil {
+			t.Fatalf("%s: %s", parts[0], err)
+		}
+		if tv.Value.String() == "true" {
+			index, _ := strconv.Atoi(parts[1])
+			return index
+		}
+	}
+	t.Fatalf("%s has no default index", expr)
+	return 0
+}
@@ -0,0 +1,717 @@
+// This file is generated by i18n/language/codegen; DO NOT EDIT.
+
+package language
+
+import (
+	"math"
+
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
+)
+
+func init() {
+	registerPluralRules([]string{"bm", "bo", "dz", "id", "ig", "ii", "in", "ja", "jbo", "jv", "jw", "kde", "kea", "km", "ko", "lkt", "lo", "ms", "my", "nqo", "root", "sah", "ses", "sg", "th", "to", "vi", "wo", "yo", "yue", "zh"}, newSet(plural.Other), "nplurals=1; plural=0;", func(ops *plural.Operands) plural.Category {
+		return plural.Other
+	})
+	registerPluralRules([]string{"am", "as", "bn", "fa", "gu", "hi", "kn", "mr", "zu"}, newSet(plural.One, plural.Other), "nplurals=2; plural=(n==0 || n==1 ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// i = 0 or n = 1
+		if ops.I == 0 ||
+			ops.N == 1 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"ff", "fr", "hy", "kab"}, newSet(plural.One, plural.Other), "nplurals=2; plural=((n==0 || n==1) ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// i = 0,1
+		if ops.I == 0 || ops.I == 1 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"ast", "ca", "de", "en", "et", "fi", "fy", "gl", "it", "ji", "nl", "sv", "sw", "ur", "yi"}, newSet(plural.One, plural.Other), "nplurals=2; plural=(n==1 ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// i = 1 and v = 0
+		if ops.I == 1 && ops.V == 0 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"si"}, newSet(plural.One, plural.Other), "nplurals=2; plural=((n==0 || n==1) ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// n = 0,1 or i = 0 and f = 1
+		if (ops.N == 0 || ops.N == 1) ||
+			ops.I == 0 && ops.F == 1 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"ak", "bh", "guw", "ln", "mg", "nso", "pa", "ti", "wa"}, newSet(plural.One, plural.Other), "nplurals=2; plural=(n>=0 && n<=1 ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// n = 0..1
+		if nInRange(ops.N, 0, 1) {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"tzm"}, newSet(plural.One, plural.Other), "nplurals=2; plural=(n>=0 && n<=1 || n>=11 && n<=99 ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// n = 0..1 or n = 11..99
+		if nInRange(ops.N, 0, 1) ||
+			nInRange(ops.N, 11, 99) {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"af", "asa", "az", "bem", "bez", "bg", "brx", "ce", "cgg", "chr", "ckb", "dv", "ee", "el", "eo", "es", "eu", "fo", "fur", "gsw", "ha", "haw", "hu", "jgo", "jmc", "ka", "kaj", "kcg", "kk", "kkj", "kl", "ks", "ksb", "ku", "ky", "lb", "lg", "mas", "mgo", "ml", "mn", "nah", "nb", "nd", "ne", "nn", "nnh", "no", "nr", "ny", "nyn", "om", "or", "os", "pap", "ps", "rm", "rof", "rwk", "saq", "sdh", "seh", "sn", "so", "sq", "ss", "ssy", "st", "syr", "ta", "te", "teo", "tig", "tk", "tn", "tr", "ts", "ug", "uz", "ve", "vo", "vun", "wae", "xh", "xog"}, newSet(plural.One, plural.Other), "nplurals=2; plural=(n==1 ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// n = 1
+		if ops.N == 1 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"da"}, newSet(plural.One, plural.Other), "nplurals=2; plural=(n==1 ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// n = 1 or t != 0 and i = 0,1
+		if ops.N == 1 ||
+			ops.T != 0 && (ops.I == 0 || ops.I == 1) {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"is"}, newSet(plural.One, plural.Other), "nplurals=2; plural=(n%10==1 && n%100!=11 ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// t = 0 and i % 10 = 1 and i % 100 != 11 or t != 0
+		if ops.T == 0 && ops.I%10 == 1 && ops.I%100 != 11 ||
+			ops.T != 0 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"mk"}, newSet(plural.One, plural.Other), "nplurals=2; plural=(n%10==1 ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// v = 0 and i % 10 = 1 or f % 10 = 1
+		if ops.V == 0 && ops.I%10 == 1 ||
+			ops.F%10 == 1 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"fil", "tl"}, newSet(plural.One, plural.Other), "nplurals=2; plural=((n==1 || n==2 || n==3) || !(n%10==4 || n%10==6 || n%10==9) ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// v = 0 and i = 1,2,3 or v = 0 and i % 10 != 4,6,9 or v != 0 and f % 10 != 4,6,9
+		if ops.V == 0 && (ops.I == 1 || ops.I == 2 || ops.I == 3) ||
+			ops.V == 0 && !(ops.I%10 == 4 || ops.I%10 == 6 || ops.I%10 == 9) ||
+			ops.V != 0 && !(ops.F%10 == 4 || ops.F%10 == 6 || ops.F%10 == 9) {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"lv", "prg"}, newSet(plural.Zero, plural.One, plural.Other), "nplurals=3; plural=(n%10==0 || n%100>=11 && n%100<=19 ? 0 : n%10==1 && n%100!=11 ? 1 : 2);", func(ops *plural.Operands) plural.Category {
+		// n % 10 = 0 or n % 100 = 11..19 or v = 2 and f % 100 = 11..19
+		if math.Mod(ops.N, 10) == 0 ||
+			nInRange(math.Mod(ops.N, 100), 11, 19) ||
+			ops.V == 2 && ops.F%100 >= 11 && ops.F%100 <= 19 {
+			return plural.Zero
+		}
+		// n % 10 = 1 and n % 100 != 11 or v = 2 and f % 10 = 1 and f % 100 != 11 or v != 2 and f % 10 = 1
+		if math.Mod(ops.N, 10) == 1 && math.Mod(ops.N, 100) != 11 ||
+			ops.V == 2 && ops.F%10 == 1 && ops.F%100 != 11 ||
+			ops.V != 2 && ops.F%10 == 1 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"lag"}, newSet(plural.Zero, plural.One, plural.Other), "nplurals=3; plural=(n==0 ? 0 : (n==0 || n==1) && n!=0 ? 1 : 2);", func(ops *plural.Operands) plural.Category {
+		// n = 0
+		if ops.N == 0 {
+			return plural.Zero
+		}
+		// i = 0,1 and n != 0
+		if (ops.I == 0 || ops.I == 1) && ops.N != 0 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"ksh"}, newSet(plural.Zero, plural.One, plural.Other), "nplurals=3; plural=(n==0 ? 0 : n==1 ? 1 : 2);", func(ops *plural.Operands) plural.Category {
+		// n = 0
+		if ops.N == 0 {
+			return plural.Zero
+		}
+		// n = 1
+		if ops.N == 1 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"iu", "kw", "naq", "se", "sma", "smi", "smj", "smn", "sms"}, newSet(plural.One, plural.Two, plural.Other), "nplurals=3; plural=(n==1 ? 0 : n==2 ? 1 : 2);", func(ops *plural.Operands) plural.Category {
+		// n = 1
+		if ops.N == 1 {
+			return plural.One
+		}
+		// n = 2
+		if ops.N == 2 {
+			return plural.Two
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"shi"}, newSet(plural.One, plural.Few, plural.Other), "nplurals=3; plural=(n==0 || n==1 ? 0 : n>=2 && n<=10 ? 1 : 2);", func(ops *plural.Operands) plural.Category {
+		// i = 0 or n = 1
+		if ops.I == 0 ||
+			ops.N == 1 {
+			return plural.One
+		}
+		// n = 2..10
+		if nInRange(ops.N, 2, 10) {
+			return plural.Few
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"mo", "ro"}, newSet(plural.One, plural.Few, plural.Other), "nplurals=3; plural=(n==1 ? 0 : n==0 || n!=1 && n%100>=1 && n%100<=19 ? 1 : 2);", func(ops *plural.Operands) plural.Category {
+		// i = 1 and v = 0
+		if ops.I == 1 && ops.V == 0 {
+			return plural.One
+		}
+		// v != 0 or n = 0 or n != 1 and n % 100 = 1..19
+		if ops.V != 0 ||
+			ops.N == 0 ||
+			ops.N != 1 && nInRange(math.Mod(ops.N, 100), 1, 19) {
+			return plural.Few
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"bs", "hr", "sh", "sr"}, newSet(plural.One, plural.Few, plural.Other), "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && !(n%100>=12 && n%100<=14) ? 1 : 2);", func(ops *plural.Operands) plural.Category {
+		// v = 0 and i % 10 = 1 and i % 100 != 11 or f % 10 = 1 and f % 100 != 11
+		if ops.V == 0 && ops.I%10 == 1 && ops.I%100 != 11 ||
+			ops.F%10 == 1 && ops.F%100 != 11 {
+			return plural.One
+		}
+		// v = 0 and i % 10 = 2..4 and i % 100 != 12..14 or f % 10 = 2..4 and f % 100 != 12..14
+		if ops.V == 0 && ops.I%10 >= 2 && ops.I%10 <= 4 && !(ops.I%100 >= 12 && ops.I%100 <= 14) ||
+			ops.F%10 >= 2 && ops.F%10 <= 4 && !(ops.F%100 >= 12 && ops.F%100 <= 14) {
+			return plural.Few
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"gd"}, newSet(plural.One, plural.Two, plural.Few, plural.Other), "nplurals=4; plural=((n==1 || n==11) ? 0 : (n==2 || n==12) ? 1 : (n>=3 && n<=10 || n>=13 && n<=19) ? 2 : 3);", func(ops *plural.Operands) plural.Category {
+		// n = 1,11
+		if ops.N == 1 || ops.N == 11 {
+			return plural.One
+		}
+		// n = 2,12
+		if ops.N == 2 || ops.N == 12 {
+			return plural.Two
+		}
+		// n = 3..10,13..19
+		if nInRange(ops.N, 3, 10) || nInRange(ops.N, 13, 19) {
+			return plural.Few
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"sl"}, newSet(plural.One, plural.Two, plural.Few, plural.Other), "nplurals=4; plural=(n%100==1 ? 0 : n%100==2 ? 1 : n%100>=3 && n%100<=4 ? 2 : 3);", func(ops *plural.Operands) plural.Category {
+		// v = 0 and i % 100 = 1
+		if ops.V == 0 && ops.I%100 == 1 {
+			return plural.One
+		}
+		// v = 0 and i % 100 = 2
+		if ops.V == 0 && ops.I%100 == 2 {
+			return plural.Two
+		}
+		// v = 0 and i % 100 = 3..4 or v != 0
+		if ops.V == 0 && ops.I%100 >= 3 && ops.I%100 <= 4 ||
+			ops.V != 0 {
+			return plural.Few
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"dsb", "hsb"}, newSet(plural.One, plural.Two, plural.Few, plural.Other), "nplurals=4; plural=(n%100==1 ? 0 : n%100==2 ? 1 : n%100>=3 && n%100<=4 ? 2 : 3);", func(ops *plural.Operands) plural.Category {
+		// v = 0 and i % 100 = 1 or f % 100 = 1
+		if ops.V == 0 && ops.I%100 == 1 ||
+			ops.F%100 == 1 {
+			return plural.One
+		}
+		// v = 0 and i % 100 = 2 or f % 100 = 2
+		if ops.V == 0 && ops.I%100 == 2 ||
+			ops.F%100 == 2 {
+			return plural.Two
+		}
+		// v = 0 and i % 100 = 3..4 or f % 100 = 3..4
+		if ops.V == 0 && ops.I%100 >= 3 && ops.I%100 <= 4 ||
+			ops.F%100 >= 3 && ops.F%100 <= 4 {
+			return plural.Few
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"he", "iw"}, newSet(plural.One, plural.Two, plural.Many, plural.Other), "nplurals=4; plural=(n==1 ? 0 : n==2 ? 1 : !(n>=0 && n<=10) && n%10==0 ? 2 : 3);", func(ops *plural.Operands) plural.Category {
+		// i = 1 and v = 0
+		if ops.I == 1 && ops.V == 0 {
+			return plural.One
+		}
+		// i = 2 and v = 0
+		if ops.I == 2 && ops.V == 0 {
+			return plural.Two
+		}
+		// v = 0 and n != 0..10 and n % 10 = 0
+		if ops.V == 0 && !nInRange(ops.N, 0, 10) && math.Mod(ops.N, 10) == 0 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"cs", "sk"}, newSet(plural.One, plural.Few, plural.Many, plural.Other), "nplurals=4; plural=(n==1 ? 0 : n>=2 && n<=4 ? 1 : 3);", func(ops *plural.Operands) plural.Category {
+		// i = 1 and v = 0
+		if ops.I == 1 && ops.V == 0 {
+			return plural.One
+		}
+		// i = 2..4 and v = 0
+		if ops.I >= 2 && ops.I <= 4 && ops.V == 0 {
+			return plural.Few
+		}
+		// v != 0
+		if ops.V != 0 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"pl"}, newSet(plural.One, plural.Few, plural.Many, plural.Other), "nplurals=4; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && !(n%100>=12 && n%100<=14) ? 1 : n!=1 && n%10>=0 && n%10<=1 || n%10>=5 && n%10<=9 || n%100>=12 && n%100<=14 ? 2 : 3);", func(ops *plural.Operands) plural.Category {
+		// i = 1 and v = 0
+		if ops.I == 1 && ops.V == 0 {
+			return plural.One
+		}
+		// v = 0 and i % 10 = 2..4 and i % 100 != 12..14
+		if ops.V == 0 && ops.I%10 >= 2 && ops.I%10 <= 4 && !(ops.I%100 >= 12 && ops.I%100 <= 14) {
+			return plural.Few
+		}
+		// v = 0 and i != 1 and i % 10 = 0..1 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 12..14
+		if ops.V == 0 && ops.I != 1 && ops.I%10 >= 0 && ops.I%10 <= 1 ||
+			ops.V == 0 && ops.I%10 >= 5 && ops.I%10 <= 9 ||
+			ops.V == 0 && ops.I%100 >= 12 && ops.I%100 <= 14 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"be"}, newSet(plural.One, plural.Few, plural.Many, plural.Other), "nplurals=4; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && !(n%100>=12 && n%100<=14) ? 1 : n%10==0 || n%10>=5 && n%10<=9 || n%100>=11 && n%100<=14 ? 2 : 3);", func(ops *plural.Operands) plural.Category {
+		// n % 10 = 1 and n % 100 != 11
+		if math.Mod(ops.N, 10) == 1 && math.Mod(ops.N, 100) != 11 {
+			return plural.One
+		}
+		// n % 10 = 2..4 and n % 100 != 12..14
+		if nInRange(math.Mod(ops.N, 10), 2, 4) && !nInRange(math.Mod(ops.N, 100), 12, 14) {
+			return plural.Few
+		}
+		// n % 10 = 0 or n % 10 = 5..9 or n % 100 = 11..14
+		if math.Mod(ops.N, 10) == 0 ||
+			nInRange(math.Mod(ops.N, 10), 5, 9) ||
+			nInRange(math.Mod(ops.N, 100), 11, 14) {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"lt"}, newSet(plural.One, plural.Few, plural.Many, plural.Other), "nplurals=4; plural=(n%10==1 && !(n%100>=11 && n%100<=19) ? 0 : n%10>=2 && n%10<=9 && !(n%100>=11 && n%100<=19) ? 1 : 3);", func(ops *plural.Operands) plural.Category {
+		// n % 10 = 1 and n % 100 != 11..19
+		if math.Mod(ops.N, 10) == 1 && !nInRange(math.Mod(ops.N, 100), 11, 19) {
+			return plural.One
+		}
+		// n % 10 = 2..9 and n % 100 != 11..19
+		if nInRange(math.Mod(ops.N, 10), 2, 9) && !nInRange(math.Mod(ops.N, 100), 11, 19) {
+			return plural.Few
+		}
+		// f != 0
+		if ops.F != 0 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"mt"}, newSet(plural.One, plural.Few, plural.Many, plural.Other), "nplurals=4; plural=(n==1 ? 0 : n==0 || n%100>=2 && n%100<=10 ? 1 : n%100>=11 && n%100<=19 ? 2 : 3);", func(ops *plural.Operands) plural.Category {
+		// n = 1
+		if ops.N == 1 {
+			return plural.One
+		}
+		// n = 0 or n % 100 = 2..10
+		if ops.N == 0 ||
+			nInRange(math.Mod(ops.N, 100), 2, 10) {
+			return plural.Few
+		}
+		// n % 100 = 11..19
+		if nInRange(math.Mod(ops.N, 100), 11, 19) {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"ru", "uk"}, newSet(plural.One, plural.Few, plural.Many, plural.Other), "nplurals=4; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && !(n%100>=12 && n%100<=14) ? 1 : n%10==0 || n%10>=5 && n%10<=9 || n%100>=11 && n%100<=14 ? 2 : 3);", func(ops *plural.Operands) plural.Category {
+		// v = 0 and i % 10 = 1 and i % 100 != 11
+		if ops.V == 0 && ops.I%10 == 1 && ops.I%100 != 11 {
+			return plural.One
+		}
+		// v = 0 and i % 10 = 2..4 and i % 100 != 12..14
+		if ops.V == 0 && ops.I%10 >= 2 && ops.I%10 <= 4 && !(ops.I%100 >= 12 && ops.I%100 <= 14) {
+			return plural.Few
+		}
+		// v = 0 and i % 10 = 0 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 11..14
+		if ops.V == 0 && ops.I%10 == 0 ||
+			ops.V == 0 && ops.I%10 >= 5 && ops.I%10 <= 9 ||
+			ops.V == 0 && ops.I%100 >= 11 && ops.I%100 <= 14 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"br"}, newSet(plural.One, plural.Two, plural.Few, plural.Many, plural.Other), "nplurals=5; plural=(n%10==1 && !(n%100==11 || n%100==71 || n%100==91) ? 0 : n%10==2 && !(n%100==12 || n%100==72 || n%100==92) ? 1 : (n%10>=3 && n%10<=4 || n%10==9) && !(n%100>=10 && n%100<=19 || n%100>=70 && n%100<=79 || n%100>=90 && n%100<=99) ? 2 : n!=0 && n%1000000==0 ? 3 : 4);", func(ops *plural.Operands) plural.Category {
+		// n % 10 = 1 and n % 100 != 11,71,91
+		if math.Mod(ops.N, 10) == 1 && !(math.Mod(ops.N, 100) == 11 || math.Mod(ops.N, 100) == 71 || math.Mod(ops.N, 100) == 91) {
+			return plural.One
+		}
+		// n % 10 = 2 and n % 100 != 12,72,92
+		if math.Mod(ops.N, 10) == 2 && !(math.Mod(ops.N, 100) == 12 || math.Mod(ops.N, 100) == 72 || math.Mod(ops.N, 100) == 92) {
+			return plural.Two
+		}
+		// n % 10 = 3..4,9 and n % 100 != 10..19,70..79,90..99
+		if (nInRange(math.Mod(ops.N, 10), 3, 4) || math.Mod(ops.N, 10) == 9) && !(nInRange(math.Mod(ops.N, 100), 10, 19) || nInRange(math.Mod(ops.N, 100), 70, 79) || nInRange(math.Mod(ops.N, 100), 90, 99)) {
+			return plural.Few
+		}
+		// n != 0 and n % 1000000 = 0
+		if ops.N != 0 && math.Mod(ops.N, 1000000) == 0 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"ga"}, newSet(plural.One, plural.Two, plural.Few, plural.Many, plural.Other), "nplurals=5; plural=(n==1 ? 0 : n==2 ? 1 : n>=3 && n<=6 ? 2 : n>=7 && n<=10 ? 3 : 4);", func(ops *plural.Operands) plural.Category {
+		// n = 1
+		if ops.N == 1 {
+			return plural.One
+		}
+		// n = 2
+		if ops.N == 2 {
+			return plural.Two
+		}
+		// n = 3..6
+		if nInRange(ops.N, 3, 6) {
+			return plural.Few
+		}
+		// n = 7..10
+		if nInRange(ops.N, 7, 10) {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"gv"}, newSet(plural.One, plural.Two, plural.Few, plural.Many, plural.Other), "nplurals=5; plural=(n%10==1 ? 0 : n%10==2 ? 1 : (n%100==0 || n%100==20 || n%100==40 || n%100==60 || n%100==80) ? 2 : 4);", func(ops *plural.Operands) plural.Category {
+		// v = 0 and i % 10 = 1
+		if ops.V == 0 && ops.I%10 == 1 {
+			return plural.One
+		}
+		// v = 0 and i % 10 = 2
+		if ops.V == 0 && ops.I%10 == 2 {
+			return plural.Two
+		}
+		// v = 0 and i % 100 = 0,20,40,60,80
+		if ops.V == 0 && (ops.I%100 == 0 || ops.I%100 == 20 || ops.I%100 == 40 || ops.I%100 == 60 || ops.I%100 == 80) {
+			return plural.Few
+		}
+		// v != 0
+		if ops.V != 0 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"ar", "ars"}, newSet(plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other), "nplurals=6; plural=(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n%100>=3 && n%100<=10 ? 3 : n%100>=11 && n%100<=99 ? 4 : 5);", func(ops *plural.Operands) plural.Category {
+		// n = 0
+		if ops.N == 0 {
+			return plural.Zero
+		}
+		// n = 1
+		if ops.N == 1 {
+			return plural.One
+		}
+		// n = 2
+		if ops.N == 2 {
+			return plural.Two
+		}
+		// n % 100 = 3..10
+		if nInRange(math.Mod(ops.N, 100), 3, 10) {
+			return plural.Few
+		}
+		// n % 100 = 11..99
+		if nInRange(math.Mod(ops.N, 100), 11, 99) {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"cy"}, newSet(plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other), "nplurals=6; plural=(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n==3 ? 3 : n==6 ? 4 : 5);", func(ops *plural.Operands) plural.Category {
+		// n = 0
+		if ops.N == 0 {
+			return plural.Zero
+		}
+		// n = 1
+		if ops.N == 1 {
+			return plural.One
+		}
+		// n = 2
+		if ops.N == 2 {
+			return plural.Two
+		}
+		// n = 3
+		if ops.N == 3 {
+			return plural.Few
+		}
+		// n = 6
+		if ops.N == 6 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"pt"}, newSet(plural.One, plural.Other), "nplurals=2; plural=(n==1 ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// i = 1 and v = 0
+		if ops.I == 1 && ops.V == 0 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerPluralRules([]string{"pt-BR"}, newSet(plural.One, plural.Other), "nplurals=2; plural=(n==1 ? 0 : 1);", func(ops *plural.Operands) plural.Category {
+		// i = 1 and v = 0 or i = 0 and t = 1
+		if ops.I == 1 && ops.V == 0 ||
+			ops.I == 0 && ops.T == 1 {
+			return plural.One
+		}
+		return plural.Other
+	})
+
+	registerOrdinalRules([]string{"af", "am", "ar", "bg", "bs", "ce", "cs", "da", "de", "dsb", "el", "es", "et", "eu", "fa", "fi", "fy", "gl", "gsw", "he", "hr", "hsb", "id", "in", "is", "iw", "ja", "km", "kn", "ko", "ky", "lt", "lv", "ml", "mn", "my", "nb", "nl", "pa", "pl", "prg", "ps", "pt", "root", "ru", "sh", "si", "sk", "sl", "sr", "sw", "ta", "te", "th", "tr", "ur", "uz", "yue", "zh", "zu"}, newSet(plural.Other), func(ops *plural.Operands) plural.Category {
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"sv"}, newSet(plural.One, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n % 10 = 1,2 and n % 100 != 11,12
+		if (math.Mod(ops.N, 10) == 1 || math.Mod(ops.N, 10) == 2) && !(math.Mod(ops.N, 100) == 11 || math.Mod(ops.N, 100) == 12) {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"fil", "fr", "ga", "hy", "lo", "mo", "ms", "ro", "tl", "vi"}, newSet(plural.One, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n = 1
+		if ops.N == 1 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"hu"}, newSet(plural.One, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n = 1,5
+		if ops.N == 1 || ops.N == 5 {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"ne"}, newSet(plural.One, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n = 1..4
+		if nInRange(ops.N, 1, 4) {
+			return plural.One
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"be"}, newSet(plural.Few, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n % 10 = 2,3 and n % 100 != 12,13
+		if (math.Mod(ops.N, 10) == 2 || math.Mod(ops.N, 10) == 3) && !(math.Mod(ops.N, 100) == 12 || math.Mod(ops.N, 100) == 13) {
+			return plural.Few
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"uk"}, newSet(plural.Few, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n % 10 = 3 and n % 100 != 13
+		if math.Mod(ops.N, 10) == 3 && math.Mod(ops.N, 100) != 13 {
+			return plural.Few
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"tk"}, newSet(plural.Few, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n % 10 = 6,9 or n = 10
+		if (math.Mod(ops.N, 10) == 6 || math.Mod(ops.N, 10) == 9) ||
+			ops.N == 10 {
+			return plural.Few
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"kk"}, newSet(plural.Many, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n % 10 = 6 or n % 10 = 9 or n % 10 = 0 and n != 0
+		if math.Mod(ops.N, 10) == 6 ||
+			math.Mod(ops.N, 10) == 9 ||
+			math.Mod(ops.N, 10) == 0 && ops.N != 0 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"it"}, newSet(plural.Many, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n = 11,8,80,800
+		if ops.N == 11 || ops.N == 8 || ops.N == 80 || ops.N == 800 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"ka"}, newSet(plural.One, plural.Many, plural.Other), func(ops *plural.Operands) plural.Category {
+		// i = 1
+		if ops.I == 1 {
+			return plural.One
+		}
+		// i = 0 or i % 100 = 2..20,40,60,80
+		if ops.I == 0 ||
+			(ops.I%100 >= 2 && ops.I%100 <= 20 || ops.I%100 == 40 || ops.I%100 == 60 || ops.I%100 == 80) {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"sq"}, newSet(plural.One, plural.Many, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n = 1
+		if ops.N == 1 {
+			return plural.One
+		}
+		// n % 10 = 4 and n % 100 != 14
+		if math.Mod(ops.N, 10) == 4 && math.Mod(ops.N, 100) != 14 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"en"}, newSet(plural.One, plural.Two, plural.Few, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n % 10 = 1 and n % 100 != 11
+		if math.Mod(ops.N, 10) == 1 && math.Mod(ops.N, 100) != 11 {
+			return plural.One
+		}
+		// n % 10 = 2 and n % 100 != 12
+		if math.Mod(ops.N, 10) == 2 && math.Mod(ops.N, 100) != 12 {
+			return plural.Two
+		}
+		// n % 10 = 3 and n % 100 != 13
+		if math.Mod(ops.N, 10) == 3 && math.Mod(ops.N, 100) != 13 {
+			return plural.Few
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"mr"}, newSet(plural.One, plural.Two, plural.Few, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n = 1
+		if ops.N == 1 {
+			return plural.One
+		}
+		// n = 2,3
+		if ops.N == 2 || ops.N == 3 {
+			return plural.Two
+		}
+		// n = 4
+		if ops.N == 4 {
+			return plural.Few
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"ca"}, newSet(plural.One, plural.Two, plural.Few, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n = 1,3
+		if ops.N == 1 || ops.N == 3 {
+			return plural.One
+		}
+		// n = 2
+		if ops.N == 2 {
+			return plural.Two
+		}
+		// n = 4
+		if ops.N == 4 {
+			return plural.Few
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"mk"}, newSet(plural.One, plural.Two, plural.Many, plural.Other), func(ops *plural.Operands) plural.Category {
+		// i % 10 = 1 and i % 100 != 11
+		if ops.I%10 == 1 && ops.I%100 != 11 {
+			return plural.One
+		}
+		// i % 10 = 2 and i % 100 != 12
+		if ops.I%10 == 2 && ops.I%100 != 12 {
+			return plural.Two
+		}
+		// i % 10 = 7,8 and i % 100 != 17,18
+		if (ops.I%10 == 7 || ops.I%10 == 8) && !(ops.I%100 == 17 || ops.I%100 == 18) {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"az"}, newSet(plural.One, plural.Few, plural.Many, plural.Other), func(ops *plural.Operands) plural.Category {
+		// i % 10 = 1,2,5,7,8 or i % 100 = 20,50,70,80
+		if (ops.I%10 == 1 || ops.I%10 == 2 || ops.I%10 == 5 || ops.I%10 == 7 || ops.I%10 == 8) ||
+			(ops.I%100 == 20 || ops.I%100 == 50 || ops.I%100 == 70 || ops.I%100 == 80) {
+			return plural.One
+		}
+		// i % 10 = 3,4 or i % 1000 = 100,200,300,400,500,600,700,800,900
+		if (ops.I%10 == 3 || ops.I%10 == 4) ||
+			(ops.I%1000 == 100 || ops.I%1000 == 200 || ops.I%1000 == 300 || ops.I%1000 == 400 || ops.I%1000 == 500 || ops.I%1000 == 600 || ops.I%1000 == 700 || ops.I%1000 == 800 || ops.I%1000 == 900) {
+			return plural.Few
+		}
+		// i = 0 or i % 10 = 6 or i % 100 = 40,60,90
+		if ops.I == 0 ||
+			ops.I%10 == 6 ||
+			(ops.I%100 == 40 || ops.I%100 == 60 || ops.I%100 == 90) {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"gu", "hi"}, newSet(plural.One, plural.Two, plural.Few, plural.Many, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n = 1
+		if ops.N == 1 {
+			return plural.One
+		}
+		// n = 2,3
+		if ops.N == 2 || ops.N == 3 {
+			return plural.Two
+		}
+		// n = 4
+		if ops.N == 4 {
+			return plural.Few
+		}
+		// n = 6
+		if ops.N == 6 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"as", "bn"}, newSet(plural.One, plural.Two, plural.Few, plural.Many, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n = 1,5,7,8,9,10
+		if ops.N == 1 || ops.N == 5 || ops.N == 7 || ops.N == 8 || ops.N == 9 || ops.N == 10 {
+			return plural.One
+		}
+		// n = 2,3
+		if ops.N == 2 || ops.N == 3 {
+			return plural.Two
+		}
+		// n = 4
+		if ops.N == 4 {
+			return plural.Few
+		}
+		// n = 6
+		if ops.N == 6 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"or"}, newSet(plural.One, plural.Two, plural.Few, plural.Many, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n = 1,5,7..9
+		if ops.N == 1 || ops.N == 5 || nInRange(ops.N, 7, 9) {
+			return plural.One
+		}
+		// n = 2,3
+		if ops.N == 2 || ops.N == 3 {
+			return plural.Two
+		}
+		// n = 4
+		if ops.N == 4 {
+			return plural.Few
+		}
+		// n = 6
+		if ops.N == 6 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+	registerOrdinalRules([]string{"cy"}, newSet(plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other), func(ops *plural.Operands) plural.Category {
+		// n = 0,7,8,9
+		if ops.N == 0 || ops.N == 7 || ops.N == 8 || ops.N == 9 {
+			return plural.Zero
+		}
+		// n = 1
+		if ops.N == 1 {
+			return plural.One
+		}
+		// n = 2
+		if ops.N == 2 {
+			return plural.Two
+		}
+		// n = 3,4
+		if ops.N == 3 || ops.N == 4 {
+			return plural.Few
+		}
+		// n = 5,6
+		if ops.N == 5 || ops.N == 6 {
+			return plural.Many
+		}
+		return plural.Other
+	})
+}
@@ -0,0 +1,1004 @@
+// This file is generated by i18n/language/codegen; DO NOT EDIT.
+
+package language
+
+import (
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
+)
+
+var cardinalSampleTests = []sampleTest{
+	{
+		locales:  []string{"bm", "bo", "dz", "id", "ig", "ii", "in", "ja", "jbo", "jv", "jw", "kde", "kea", "km", "ko", "lkt", "lo", "ms", "my", "nqo", "root", "sah", "ses", "sg", "th", "to", "vi", "wo", "yo", "yue", "zh"},
+		category: plural.Other,
+		integers: []string{"0~15", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0~1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"am", "as", "bn", "fa", "gu", "hi", "kn", "mr", "zu"},
+		category: plural.One,
+		integers: []string{"0", "1"},
+		decimals: []string{"0.0~1.0", "0.00~0.04"},
+	},
+	{
+		locales:  []string{"am", "as", "bn", "fa", "gu", "hi", "kn", "mr", "zu"},
+		category: plural.Other,
+		integers: []string{"2~17", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"1.1~2.6", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"ff", "fr", "hy", "kab"},
+		category: plural.One,
+		integers: []string{"0", "1"},
+		decimals: []string{"0.0~1.5"},
+	},
+	{
+		locales:  []string{"ff", "fr", "hy", "kab"},
+		category: plural.Other,
+		integers: []string{"2~17", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"2.0~3.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"ast", "ca", "de", "en", "et", "fi", "fy", "gl", "it", "ji", "nl", "sv", "sw", "ur", "yi"},
+		category: plural.One,
+		integers: []string{"1"},
+	},
+	{
+		locales:  []string{"ast", "ca", "de", "en", "et", "fi", "fy", "gl", "it", "ji", "nl", "sv", "sw", "ur", "yi"},
+		category: plural.Other,
+		integers: []string{"0", "2~16", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0~1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"si"},
+		category: plural.One,
+		integers: []string{"0", "1"},
+		decimals: []string{"0.0", "0.1", "1.0", "0.00", "0.01", "1.00", "0.000", "0.001", "1.000", "0.0000", "0.0001", "1.0000"},
+	},
+	{
+		locales:  []string{"si"},
+		category: plural.Other,
+		integers: []string{"2~17", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.2~0.9", "1.1~1.8", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"ak", "bh", "guw", "ln", "mg", "nso", "pa", "ti", "wa"},
+		category: plural.One,
+		integers: []string{"0", "1"},
+		decimals: []string{"0.0", "1.0", "0.00", "1.00", "0.000", "1.000", "0.0000", "1.0000"},
+	},
+	{
+		locales:  []string{"ak", "bh", "guw", "ln", "mg", "nso", "pa", "ti", "wa"},
+		category: plural.Other,
+		integers: []string{"2~17", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.1~0.9", "1.1~1.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"tzm"},
+		category: plural.One,
+		integers: []string{"0", "1", "11~24"},
+		decimals: []string{"0.0", "1.0", "11.0", "12.0", "13.0", "14.0", "15.0", "16.0", "17.0", "18.0", "19.0", "20.0", "21.0", "22.0", "23.0", "24.0"},
+	},
+	{
+		locales:  []string{"tzm"},
+		category: plural.Other,
+		integers: []string{"2~10", "100~106", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.1~0.9", "1.1~1.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"af", "asa", "az", "bem", "bez", "bg", "brx", "ce", "cgg", "chr", "ckb", "dv", "ee", "el", "eo", "es", "eu", "fo", "fur", "gsw", "ha", "haw", "hu", "jgo", "jmc", "ka", "kaj", "kcg", "kk", "kkj", "kl", "ks", "ksb", "ku", "ky", "lb", "lg", "mas", "mgo", "ml", "mn", "nah", "nb", "nd", "ne", "nn", "nnh", "no", "nr", "ny", "nyn", "om", "or", "os", "pap", "ps", "rm", "rof", "rwk", "saq", "sdh", "seh", "sn", "so", "sq", "ss", "ssy", "st", "syr", "ta", "te", "teo", "tig", "tk", "tn", "tr", "ts", "ug", "uz", "ve", "vo", "vun", "wae", "xh", "xog"},
+		category: plural.One,
+		integers: []string{"1"},
+		decimals: []string{"1.0", "1.00", "1.000", "1.0000"},
+	},
+	{
+		locales:  []string{"af", "asa", "az", "bem", "bez", "bg", "brx", "ce", "cgg", "chr", "ckb", "dv", "ee", "el", "eo", "es", "eu", "fo", "fur", "gsw", "ha", "haw", "hu", "jgo", "jmc", "ka", "kaj", "kcg", "kk", "kkj", "kl", "ks", "ksb", "ku", "ky", "lb", "lg", "mas", "mgo", "ml", "mn", "nah", "nb", "nd", "ne", "nn", "nnh", "no", "nr", "ny", "nyn", "om", "or", "os", "pap", "ps", "rm", "rof", "rwk", "saq", "sdh", "seh", "sn", "so", "sq", "ss", "ssy", "st", "syr", "ta", "te", "teo", "tig", "tk", "tn", "tr", "ts", "ug", "uz", "ve", "vo", "vun", "wae", "xh", "xog"},
+		category: plural.Other,
+		integers: []string{"0", "2~16", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0~0.9", "1.1~1.6", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"da"},
+		category: plural.One,
+		integers: []string{"1"},
+		decimals: []string{"0.1~1.6"},
+	},
+	{
+		locales:  []string{"da"},
+		category: plural.Other,
+		integers: []string{"0", "2~16", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0", "2.0~3.4", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"is"},
+		category: plural.One,
+		integers: []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"},
+		decimals: []string{"0.1~1.6", "10.1", "100.1", "1000.1"},
+	},
+	{
+		locales:  []string{"is"},
+		category: plural.Other,
+		integers: []string{"0", "2~16", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0", "2.0", "3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"mk"},
+		category: plural.One,
+		integers: []string{"1", "11", "21", "31", "41", "51", "61", "71", "101", "1001"},
+		decimals: []string{"0.1", "1.1", "2.1", "3.1", "4.1", "5.1", "6.1", "7.1", "10.1", "100.1", "1000.1"},
+	},
+	{
+		locales:  []string{"mk"},
+		category: plural.Other,
+		integers: []string{"0", "2~10", "12~17", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0", "0.2~1.0", "1.2~1.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"fil", "tl"},
+		category: plural.One,
+		integers: []string{"0~3", "5", "7", "8", "10~13", "15", "17", "18", "20", "21", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0~0.3", "0.5", "0.7", "0.8", "1.0~1.3", "1.5", "1.7", "1.8", "2.0", "2.1", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"fil", "tl"},
+		category: plural.Other,
+		integers: []string{"4", "6", "9", "14", "16", "19", "24", "26", "104", "1004"},
+		decimals: []string{"0.4", "0.6", "0.9", "1.4", "1.6", "1.9", "2.4", "2.6", "10.4", "100.4", "1000.4"},
+	},
+	{
+		locales:  []string{"lv", "prg"},
+		category: plural.Zero,
+		integers: []string{"0", "10~20", "30", "40", "50", "60", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0", "10.0", "11.0", "12.0", "13.0", "14.0", "15.0", "16.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"lv", "prg"},
+		category: plural.One,
+		integers: []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"},
+		decimals: []string{"0.1", "1.0", "1.1", "2.1", "3.1", "4.1", "5.1", "6.1", "7.1", "10.1", "100.1", "1000.1"},
+	},
+	{
+		locales:  []string{"lv", "prg"},
+		category: plural.Other,
+		integers: []string{"2~9", "22~29", "102", "1002"},
+		decimals: []string{"0.2~0.9", "1.2~1.9", "10.2", "100.2", "1000.2"},
+	},
+	{
+		locales:  []string{"lag"},
+		category: plural.Zero,
+		integers: []string{"0"},
+		decimals: []string{"0.0", "0.00", "0.000", "0.0000"},
+	},
+	{
+		locales:  []string{"lag"},
+		category: plural.One,
+		integers: []string{"1"},
+		decimals: []string{"0.1~1.6"},
+	},
+	{
+		locales:  []string{"lag"},
+		category: plural.Other,
+		integers: []string{"2~17", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"2.0~3.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"ksh"},
+		category: plural.Zero,
+		integers: []string{"0"},
+		decimals: []string{"0.0", "0.00", "0.000", "0.0000"},
+	},
+	{
+		locales:  []string{"ksh"},
+		category: plural.One,
+		integers: []string{"1"},
+		decimals: []string{"1.0", "1.00", "1.000", "1.0000"},
+	},
+	{
+		locales:  []string{"ksh"},
+		category: plural.Other,
+		integers: []string{"2~17", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.1~0.9", "1.1~1.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"iu", "kw", "naq", "se", "sma", "smi", "smj", "smn", "sms"},
+		category: plural.One,
+		integers: []string{"1"},
+		decimals: []string{"1.0", "1.00", "1.000", "1.0000"},
+	},
+	{
+		locales:  []string{"iu", "kw", "naq", "se", "sma", "smi", "smj", "smn", "sms"},
+		category: plural.Two,
+		integers: []string{"2"},
+		decimals: []string{"2.0", "2.00", "2.000", "2.0000"},
+	},
+	{
+		locales:  []string{"iu", "kw", "naq", "se", "sma", "smi", "smj", "smn", "sms"},
+		category: plural.Other,
+		integers: []string{"0", "3~17", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0~0.9", "1.1~1.6", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"shi"},
+		category: plural.One,
+		integers: []string{"0", "1"},
+		decimals: []string{"0.0~1.0", "0.00~0.04"},
+	},
+	{
+		locales:  []string{"shi"},
+		category: plural.Few,
+		integers: []string{"2~10"},
+		decimals: []string{"2.0", "3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "9.0", "10.0", "2.00", "3.00", "4.00", "5.00", "6.00", "7.00", "8.00"},
+	},
+	{
+		locales:  []string{"shi"},
+		category: plural.Other,
+		integers: []string{"11~26", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"1.1~1.9", "2.1~2.7", "10.1", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"mo", "ro"},
+		category: plural.One,
+		integers: []string{"1"},
+	},
+	{
+		locales:  []string{"mo", "ro"},
+		category: plural.Few,
+		integers: []string{"0", "2~16", "101", "1001"},
+		decimals: []string{"0.0~1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"mo", "ro"},
+		category: plural.Other,
+		integers: []string{"20~35", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"bs", "hr", "sh", "sr"},
+		category: plural.One,
+		integers: []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"},
+		decimals: []string{"0.1", "1.1", "2.1", "3.1", "4.1", "5.1", "6.1", "7.1", "10.1", "100.1", "1000.1"},
+	},
+	{
+		locales:  []string{"bs", "hr", "sh", "sr"},
+		category: plural.Few,
+		integers: []string{"2~4", "22~24", "32~34", "42~44", "52~54", "62", "102", "1002"},
+		decimals: []string{"0.2~0.4", "1.2~1.4", "2.2~2.4", "3.2~3.4", "4.2~4.4", "5.2", "10.2", "100.2", "1000.2"},
+	},
+	{
+		locales:  []string{"bs", "hr", "sh", "sr"},
+		category: plural.Other,
+		integers: []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0", "0.5~1.0", "1.5~2.0", "2.5~2.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"gd"},
+		category: plural.One,
+		integers: []string{"1", "11"},
+		decimals: []string{"1.0", "11.0", "1.00", "11.00", "1.000", "11.000", "1.0000"},
+	},
+	{
+		locales:  []string{"gd"},
+		category: plural.Two,
+		integers: []string{"2", "12"},
+		decimals: []string{"2.0", "12.0", "2.00", "12.00", "2.000", "12.000", "2.0000"},
+	},
+	{
+		locales:  []string{"gd"},
+		category: plural.Few,
+		integers: []string{"3~10", "13~19"},
+		decimals: []string{"3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "9.0", "10.0", "13.0", "14.0", "15.0", "16.0", "17.0", "18.0", "19.0", "3.00"},
+	},
+	{
+		locales:  []string{"gd"},
+		category: plural.Other,
+		integers: []string{"0", "20~34", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0~0.9", "1.1~1.6", "10.1", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"sl"},
+		category: plural.One,
+		integers: []string{"1", "101", "201", "301", "401", "501", "601", "701", "1001"},
+	},
+	{
+		locales:  []string{"sl"},
+		category: plural.Two,
+		integers: []string{"2", "102", "202", "302", "402", "502", "602", "702", "1002"},
+	},
+	{
+		locales:  []string{"sl"},
+		category: plural.Few,
+		integers: []string{"3", "4", "103", "104", "203", "204", "303", "304", "403", "404", "503", "504", "603", "604", "703", "704", "1003"},
+		decimals: []string{"0.0~1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"sl"},
+		category: plural.Other,
+		integers: []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"dsb", "hsb"},
+		category: plural.One,
+		integers: []string{"1", "101", "201", "301", "401", "501", "601", "701", "1001"},
+		decimals: []string{"0.1", "1.1", "2.1", "3.1", "4.1", "5.1", "6.1", "7.1", "10.1", "100.1", "1000.1"},
+	},
+	{
+		locales:  []string{"dsb", "hsb"},
+		category: plural.Two,
+		integers: []string{"2", "102", "202", "302", "402", "502", "602", "702", "1002"},
+		decimals: []string{"0.2", "1.2", "2.2", "3.2", "4.2", "5.2", "6.2", "7.2", "10.2", "100.2", "1000.2"},
+	},
+	{
+		locales:  []string{"dsb", "hsb"},
+		category: plural.Few,
+		integers: []string{"3", "4", "103", "104", "203", "204", "303", "304", "403", "404", "503", "504", "603", "604", "703", "704", "1003"},
+		decimals: []string{"0.3", "0.4", "1.3", "1.4", "2.3", "2.4", "3.3", "3.4", "4.3", "4.4", "5.3", "5.4", "6.3", "6.4", "7.3", "7.4", "10.3", "100.3", "1000.3"},
+	},
+	{
+		locales:  []string{"dsb", "hsb"},
+		category: plural.Other,
+		integers: []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0", "0.5~1.0", "1.5~2.0", "2.5~2.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"he", "iw"},
+		category: plural.One,
+		integers: []string{"1"},
+	},
+	{
+		locales:  []string{"he", "iw"},
+		category: plural.Two,
+		integers: []string{"2"},
+	},
+	{
+		locales:  []string{"he", "iw"},
+		category: plural.Many,
+		integers: []string{"20", "30", "40", "50", "60", "70", "80", "90", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"he", "iw"},
+		category: plural.Other,
+		integers: []string{"0", "3~17", "101", "1001"},
+		decimals: []string{"0.0~1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"cs", "sk"},
+		category: plural.One,
+		integers: []string{"1"},
+	},
+	{
+		locales:  []string{"cs", "sk"},
+		category: plural.Few,
+		integers: []string{"2~4"},
+	},
+	{
+		locales:  []string{"cs", "sk"},
+		category: plural.Many,
+		decimals: []string{"0.0~1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"cs", "sk"},
+		category: plural.Other,
+		integers: []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"pl"},
+		category: plural.One,
+		integers: []string{"1"},
+	},
+	{
+		locales:  []string{"pl"},
+		category: plural.Few,
+		integers: []string{"2~4", "22~24", "32~34", "42~44", "52~54", "62", "102", "1002"},
+	},
+	{
+		locales:  []string{"pl"},
+		category: plural.Many,
+		integers: []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"pl"},
+		category: plural.Other,
+		decimals: []string{"0.0~1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"be"},
+		category: plural.One,
+		integers: []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"},
+		decimals: []string{"1.0", "21.0", "31.0", "41.0", "51.0", "61.0", "71.0", "81.0", "101.0", "1001.0"},
+	},
+	{
+		locales:  []string{"be"},
+		category: plural.Few,
+		integers: []string{"2~4", "22~24", "32~34", "42~44", "52~54", "62", "102", "1002"},
+		decimals: []string{"2.0", "3.0", "4.0", "22.0", "23.0", "24.0", "32.0", "33.0", "102.0", "1002.0"},
+	},
+	{
+		locales:  []string{"be"},
+		category: plural.Many,
+		integers: []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0", "5.0", "6.0", "7.0", "8.0", "9.0", "10.0", "11.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"be"},
+		category: plural.Other,
+		decimals: []string{"0.1~0.9", "1.1~1.7", "10.1", "100.1", "1000.1"},
+	},
+	{
+		locales:  []string{"lt"},
+		category: plural.One,
+		integers: []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"},
+		decimals: []string{"1.0", "21.0", "31.0", "41.0", "51.0", "61.0", "71.0", "81.0", "101.0", "1001.0"},
+	},
+	{
+		locales:  []string{"lt"},
+		category: plural.Few,
+		integers: []string{"2~9", "22~29", "102", "1002"},
+		decimals: []string{"2.0", "3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "9.0", "22.0", "102.0", "1002.0"},
+	},
+	{
+		locales:  []string{"lt"},
+		category: plural.Many,
+		decimals: []string{"0.1~0.9", "1.1~1.7", "10.1", "100.1", "1000.1"},
+	},
+	{
+		locales:  []string{"lt"},
+		category: plural.Other,
+		integers: []string{"0", "10~20", "30", "40", "50", "60", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0", "10.0", "11.0", "12.0", "13.0", "14.0", "15.0", "16.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"mt"},
+		category: plural.One,
+		integers: []string{"1"},
+		decimals: []string{"1.0", "1.00", "1.000", "1.0000"},
+	},
+	{
+		locales:  []string{"mt"},
+		category: plural.Few,
+		integers: []string{"0", "2~10", "102~107", "1002"},
+		decimals: []string{"0.0", "2.0", "3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "10.0", "102.0", "1002.0"},
+	},
+	{
+		locales:  []string{"mt"},
+		category: plural.Many,
+		integers: []string{"11~19", "111~117", "1011"},
+		decimals: []string{"11.0", "12.0", "13.0", "14.0", "15.0", "16.0", "17.0", "18.0", "111.0", "1011.0"},
+	},
+	{
+		locales:  []string{"mt"},
+		category: plural.Other,
+		integers: []string{"20~35", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.1~0.9", "1.1~1.7", "10.1", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"ru", "uk"},
+		category: plural.One,
+		integers: []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"},
+	},
+	{
+		locales:  []string{"ru", "uk"},
+		category: plural.Few,
+		integers: []string{"2~4", "22~24", "32~34", "42~44", "52~54", "62", "102", "1002"},
+	},
+	{
+		locales:  []string{"ru", "uk"},
+		category: plural.Many,
+		integers: []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"ru", "uk"},
+		category: plural.Other,
+		decimals: []string{"0.0~1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"br"},
+		category: plural.One,
+		integers: []string{"1", "21", "31", "41", "51", "61", "81", "101", "1001"},
+		decimals: []string{"1.0", "21.0", "31.0", "41.0", "51.0", "61.0", "81.0", "101.0", "1001.0"},
+	},
+	{
+		locales:  []string{"br"},
+		category: plural.Two,
+		integers: []string{"2", "22", "32", "42", "52", "62", "82", "102", "1002"},
+		decimals: []string{"2.0", "22.0", "32.0", "42.0", "52.0", "62.0", "82.0", "102.0", "1002.0"},
+	},
+	{
+		locales:  []string{"br"},
+		category: plural.Few,
+		integers: []string{"3", "4", "9", "23", "24", "29", "33", "34", "39", "43", "44", "49", "103", "1003"},
+		decimals: []string{"3.0", "4.0", "9.0", "23.0", "24.0", "29.0", "33.0", "34.0", "103.0", "1003.0"},
+	},
+	{
+		locales:  []string{"br"},
+		category: plural.Many,
+		integers: []string{"1000000"},
+		decimals: []string{"1000000.0", "1000000.00", "1000000.000"},
+	},
+	{
+		locales:  []string{"br"},
+		category: plural.Other,
+		integers: []string{"0", "5~8", "10~20", "100", "1000", "10000", "100000"},
+		decimals: []string{"0.0~0.9", "1.1~1.6", "10.0", "100.0", "1000.0", "10000.0", "100000.0"},
+	},
+	{
+		locales:  []string{"ga"},
+		category: plural.One,
+		integers: []string{"1"},
+		decimals: []string{"1.0", "1.00", "1.000", "1.0000"},
+	},
+	{
+		locales:  []string{"ga"},
+		category: plural.Two,
+		integers: []string{"2"},
+		decimals: []string{"2.0", "2.00", "2.000", "2.0000"},
+	},
+	{
+		locales:  []string{"ga"},
+		category: plural.Few,
+		integers: []string{"3~6"},
+		decimals: []string{"3.0", "4.0", "5.0", "6.0", "3.00", "4.00", "5.00", "6.00", "3.000", "4.000", "5.000", "6.000", "3.0000", "4.0000", "5.0000", "6.0000"},
+	},
+	{
+		locales:  []string{"ga"},
+		category: plural.Many,
+		integers: []string{"7~10"},
+		decimals: []string{"7.0", "8.0", "9.0", "10.0", "7.00", "8.00", "9.00", "10.00", "7.000", "8.000", "9.000", "10.000", "7.0000", "8.0000", "9.0000", "10.0000"},
+	},
+	{
+		locales:  []string{"ga"},
+		category: plural.Other,
+		integers: []string{"0", "11~25", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0~0.9", "1.1~1.6", "10.1", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"gv"},
+		category: plural.One,
+		integers: []string{"1", "11", "21", "31", "41", "51", "61", "71", "101", "1001"},
+	},
+	{
+		locales:  []string{"gv"},
+		category: plural.Two,
+		integers: []string{"2", "12", "22", "32", "42", "52", "62", "72", "102", "1002"},
+	},
+	{
+		locales:  []string{"gv"},
+		category: plural.Few,
+		integers: []string{"0", "20", "40", "60", "80", "100", "120", "140", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"gv"},
+		category: plural.Many,
+		decimals: []string{"0.0~1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"gv"},
+		category: plural.Other,
+		integers: []string{"3~10", "13~19", "23", "103", "1003"},
+	},
+	{
+		locales:  []string{"ar", "ars"},
+		category: plural.Zero,
+		integers: []string{"0"},
+		decimals: []string{"0.0", "0.00", "0.000", "0.0000"},
+	},
+	{
+		locales:  []string{"ar", "ars"},
+		category: plural.One,
+		integers: []string{"1"},
+		decimals: []string{"1.0", "1.00", "1.000", "1.0000"},
+	},
+	{
+		locales:  []string{"ar", "ars"},
+		category: plural.Two,
+		integers: []string{"2"},
+		decimals: []string{"2.0", "2.00", "2.000", "2.0000"},
+	},
+	{
+		locales:  []string{"ar", "ars"},
+		category: plural.Few,
+		integers: []string{"3~10", "103~110", "1003"},
+		decimals: []string{"3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "9.0", "10.0", "103.0", "1003.0"},
+	},
+	{
+		locales:  []string{"ar", "ars"},
+		category: plural.Many,
+		integers: []string{"11~26", "111", "1011"},
+		decimals: []string{"11.0", "12.0", "13.0", "14.0", "15.0", "16.0", "17.0", "18.0", "111.0", "1011.0"},
+	},
+	{
+		locales:  []string{"ar", "ars"},
+		category: plural.Other,
+		integers: []string{"100~102", "200~202", "300~302", "400~402", "500~502", "600", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.1~0.9", "1.1~1.7", "10.1", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"cy"},
+		category: plural.Zero,
+		integers: []string{"0"},
+		decimals: []string{"0.0", "0.00", "0.000", "0.0000"},
+	},
+	{
+		locales:  []string{"cy"},
+		category: plural.One,
+		integers: []string{"1"},
+		decimals: []string{"1.0", "1.00", "1.000", "1.0000"},
+	},
+	{
+		locales:  []string{"cy"},
+		category: plural.Two,
+		integers: []string{"2"},
+		decimals: []string{"2.0", "2.00", "2.000", "2.0000"},
+	},
+	{
+		locales:  []string{"cy"},
+		category: plural.Few,
+		integers: []string{"3"},
+		decimals: []string{"3.0", "3.00", "3.000", "3.0000"},
+	},
+	{
+		locales:  []string{"cy"},
+		category: plural.Many,
+		integers: []string{"6"},
+		decimals: []string{"6.0", "6.00", "6.000", "6.0000"},
+	},
+	{
+		locales:  []string{"cy"},
+		category: plural.Other,
+		integers: []string{"4", "5", "7~20", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.1~0.9", "1.1~1.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"pt"},
+		category: plural.One,
+		integers: []string{"1"},
+	},
+	{
+		locales:  []string{"pt"},
+		category: plural.Other,
+		integers: []string{"0", "2~16", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0~1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+	{
+		locales:  []string{"pt-BR"},
+		category: plural.One,
+		integers: []string{"1"},
+		decimals: []string{"0.1", "0.01", "0.10", "0.001", "0.010", "0.100"},
+	},
+	{
+		locales:  []string{"pt-BR"},
+		category: plural.Other,
+		integers: []string{"0", "2~16", "100", "1000", "10000", "100000", "1000000"},
+		decimals: []string{"0.0", "0.2~1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"},
+	},
+}
+
+var ordinalSampleTests = []sampleTest{
+	{
+		locales:  []string{"af", "am", "ar", "bg", "bs", "ce", "cs", "da", "de", "dsb", "el", "es", "et", "eu", "fa", "fi", "fy", "gl", "gsw", "he", "hr", "hsb", "id", "in", "is", "iw", "ja", "km", "kn", "ko", "ky", "lt", "lv", "ml", "mn", "my", "nb", "nl", "pa", "pl", "prg", "ps", "pt", "root", "ru", "sh", "si", "sk", "sl", "sr", "sw", "ta", "te", "th", "tr", "ur", "uz", "yue", "zh", "zu"},
+		category: plural.Other,
+		integers: []string{"0~15", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"sv"},
+		category: plural.One,
+		integers: []string{"1", "2", "21", "22", "31", "32", "41", "42", "51", "52", "61", "62", "71", "72", "81", "82", "101", "1001"},
+	},
+	{
+		locales:  []string{"sv"},
+		category: plural.Other,
+		integers: []string{"0", "3~17", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"fil", "fr", "ga", "hy", "lo", "mo", "ms", "ro", "tl", "vi"},
+		category: plural.One,
+		integers: []string{"1"},
+	},
+	{
+		locales:  []string{"fil", "fr", "ga", "hy", "lo", "mo", "ms", "ro", "tl", "vi"},
+		category: plural.Other,
+		integers: []string{"0", "2~16", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"hu"},
+		category: plural.One,
+		integers: []string{"1", "5"},
+	},
+	{
+		locales:  []string{"hu"},
+		category: plural.Other,
+		integers: []string{"0", "2~4", "6~17", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"ne"},
+		category: plural.One,
+		integers: []string{"1~4"},
+	},
+	{
+		locales:  []string{"ne"},
+		category: plural.Other,
+		integers: []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"be"},
+		category: plural.Few,
+		integers: []string{"2", "3", "22", "23", "32", "33", "42", "43", "52", "53", "62", "63", "72", "73", "82", "83", "102", "1002"},
+	},
+	{
+		locales:  []string{"be"},
+		category: plural.Other,
+		integers: []string{"0", "1", "4~17", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"uk"},
+		category: plural.Few,
+		integers: []string{"3", "23", "33", "43", "53", "63", "73", "83", "103", "1003"},
+	},
+	{
+		locales:  []string{"uk"},
+		category: plural.Other,
+		integers: []string{"0~2", "4~16", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"tk"},
+		category: plural.Few,
+		integers: []string{"6", "9", "10", "16", "19", "26", "29", "36", "39", "106", "1006"},
+	},
+	{
+		locales:  []string{"tk"},
+		category: plural.Other,
+		integers: []string{"0~5", "7", "8", "11~15", "17", "18", "20", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"kk"},
+		category: plural.Many,
+		integers: []string{"6", "9", "10", "16", "19", "20", "26", "29", "30", "36", "39", "40", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"kk"},
+		category: plural.Other,
+		integers: []string{"0~5", "7", "8", "11~15", "17", "18", "21", "101", "1001"},
+	},
+	{
+		locales:  []string{"it"},
+		category: plural.Many,
+		integers: []string{"8", "11", "80", "800"},
+	},
+	{
+		locales:  []string{"it"},
+		category: plural.Other,
+		integers: []string{"0~7", "9", "10", "12~17", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"ka"},
+		category: plural.One,
+		integers: []string{"1"},
+	},
+	{
+		locales:  []string{"ka"},
+		category: plural.Many,
+		integers: []string{"0", "2~16", "102", "1002"},
+	},
+	{
+		locales:  []string{"ka"},
+		category: plural.Other,
+		integers: []string{"21~36", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"sq"},
+		category: plural.One,
+		integers: []string{"1"},
+	},
+	{
+		locales:  []string{"sq"},
+		category: plural.Many,
+		integers: []string{"4", "24", "34", "44", "54", "64", "74", "84", "104", "1004"},
+	},
+	{
+		locales:  []string{"sq"},
+		category: plural.Other,
+		integers: []string{"0", "2", "3", "5~17", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"en"},
+		category: plural.One,
+		integers: []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"},
+	},
+	{
+		locales:  []string{"en"},
+		category: plural.Two,
+		integers: []string{"2", "22", "32", "42", "52", "62", "72", "82", "102", "1002"},
+	},
+	{
+		locales:  []string{"en"},
+		category: plural.Few,
+		integers: []string{"3", "23", "33", "43", "53", "63", "73", "83", "103", "1003"},
+	},
+	{
+		locales:  []string{"en"},
+		category: plural.Other,
+		integers: []string{"0", "4~18", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"mr"},
+		category: plural.One,
+		integers: []string{"1"},
+	},
+	{
+		locales:  []string{"mr"},
+		category: plural.Two,
+		integers: []string{"2", "3"},
+	},
+	{
+		locales:  []string{"mr"},
+		category: plural.Few,
+		integers: []string{"4"},
+	},
+	{
+		locales:  []string{"mr"},
+		category: plural.Other,
+		integers: []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"ca"},
+		category: plural.One,
+		integers: []string{"1", "3"},
+	},
+	{
+		locales:  []string{"ca"},
+		category: plural.Two,
+		integers: []string{"2"},
+	},
+	{
+		locales:  []string{"ca"},
+		category: plural.Few,
+		integers: []string{"4"},
+	},
+	{
+		locales:  []string{"ca"},
+		category: plural.Other,
+		integers: []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"mk"},
+		category: plural.One,
+		integers: []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"},
+	},
+	{
+		locales:  []string{"mk"},
+		category: plural.Two,
+		integers: []string{"2", "22", "32", "42", "52", "62", "72", "82", "102", "1002"},
+	},
+	{
+		locales:  []string{"mk"},
+		category: plural.Many,
+		integers: []string{"7", "8", "27", "28", "37", "38", "47", "48", "57", "58", "67", "68", "77", "78", "87", "88", "107", "1007"},
+	},
+	{
+		locales:  []string{"mk"},
+		category: plural.Other,
+		integers: []string{"0", "3~6", "9~19", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"az"},
+		category: plural.One,
+		integers: []string{"1", "2", "5", "7", "8", "11", "12", "15", "17", "18", "20~22", "25", "101", "1001"},
+	},
+	{
+		locales:  []string{"az"},
+		category: plural.Few,
+		integers: []string{"3", "4", "13", "14", "23", "24", "33", "34", "43", "44", "53", "54", "63", "64", "73", "74", "100", "1003"},
+	},
+	{
+		locales:  []string{"az"},
+		category: plural.Many,
+		integers: []string{"0", "6", "16", "26", "36", "40", "46", "56", "106", "1006"},
+	},
+	{
+		locales:  []string{"az"},
+		category: plural.Other,
+		integers: []string{"9", "10", "19", "29", "30", "39", "49", "59", "69", "79", "109", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"gu", "hi"},
+		category: plural.One,
+		integers: []string{"1"},
+	},
+	{
+		locales:  []string{"gu", "hi"},
+		category: plural.Two,
+		integers: []string{"2", "3"},
+	},
+	{
+		locales:  []string{"gu", "hi"},
+		category: plural.Few,
+		integers: []string{"4"},
+	},
+	{
+		locales:  []string{"gu", "hi"},
+		category: plural.Many,
+		integers: []string{"6"},
+	},
+	{
+		locales:  []string{"gu", "hi"},
+		category: plural.Other,
+		integers: []string{"0", "5", "7~20", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"as", "bn"},
+		category: plural.One,
+		integers: []string{"1", "5", "7~10"},
+	},
+	{
+		locales:  []string{"as", "bn"},
+		category: plural.Two,
+		integers: []string{"2", "3"},
+	},
+	{
+		locales:  []string{"as", "bn"},
+		category: plural.Few,
+		integers: []string{"4"},
+	},
+	{
+		locales:  []string{"as", "bn"},
+		category: plural.Many,
+		integers: []string{"6"},
+	},
+	{
+		locales:  []string{"as", "bn"},
+		category: plural.Other,
+		integers: []string{"0", "11~25", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"or"},
+		category: plural.One,
+		integers: []string{"1", "5", "7~9"},
+	},
+	{
+		locales:  []string{"or"},
+		category: plural.Two,
+		integers: []string{"2", "3"},
+	},
+	{
+		locales:  []string{"or"},
+		category: plural.Few,
+		integers: []string{"4"},
+	},
+	{
+		locales:  []string{"or"},
+		category: plural.Many,
+		integers: []string{"6"},
+	},
+	{
+		locales:  []string{"or"},
+		category: plural.Other,
+		integers: []string{"0", "10~24", "100", "1000", "10000", "100000", "1000000"},
+	},
+	{
+		locales:  []string{"cy"},
+		category: plural.Zero,
+		integers: []string{"0", "7~9"},
+	},
+	{
+		locales:  []string{"cy"},
+		category: plural.One,
+		integers: []string{"1"},
+	},
+	{
+		locales:  []string{"cy"},
+		category: plural.Two,
+		integers: []string{"2"},
+	},
+	{
+		locales:  []string{"cy"},
+		category: plural.Few,
+		integers: []string{"3", "4"},
+	},
+	{
+		locales:  []string{"cy"},
+		category: plural.Many,
+		integers: []string{"5", "6"},
+	},
+	{
+		locales:  []string{"cy"},
+		category: plural.Other,
+		integers: []string{"10~25", "100", "1000", "10000", "100000", "1000000"},
+	},
+}
@@ -0,0 +1,56 @@
+package locale
+
+// Direction is the direction in which the text of a locale is written.
+type Direction string
+
+const (
+	LeftToRight Direction = "ltr"
+	RightToLeft Direction = "rtl"
+)
+
+// rtlScripts are the ISO 15924 codes of the scripts that are written right-to-left.
+var rtlScripts = map[string]bool{
+	"Adlm": true, // Adlam
+	"Arab": true, // Arabic
+	"Hebr": true, // Hebrew
+	"Mand": true, // Mandaic
+	"Nkoo": true, // N'Ko
+	"Rohg": true, // Hanifi Rohingya
+	"Samr": true, // Samaritan
+	"Syrc": true, // Syriac
+	"Thaa": true, // Thaana
+}
+
+// rtlLanguages are the languages whose default script is written right-to-left.
+var rtlLanguages = map[string]bool{
+	"ar":  true, // Arabic
+	"ckb": true, // Central Kurdish
+	"dv":  true, // Divehi
+	"fa":  true, // Persian
+	"he":  true, // Hebrew
+	"ks":  true, // Kashmiri
+	"lrc": true, // Northern Luri
+	"mzn": true, // Mazanderani
+	"ps":  true, // Pashto
+	"sd":  true, // Sindhi
+	"syr": true, // Syriac
+	"ug":  true, // Uyghur
+	"ur":  true, // Urdu
+	"yi":  true, // Yiddish
+}
+
+// Direction returns the direction of the script of l, which is the script subtag
+// of l (e.g. az-Arab is right-to-left) or else the default script of its language
+// (e.g. ar-EG is right-to-left and en-US is left-to-right).
+func (l *Locale) Direction() Direction {
+	if l.Tag.Script != "" {
+		if rtlScripts[l.Tag.Script] {
+			return RightToLeft
+		}
+		return LeftToRight
+	}
+	if rtlLanguages[l.Tag.Language] {
+		return RightToLeft
+	}
+	return LeftToRight
+}
@@ -0,0 +1,37 @@
+package locale
+
+import (
+	"testing"
+)
+
+func TestDirection(t *testing.T) {
+	tests := []struct {
+		localeID  string
+		direction Direction
+	}{
+		{"en-US", LeftToRight},
+		{"en-XA", LeftToRight},
+		{"zh-Hant-TW", LeftToRight},
+		{"ar", RightToLeft},
+		{"ar-AR", RightToLeft},
+		{"ar-EG", RightToLeft},
+		{"he-IL", RightToLeft},
+		{"iw-IL", RightToLeft},
+		{"fa-IR", RightToLeft},
+		{"ur-PK", RightToLeft},
+		{"az", LeftToRight},
+		{"az-Arab", RightToLeft},
+		{"pa-Arab-PK", RightToLeft},
+		{"ks-Deva", LeftToRight},
+	}
+	for _, test := range tests {
+		l, err := New(test.localeID)
+		if err != nil {
+			t.Errorf("New(%q) returned error %s", test.localeID, err)
+			continue
+		}
+		if direction := l.Direction(); direction != test.direction {
+			t.Errorf("%s.Direction() returned %s; expected %s", test.localeID, direction, test.direction)
+		}
+	}
+}
@@ -4,51 +4,63 @@ package locale
 import (
 	"fmt"
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/language"
+	"path/filepath"
 	"regexp"
 	"strings"
 )
 
-// Locale is a language and a geographic region (e.g. en-US, en-GB).
+// Locale is a language and optionally a script, geographic region
+// and other BCP 47 subtags (e.g. en-US, en-GB, zh-Hant-TW, es-419).
 type Locale struct {
 	ID       string
 	Language *language.Language
+	Tag      *Tag
 }
 
-// tagMatcher matches language tags (e.g. zh-CN).
-var tagMatcher = regexp.MustCompile(`^([a-z]{2})[_\-]([A-Z]{2})$`)
-
 // tagSplitter matches characters not found in language tags.
-var tagSplitter = regexp.MustCompile(`[^a-zA-Z_\-]+`)
+var tagSplitter = regexp.MustCompile(`[^a-zA-Z0-9_\-]+`)
 
-// New searches s for a valid language tag (RFC 5646)
-// of the form xx-YY or xx_YY where
-// xx is a 2 character language code and
-// YY is a 2 character country code.
+// New returns the locale of s if s is a BCP 47 language tag (e.g. fr, en-US, zh-Hant-TW).
+//
+// Otherwise New searches s for a language tag, which is useful for finding
+// the locale of a translation file (e.g. path/to/en-US.all.json).
+// A tag that is only a language (e.g. fr) is found if it is the first part
+// of the file name (e.g. path/to/fr.all.json).
 //
 // It returns an error if s doesn't contain exactly one language tag or
 // if the language represented by the tag is not supported by this package.
 func New(s string) (*Locale, error) {
-	parts := tagSplitter.Split(s, -1)
-	var id, lc string
+	if tag, err := ParseTag(s); err == nil {
+		return newLocale(tag)
+	}
+
+	name := strings.SplitN(filepath.Base(s), ".", 2)[0]
+	var tag *Tag
 	count := 0
-	for _, part := range parts {
-		if tag := tagMatcher.FindStringSubmatch(part); tag != nil {
-			count += 1
-			id, lc = tag[0], tag[1]
+	for _, part := range tagSplitter.Split(s, -1) {
+		t, err := ParseTag(part)
+		if err != nil || language.LanguageWithID(t.Language) == nil {
+			continue
+		}
+		if t.Parent() == nil && part != name {
+			continue
 		}
+		count++
+		tag = t
 	}
 	if count != 1 {
 		return nil, fmt.Errorf("%d locales found in string %s", count, s)
 	}
-	id = strings.Replace(id, "_", "-", -1)
+	return newLocale(tag)
+}
+
+func newLocale(tag *Tag) (*Locale, error) {
+	id := tag.String()
 	lang := language.LanguageWithID(id)
-	if lang == nil {
-		lang = language.LanguageWithID(lc)
-	}
 	if lang == nil {
 		return nil, fmt.Errorf("unknown language %s", id)
 	}
-	return &Locale{id, lang}, nil
+	return &Locale{id, lang, tag}, nil
 }
 
 // MustNew is similar to New except that it panics if an error happens.
@@ -60,6 +72,16 @@ func MustNew(s string) *Locale {
 	return locale
 }
 
+// Parent returns the locale that l falls back to (e.g. zh-Hant for zh-Hant-TW)
+// or nil if l only has a language.
+func (l *Locale) Parent() *Locale {
+	tag := l.Tag.Parent()
+	if tag == nil {
+		return nil
+	}
+	return &Locale{tag.String(), l.Language, tag}
+}
+
 func (l *Locale) String() string {
 	return l.ID
 }
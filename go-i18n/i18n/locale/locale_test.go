@@ -8,30 +8,68 @@ import (
 func TestNew(t *testing.T) {
 	tests := []struct {
 		localeID string
+		id       string
 		lang     *language.Language
 	}{
-		{"en-US", language.LanguageWithID("en")},
-		{"en_US", language.LanguageWithID("en")},
-		{"zh-CN", language.LanguageWithID("zh")},
-		{"zh-TW", language.LanguageWithID("zh")},
-		{"pt-BR", language.LanguageWithID("pt-BR")},
-		{"pt_BR", language.LanguageWithID("pt-BR")},
-		{"pt-PT", language.LanguageWithID("pt")},
-		{"pt_PT", language.LanguageWithID("pt")},
-		{"zh-Hans-CN", nil},
-		{"zh-Hant-TW", nil},
-		{"xx-Yyen-US", nil},
-		{"en US", nil},
-		{"en-US-en-US", nil},
-		{".en-US..en-US.", nil},
+		{"en-US", "en-US", language.LanguageWithID("en")},
+		{"en_US", "en-US", language.LanguageWithID("en")},
+		{"zh-CN", "zh-CN", language.LanguageWithID("zh")},
+		{"zh-TW", "zh-TW", language.LanguageWithID("zh")},
+		{"pt-BR", "pt-BR", language.LanguageWithID("pt-BR")},
+		{"pt_BR", "pt-BR", language.LanguageWithID("pt-BR")},
+		{"pt-PT", "pt-PT", language.LanguageWithID("pt")},
+		{"pt_PT", "pt-PT", language.LanguageWithID("pt")},
+		{"fr", "fr", language.LanguageWithID("fr")},
+		{"es-419", "es-419", language.LanguageWithID("es")},
+		{"sr-Latn", "sr-Latn", language.LanguageWithID("sr")},
+		{"zh-Hans-CN", "zh-Hans-CN", language.LanguageWithID("zh")},
+		{"zh-Hant-TW", "zh-Hant-TW", language.LanguageWithID("zh")},
+		{"zh_hant_tw", "zh-Hant-TW", language.LanguageWithID("zh")},
+		{"iw-IL", "he-IL", language.LanguageWithID("he")},
+		{"path/to/en-US.all.json", "en-US", language.LanguageWithID("en")},
+		{"path/to/zh-Hant-TW.all.json", "zh-Hant-TW", language.LanguageWithID("zh")},
+		{"path/to/es-419.json", "es-419", language.LanguageWithID("es")},
+		{"root/fr.all.json", "fr", language.LanguageWithID("fr")},
+		{"fr/en-US.json", "en-US", language.LanguageWithID("en")},
+		{"xx-Yyen-US", "", nil},
+		{"en US", "", nil},
+		{"en-US-en-US", "", nil},
+		{".en-US..en-US.", "", nil},
+		{"path/to/all.json", "", nil},
 	}
 	for _, test := range tests {
 		loc, err := New(test.localeID)
 		if loc == nil && test.lang != nil {
 			t.Errorf("New(%q) = <nil>, %q; expected %q, <nil>", test.localeID, err, test.lang)
 		}
-		if loc != nil && loc.Language != test.lang {
-			t.Errorf("New(%q) = %q; expected %q", test.localeID, loc.Language, test.lang)
+		if loc != nil && (loc.Language != test.lang || loc.ID != test.id) {
+			t.Errorf("New(%q) = %s %q; expected %s %q", test.localeID, loc.ID, loc.Language, test.id, test.lang)
 		}
 	}
 }
+
+func TestParent(t *testing.T) {
+	l := MustNew("zh-Hant-TW")
+	var ids []string
+	for ; l != nil; l = l.Parent() {
+		ids = append(ids, l.ID)
+		if l.Language != language.LanguageWithID("zh") {
+			t.Errorf("language of %s was %q; expected zh", l.ID, l.Language)
+		}
+	}
+	if expected := []string{"zh-Hant-TW", "zh-Hant", "zh"}; !equal(ids, expected) {
+		t.Errorf("parents were %v; expected %v", ids, expected)
+	}
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,190 @@
+package locale
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Tag is a language tag as defined by BCP 47 (RFC 5646) (e.g. zh-Hant-TW, es-419, sr-Latn).
+//
+// The subtags of a Tag are in canonical case: the language is lower case,
+// the script is title case, the region is upper case and everything else is lower case.
+type Tag struct {
+	Language   string   // ISO 639 language code (e.g. zh)
+	Script     string   // ISO 15924 script code (e.g. Hant)
+	Region     string   // ISO 3166-1 country code or UN M.49 area code (e.g. TW, 419)
+	Variants   []string // registered variants (e.g. 1901)
+	Extensions []string // extensions including their singleton (e.g. u-nu-latn)
+	PrivateUse string   // private use subtags including the x singleton (e.g. x-buoy)
+}
+
+// languageAliases maps deprecated language subtags to their preferred values.
+var languageAliases = map[string]string{
+	"in": "id",
+	"iw": "he",
+	"ji": "yi",
+	"jw": "jv",
+	"mo": "ro",
+}
+
+// ParseTag parses s as a BCP 47 language tag.
+// Subtags may be separated by a dash or an underscore (e.g. en_US).
+//
+// The tag is canonicalized: the case of each subtag is normalized,
+// deprecated language subtags are replaced (e.g. iw becomes he),
+// an extended language subtag replaces its prefix (e.g. zh-yue becomes yue)
+// and extensions are sorted by their singleton.
+//
+// Grandfathered tags and tags that only consist of private use subtags are not supported.
+func ParseTag(s string) (*Tag, error) {
+	subtags := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+	if len(subtags) == 0 || strings.Trim(s, "-_") != s || strings.Contains(s, "--") || strings.Contains(s, "__") {
+		return nil, fmt.Errorf("invalid language tag %q", s)
+	}
+	invalid := func(subtag string) error {
+		return fmt.Errorf("invalid subtag %q in language tag %q", subtag, s)
+	}
+
+	tag := &Tag{}
+	i := 0
+	next := func() string {
+		if i < len(subtags) {
+			return subtags[i]
+		}
+		return ""
+	}
+
+	if lang := next(); isAlpha(lang) && (len(lang) == 2 || len(lang) == 3 || (len(lang) >= 5 && len(lang) <= 8)) {
+		tag.Language = lang
+		i++
+	} else {
+		return nil, invalid(lang)
+	}
+	if extlang := next(); len(tag.Language) <= 3 && len(extlang) == 3 && isAlpha(extlang) {
+		tag.Language = extlang
+		i++
+	}
+	if alias, ok := languageAliases[tag.Language]; ok {
+		tag.Language = alias
+	}
+
+	if script := next(); len(script) == 4 && isAlpha(script) {
+		tag.Script = strings.ToUpper(script[:1]) + script[1:]
+		i++
+	}
+	if region := next(); (len(region) == 2 && isAlpha(region)) || (len(region) == 3 && isDigit(region)) {
+		tag.Region = strings.ToUpper(region)
+		i++
+	}
+	for variant := next(); isVariant(variant); variant = next() {
+		for _, v := range tag.Variants {
+			if v == variant {
+				return nil, invalid(variant)
+			}
+		}
+		tag.Variants = append(tag.Variants, variant)
+		i++
+	}
+
+	singletons := make(map[string]bool)
+	for singleton := next(); len(singleton) == 1 && singleton != "x"; singleton = next() {
+		if singletons[singleton] || !isAlphanum(singleton) {
+			return nil, invalid(singleton)
+		}
+		singletons[singleton] = true
+		i++
+
+		extension := []string{singleton}
+		for subtag := next(); len(subtag) >= 2 && len(subtag) <= 8 && isAlphanum(subtag); subtag = next() {
+			extension = append(extension, subtag)
+			i++
+		}
+		if len(extension) == 1 {
+			return nil, invalid(singleton)
+		}
+		tag.Extensions = append(tag.Extensions, strings.Join(extension, "-"))
+	}
+	sort.Strings(tag.Extensions)
+
+	if next() == "x" {
+		privateUse := subtags[i:]
+		if len(privateUse) == 1 {
+			return nil, invalid("x")
+		}
+		for _, subtag := range privateUse[1:] {
+			if len(subtag) > 8 || !isAlphanum(subtag) {
+				return nil, invalid(subtag)
+			}
+		}
+		tag.PrivateUse = strings.Join(privateUse, "-")
+		i = len(subtags)
+	}
+
+	if i < len(subtags) {
+		return nil, invalid(subtags[i])
+	}
+	return tag, nil
+}
+
+// String returns the canonical form of t (e.g. zh-Hant-TW).
+func (t *Tag) String() string {
+	subtags := []string{t.Language}
+	for _, subtag := range []string{t.Script, t.Region} {
+		if subtag != "" {
+			subtags = append(subtags, subtag)
+		}
+	}
+	subtags = append(subtags, t.Variants...)
+	subtags = append(subtags, t.Extensions...)
+	if t.PrivateUse != "" {
+		subtags = append(subtags, t.PrivateUse)
+	}
+	return strings.Join(subtags, "-")
+}
+
+// Parent returns the tag that t falls back to or nil if t only has a language.
+//
+// Extensions and private use subtags are removed first, followed by
+// the variants, the region and the script:
+//
+//	zh-Hant-TW-u-nu-hanidec -> zh-Hant-TW -> zh-Hant -> zh
+func (t *Tag) Parent() *Tag {
+	parent := &Tag{Language: t.Language, Script: t.Script, Region: t.Region, Variants: t.Variants}
+	switch {
+	case len(t.Extensions) > 0 || t.PrivateUse != "":
+	case len(t.Variants) > 0:
+		parent.Variants = t.Variants[:len(t.Variants)-1]
+	case t.Region != "":
+		parent.Region = ""
+	case t.Script != "":
+		parent.Script = ""
+	default:
+		return nil
+	}
+	if len(parent.Variants) == 0 {
+		parent.Variants = nil
+	}
+	return parent
+}
+
+func isVariant(s string) bool {
+	if !isAlphanum(s) {
+		return false
+	}
+	return (len(s) >= 5 && len(s) <= 8) || (len(s) == 4 && isDigit(s[:1]))
+}
+
+func isAlpha(s string) bool {
+	return s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyz") == ""
+}
+
+func isDigit(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+func isAlphanum(s string) bool {
+	return s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyz0123456789") == ""
+}
@@ -0,0 +1,103 @@
+package locale
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		s   string
+		tag *Tag
+	}{
+		{"fr", &Tag{Language: "fr"}},
+		{"en-US", &Tag{Language: "en", Region: "US"}},
+		{"en_us", &Tag{Language: "en", Region: "US"}},
+		{"es-419", &Tag{Language: "es", Region: "419"}},
+		{"sr-Latn", &Tag{Language: "sr", Script: "Latn"}},
+		{"ZH-HANT-TW", &Tag{Language: "zh", Script: "Hant", Region: "TW"}},
+		{"de-CH-1901", &Tag{Language: "de", Region: "CH", Variants: []string{"1901"}}},
+		{"sl-rozaj-biske", &Tag{Language: "sl", Variants: []string{"rozaj", "biske"}}},
+		{"zh-yue-HK", &Tag{Language: "yue", Region: "HK"}},
+		{"iw", &Tag{Language: "he"}},
+		{"in-ID", &Tag{Language: "id", Region: "ID"}},
+		{"th-TH-u-nu-thai", &Tag{Language: "th", Region: "TH", Extensions: []string{"u-nu-thai"}}},
+		{"en-u-ca-gregory-a-foo", &Tag{Language: "en", Extensions: []string{"a-foo", "u-ca-gregory"}}},
+		{"en-US-x-buoy", &Tag{Language: "en", Region: "US", PrivateUse: "x-buoy"}},
+		{"", nil},
+		{"e", nil},
+		{"en-", nil},
+		{"-en", nil},
+		{"en--US", nil},
+		{"en US", nil},
+		{"x-buoy", nil},
+		{"i-klingon", nil},
+		{"en-US-US", nil},
+		{"de-1901-1901", nil},
+		{"en-u", nil},
+		{"en-u-ca-u-nu", nil},
+		{"en-x", nil},
+		{"en-US-en-US", nil},
+		{"toolongtag", nil},
+	}
+	for _, test := range tests {
+		tag, err := ParseTag(test.s)
+		if !reflect.DeepEqual(tag, test.tag) {
+			t.Errorf("ParseTag(%q) = %#v, %v; expected %#v", test.s, tag, err, test.tag)
+		}
+		if tag == nil && err == nil {
+			t.Errorf("ParseTag(%q) returned nil error", test.s)
+		}
+	}
+}
+
+func TestTagString(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected string
+	}{
+		{"en_us", "en-US"},
+		{"ZH-hant-tw", "zh-Hant-TW"},
+		{"es-419", "es-419"},
+		{"de-ch-1901", "de-CH-1901"},
+		{"th-th-u-nu-thai-x-Buoy", "th-TH-u-nu-thai-x-buoy"},
+		{"en-u-ca-gregory-a-foo", "en-a-foo-u-ca-gregory"},
+	}
+	for _, test := range tests {
+		tag, err := ParseTag(test.s)
+		if err != nil {
+			t.Errorf("ParseTag(%q) returned error %s", test.s, err)
+			continue
+		}
+		if s := tag.String(); s != test.expected {
+			t.Errorf("ParseTag(%q).String() = %q; expected %q", test.s, s, test.expected)
+		}
+	}
+}
+
+func TestTagParent(t *testing.T) {
+	tests := []struct {
+		s       string
+		parents []string
+	}{
+		{"zh-Hant-TW", []string{"zh-Hant", "zh"}},
+		{"es-419", []string{"es"}},
+		{"sl-rozaj-biske", []string{"sl-rozaj", "sl"}},
+		{"th-TH-u-nu-thai-x-buoy", []string{"th-TH", "th"}},
+		{"fr", nil},
+	}
+	for _, test := range tests {
+		tag, err := ParseTag(test.s)
+		if err != nil {
+			t.Errorf("ParseTag(%q) returned error %s", test.s, err)
+			continue
+		}
+		var parents []string
+		for p := tag.Parent(); p != nil; p = p.Parent() {
+			parents = append(parents, p.String())
+		}
+		if !reflect.DeepEqual(parents, test.parents) {
+			t.Errorf("parents of %s were %v; expected %v", test.s, parents, test.parents)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package plural
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Count is an explicit plural count.
+//
+// Passing a Count to a TranslateFunc makes clear that the argument selects the plural form
+// and allows the number of visible fraction digits to be chosen (e.g. 1 with two digits is 1.00,
+// which is "other" in English).
+type Count struct {
+	value  interface{}
+	digits int
+}
+
+// NewCount returns a Count of v, which may be any type supported by NewOperands.
+func NewCount(v interface{}) Count {
+	return Count{value: v, digits: -1}
+}
+
+// WithDigits returns c rounded or padded to the number of visible fraction digits.
+func (c Count) WithDigits(digits int) Count {
+	c.digits = digits
+	return c
+}
+
+// Value returns the number that c was created with.
+func (c Count) Value() interface{} {
+	return c.value
+}
+
+// String returns c as a decimal number with its visible fraction digits (e.g. 1.50).
+func (c Count) String() string {
+	s, err := decimal(c)
+	if err != nil {
+		return fmt.Sprint(c.value)
+	}
+	return s
+}
+
+// decimal formats v as a decimal number without an exponent.
+func decimal(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case int:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return "", fmt.Errorf("invalid number %v", v)
+		}
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return "", fmt.Errorf("invalid number %v", v)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case *big.Float:
+		if v == nil || v.IsInf() {
+			return "", fmt.Errorf("invalid number %v", v)
+		}
+		return v.Text('f', -1), nil
+	case *big.Int:
+		if v == nil {
+			return "", fmt.Errorf("invalid number %v", v)
+		}
+		return v.String(), nil
+	case string:
+		return v, nil
+	case Count:
+		s, err := decimal(v.value)
+		if err != nil || v.digits < 0 {
+			return s, err
+		}
+		if _, err := newOperandsString(s); err != nil {
+			return "", err
+		}
+		return round(s, v.digits), nil
+	default:
+		return "", fmt.Errorf("invalid type %T; expected integer, float or string", v)
+	}
+}
+
+// round rounds the decimal number s half away from zero to the number of fraction digits.
+func round(s string, digits int) string {
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+	for len(fraction) < digits {
+		fraction += "0"
+	}
+
+	roundUp := len(fraction) > digits && fraction[digits] >= '5'
+	number := []byte(integer + fraction[:digits])
+	for i := len(number) - 1; roundUp && i >= 0; i-- {
+		if number[i] == '9' {
+			number[i] = '0'
+		} else {
+			number[i]++
+			roundUp = false
+		}
+	}
+	if roundUp {
+		number = append([]byte{'1'}, number...)
+	}
+
+	integer, fraction = string(number[:len(number)-digits]), string(number[len(number)-digits:])
+	if digits > 0 {
+		return sign + integer + "." + fraction
+	}
+	return sign + integer
+}
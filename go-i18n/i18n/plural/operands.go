@@ -9,13 +9,18 @@ import (
 // http://unicode.org/reports/tr35/tr35-numbers.html#Operands
 type Operands struct {
 	N float64 // absolute value of the source number (integer and decimals)
-	I int64   // integer digits of n
+	I int64   // integer digits of n, the last 18 plus 10^18 if there are more
 	V int     // number of visible fraction digits in n, with trailing zeros
 	W int     // number of visible fraction digits in n, without trailing zeros
 	F int     // visible fractional digits in n, with trailing zeros
 	T int     // visible fractional digits in n, without trailing zeros
 }
 
+// NewOperands returns the operands of v, which may be an integer, a float,
+// a *big.Float, a *big.Int, a Count or a decimal number formatted as a string (e.g. "1.50").
+//
+// Floats have as many visible fraction digits as needed to represent them exactly
+// (e.g. 1.5 has one); use a Count to choose the number of visible fraction digits.
 func NewOperands(v interface{}) (*Operands, error) {
 	switch v := v.(type) {
 	case int:
@@ -30,10 +35,12 @@ func NewOperands(v interface{}) (*Operands, error) {
 		return newOperandsInt64(v), nil
 	case string:
 		return newOperandsString(v)
-	case float32, float64:
-		return nil, fmt.Errorf("floats should be formatted into a string")
 	default:
-		return nil, fmt.Errorf("invalid type %T; expected integer or string", v)
+		s, err := decimal(v)
+		if err != nil {
+			return nil, err
+		}
+		return newOperandsString(s)
 	}
 }
 
@@ -45,6 +52,9 @@ func newOperandsInt64(i int64) *Operands {
 }
 
 func newOperandsString(s string) (*Operands, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty number")
+	}
 	if s[0] == '-' {
 		s = s[1:]
 	}
@@ -54,7 +64,7 @@ func newOperandsString(s string) (*Operands, error) {
 	}
 	ops := &Operands{N: n}
 	parts := strings.SplitN(s, ".", 2)
-	ops.I, err = strconv.ParseInt(parts[0], 10, 64)
+	ops.I, err = parseDigits(parts[0])
 	if err != nil {
 		return nil, err
 	}
@@ -70,14 +80,14 @@ func newOperandsString(s string) (*Operands, error) {
 		}
 	}
 	if ops.V > 0 {
-		f, err := strconv.ParseInt(fraction, 10, 0)
+		f, err := parseDigits(fraction)
 		if err != nil {
 			return nil, err
 		}
 		ops.F = int(f)
 	}
 	if ops.W > 0 {
-		t, err := strconv.ParseInt(fraction[:ops.W], 10, 0)
+		t, err := parseDigits(fraction[:ops.W])
 		if err != nil {
 			return nil, err
 		}
@@ -85,3 +95,28 @@ func newOperandsString(s string) (*Operands, error) {
 	}
 	return ops, nil
 }
+
+// maxDigits is the number of decimal digits of an operand that always fit an int64.
+const maxDigits = 18
+
+// parseDigits returns the value of the decimal digits s.
+//
+// Values with more than maxDigits digits (e.g. a *big.Int above 2^63) keep their last
+// maxDigits digits plus 10^maxDigits, so that the remainders used by CLDR rules
+// (e.g. i % 100) are right and the value stays larger than any value the rules compare it with.
+func parseDigits(s string) (int64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid number %q", s)
+		}
+	}
+	digits := strings.TrimLeft(s, "0")
+	if len(digits) <= maxDigits {
+		return strconv.ParseInt("0"+digits, 10, 64)
+	}
+	i, err := strconv.ParseInt(digits[len(digits)-maxDigits:], 10, 64)
+	return i + 1e18, err
+}
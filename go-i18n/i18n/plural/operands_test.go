@@ -1,6 +1,8 @@
 package plural
 
 import (
+	"math"
+	"math/big"
 	"reflect"
 	"testing"
 )
@@ -22,7 +24,33 @@ func TestNewOperands(t *testing.T) {
 		{"1.03", &Operands{1.03, 1, 2, 2, 3, 3}, false},
 		{"1.230", &Operands{1.23, 1, 3, 2, 230, 23}, false},
 		{"20.0230", &Operands{20.023, 20, 4, 3, 230, 23}, false},
-		{20.0230, nil, true},
+		{20.0230, &Operands{20.023, 20, 3, 3, 23, 23}, false},
+		{float32(1.5), &Operands{1.5, 1, 1, 1, 5, 5}, false},
+		{-2.0, &Operands{2.0, 2, 0, 0, 0, 0}, false},
+		{uint(3), &Operands{3.0, 3, 0, 0, 0, 0}, false},
+		{big.NewFloat(1.25), &Operands{1.25, 1, 2, 2, 25, 25}, false},
+		{big.NewInt(42), &Operands{42.0, 42, 0, 0, 0, 0}, false},
+		{NewCount(1), &Operands{1.0, 1, 0, 0, 0, 0}, false},
+		{NewCount(1).WithDigits(2), &Operands{1.0, 1, 2, 0, 0, 0}, false},
+		{NewCount(1.5).WithDigits(2), &Operands{1.5, 1, 2, 1, 50, 5}, false},
+		{NewCount(1.26).WithDigits(1), &Operands{1.3, 1, 1, 1, 3, 3}, false},
+		{NewCount("1.50"), &Operands{1.5, 1, 2, 1, 50, 5}, false},
+		{NewCount(big.NewFloat(2)).WithDigits(1), &Operands{2.0, 2, 1, 0, 0, 0}, false},
+		{"12345678901234567890123", &Operands{1.2345678901234568e22, 1e18 + 678901234567890123, 0, 0, 0, 0}, false},
+		{"100000000000000000001.5", &Operands{1e20, 1e18 + 1, 1, 1, 5, 5}, false},
+		{"0.1234567890123456789", &Operands{0.12345678901234568, 0, 19, 19, 1e18 + 234567890123456789, 1e18 + 234567890123456789}, false},
+		{1e30, &Operands{1e30, 1e18, 0, 0, 0, 0}, false},
+		{uint64(math.MaxUint64), &Operands{math.MaxUint64, 1e18 + 446744073709551615, 0, 0, 0, 0}, false},
+		{new(big.Int).Lsh(big.NewInt(1), 64), &Operands{1 << 64, 1e18 + 446744073709551616, 0, 0, 0, 0}, false},
+		{new(big.Float).SetMantExp(big.NewFloat(1.5), 70), &Operands{1.5 * (1 << 70), 1e18 + 887431076117000000, 0, 0, 0, 0}, false},
+		{"", nil, true},
+		{"one", nil, true},
+		{"1e5", nil, true},
+		{".5", nil, true},
+		{math.NaN(), nil, true},
+		{math.Inf(1), nil, true},
+		{NewCount(true), nil, true},
+		{true, nil, true},
 	}
 	for _, test := range tests {
 		ops, err := NewOperands(test.input)
@@ -36,6 +64,30 @@ func TestNewOperands(t *testing.T) {
 	}
 }
 
+func TestCountString(t *testing.T) {
+	tests := []struct {
+		count Count
+		s     string
+	}{
+		{NewCount(1), "1"},
+		{NewCount(1).WithDigits(2), "1.00"},
+		{NewCount(1.5), "1.5"},
+		{NewCount(float32(0.1)), "0.1"},
+		{NewCount(1.234).WithDigits(2), "1.23"},
+		{NewCount("-1.5").WithDigits(0), "-2"},
+		{NewCount(12.345).WithDigits(2), "12.35"},
+		{NewCount(9.96).WithDigits(1), "10.0"},
+		{NewCount(-0.5).WithDigits(0), "-1"},
+		{NewCount(0.04).WithDigits(1), "0.0"},
+		{NewCount(true), "true"},
+	}
+	for _, test := range tests {
+		if s := test.count.String(); s != test.s {
+			t.Errorf("%#v.String() = %q; expected %q", test.count, s, test.s)
+		}
+	}
+}
+
 func BenchmarkNewOperand(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		if _, err := NewOperands("1234.56780000"); err != nil {
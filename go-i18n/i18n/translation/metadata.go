@@ -0,0 +1,120 @@
+package translation
+
+import (
+	"fmt"
+	"math"
+)
+
+// Metadata describes a translation to translators and tools.
+// Only Markup changes how a translation is rendered.
+type Metadata struct {
+	// Description explains where and how the string is used.
+	Description string
+	// Context disambiguates strings that have the same text but need different translations
+	// (e.g. "Close" the dialog versus "close" to the shore).
+	Context string
+	// MaxLength is the maximum number of characters of each template, not counting {{actions}}.
+	// Zero means there is no maximum.
+	MaxLength int
+	// Tags group translations (e.g. by screen or feature).
+	Tags []string
+	// Markup reports that the translation intentionally contains HTML markup,
+	// so its text is not escaped when it is rendered as HTML.
+	Markup bool
+}
+
+// ParseMetadata reads the optional "description", "context", "maxLength", "tags" and "markup" keys of data.
+func ParseMetadata(data map[string]interface{}) (Metadata, error) {
+	var m Metadata
+	var ok bool
+	if v := data["description"]; v != nil {
+		if m.Description, ok = v.(string); !ok {
+			return m, fmt.Errorf(`unsupported type for "description" key %T`, v)
+		}
+	}
+	if v := data["context"]; v != nil {
+		if m.Context, ok = v.(string); !ok {
+			return m, fmt.Errorf(`unsupported type for "context" key %T`, v)
+		}
+	}
+	switch v := data["maxLength"].(type) {
+	case nil:
+	case int:
+		m.MaxLength = v
+	case int64:
+		m.MaxLength = int(v)
+	case float64:
+		if v != math.Trunc(v) {
+			return m, fmt.Errorf(`"maxLength" key %v is not an integer`, v)
+		}
+		m.MaxLength = int(v)
+	default:
+		return m, fmt.Errorf(`unsupported type for "maxLength" key %T`, v)
+	}
+	if m.MaxLength < 0 {
+		return m, fmt.Errorf(`"maxLength" key %d is negative`, m.MaxLength)
+	}
+	switch v := data["tags"].(type) {
+	case nil:
+	case []string:
+		m.Tags = v
+	case []interface{}:
+		for _, tag := range v {
+			s, ok := tag.(string)
+			if !ok {
+				return m, fmt.Errorf(`tag has value of type %T; expected string`, tag)
+			}
+			m.Tags = append(m.Tags, s)
+		}
+	default:
+		return m, fmt.Errorf(`unsupported type for "tags" key %T`, v)
+	}
+	switch v := data["markup"].(type) {
+	case nil:
+	case bool:
+		m.Markup = v
+	default:
+		return m, fmt.Errorf(`unsupported type for "markup" key %T`, v)
+	}
+	return m, nil
+}
+
+// Merge returns m with the fields that are set in other.
+func (m Metadata) Merge(other Metadata) Metadata {
+	if other.Description != "" {
+		m.Description = other.Description
+	}
+	if other.Context != "" {
+		m.Context = other.Context
+	}
+	if other.MaxLength != 0 {
+		m.MaxLength = other.MaxLength
+	}
+	if len(other.Tags) > 0 {
+		m.Tags = other.Tags
+	}
+	if other.Markup {
+		m.Markup = true
+	}
+	return m
+}
+
+// marshal adds the fields of m that are set to data.
+func (m Metadata) marshal(data map[string]interface{}) map[string]interface{} {
+	if m.Description != "" {
+		data["description"] = m.Description
+	}
+	if m.Context != "" {
+		data["context"] = m.Context
+	}
+	if m.MaxLength != 0 {
+		data["maxLength"] = m.MaxLength
+	}
+	if len(m.Tags) > 0 {
+		data["tags"] = m.Tags
+	}
+	if m.Markup {
+		data["markup"] = true
+	}
+	return data
+}
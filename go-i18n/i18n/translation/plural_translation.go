@@ -8,13 +8,19 @@ import (
 type pluralTranslation struct {
 	id        string
 	templates map[plural.Category]*template
+	ordinal   bool
+	metadata  Metadata
 }
 
 func (pt *pluralTranslation) MarshalInterface() interface{} {
-	return map[string]interface{}{
+	data := map[string]interface{}{
 		"id":          pt.id,
 		"translation": pt.templates,
 	}
+	if pt.ordinal {
+		data["ordinal"] = true
+	}
+	return pt.metadata.marshal(data)
 }
 
 func (pt *pluralTranslation) ID() string {
@@ -26,18 +32,27 @@ func (pt *pluralTranslation) Template(pc plural.Category) *template {
 }
 
 func (pt *pluralTranslation) UntranslatedCopy() Translation {
-	return &pluralTranslation{pt.id, make(map[plural.Category]*template)}
+	return &pluralTranslation{pt.id, make(map[plural.Category]*template), pt.ordinal, pt.metadata}
+}
+
+// categories returns the plural categories of l that pt is keyed by.
+func (pt *pluralTranslation) categories(l *language.Language) map[plural.Category]struct{} {
+	if pt.ordinal {
+		return l.OrdinalCategories
+	}
+	return l.PluralCategories
 }
 
 func (pt *pluralTranslation) Normalize(l *language.Language) Translation {
 	// Delete plural categories that don't belong to this language.
+	categories := pt.categories(l)
 	for pc := range pt.templates {
-		if _, ok := l.PluralCategories[pc]; !ok {
+		if _, ok := categories[pc]; !ok {
 			delete(pt.templates, pc)
 		}
 	}
 	// Create map entries for missing valid categories.
-	for pc := range l.PluralCategories {
+	for pc := range categories {
 		if _, ok := pt.templates[pc]; !ok {
 			pt.templates[pc] = mustNewTemplate("")
 		}
@@ -51,12 +66,13 @@ func (pt *pluralTranslation) Backfill(src Translation) Translation {
 			pt.templates[pc] = src.Template(plural.Other)
 		}
 	}
+	pt.metadata = src.Metadata().Merge(pt.metadata)
 	return pt
 }
 
 func (pt *pluralTranslation) Merge(t Translation) Translation {
 	other, ok := t.(*pluralTranslation)
-	if !ok || pt.ID() != t.ID() {
+	if !ok || pt.ID() != t.ID() || pt.ordinal != other.ordinal {
 		return t
 	}
 	for pluralCategory, template := range other.templates {
@@ -64,11 +80,12 @@ func (pt *pluralTranslation) Merge(t Translation) Translation {
 			pt.templates[pluralCategory] = template
 		}
 	}
+	pt.metadata = pt.metadata.Merge(other.metadata)
 	return pt
 }
 
 func (pt *pluralTranslation) Incomplete(l *language.Language) bool {
-	for pc := range l.PluralCategories {
+	for pc := range pt.categories(l) {
 		if t := pt.templates[pc]; t == nil || t.src == "" {
 			return true
 		}
@@ -76,4 +93,12 @@ func (pt *pluralTranslation) Incomplete(l *language.Language) bool {
 	return false
 }
 
+func (pt *pluralTranslation) Ordinal() bool {
+	return pt.ordinal
+}
+
+func (pt *pluralTranslation) Metadata() Metadata {
+	return pt.metadata
+}
+
 var _ = Translation(&pluralTranslation{})
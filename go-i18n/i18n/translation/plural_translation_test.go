@@ -19,7 +19,7 @@ func pluralTranslationFixture(t *testing.T, id string, pluralCategories ...plura
 	for _, pc := range pluralCategories {
 		templates[pc] = mustTemplate(t, string(pc))
 	}
-	return &pluralTranslation{id, templates}
+	return &pluralTranslation{id, templates, false, Metadata{}}
 }
 
 func verifyDeepEqual(t *testing.T, actual, expected interface{}) {
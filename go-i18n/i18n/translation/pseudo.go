@@ -0,0 +1,82 @@
+package translation
+
+import (
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// pseudoAccents maps ASCII letters to accented letters that remain readable.
+var pseudoAccents = strings.NewReplacer(
+	"a", "á", "b", "ƀ", "c", "ç", "d", "ð", "e", "é", "f", "ƒ", "g", "ĝ", "h", "ĥ", "i", "î",
+	"j", "ĵ", "k", "ķ", "l", "ļ", "m", "ɱ", "n", "ñ", "o", "ö", "p", "þ", "q", "ǫ", "r", "ŕ",
+	"s", "š", "t", "ţ", "u", "û", "v", "ṽ", "w", "ŵ", "x", "ẋ", "y", "ý", "z", "ž",
+	"A", "Å", "B", "Ɓ", "C", "Ç", "D", "Ð", "E", "É", "F", "Ƒ", "G", "Ĝ", "H", "Ĥ", "I", "Î",
+	"J", "Ĵ", "K", "Ķ", "L", "Ļ", "M", "Ṁ", "N", "Ñ", "O", "Ö", "P", "Þ", "Q", "Ǫ", "R", "Ŕ",
+	"S", "Š", "T", "Ţ", "U", "Û", "V", "Ṽ", "W", "Ŵ", "X", "Ẋ", "Y", "Ý", "Z", "Ž",
+)
+
+// pseudoActions matches the {{actions}} of a template.
+var pseudoActions = regexp.MustCompile(`(?s)\{\{.*?\}\}`)
+
+// pseudoMarkup matches the {{actions}}, HTML tags and character references of a markup template.
+var pseudoMarkup = regexp.MustCompile(`(?s)\{\{.*?\}\}|<[^>]*>|&#?[0-9A-Za-z]+;`)
+
+// pseudoExpansion is how much longer pseudolocalized text is than the source text,
+// which is about how much longer many translations are than English.
+const pseudoExpansion = 0.3
+
+// Pseudolocalize returns a copy of t whose templates have accented letters, are padded
+// to be about 30% longer and are wrapped in brackets (e.g. "Hello {{.Person}}" becomes
+// "[Ĥéļļö {{.Person}}~~]"). The {{actions}} of the templates are not changed, and neither
+// are the HTML tags of translations that are marked as markup.
+//
+// Pseudolocalized text shows which strings of a user interface are not translated
+// and which ones are truncated when they get longer.
+func Pseudolocalize(t Translation) Translation {
+	metadata := t.Metadata()
+	switch t := t.(type) {
+	case *singleTranslation:
+		return &singleTranslation{t.id, pseudoTemplate(t.template, metadata.Markup), metadata}
+	case *pluralTranslation:
+		templates := make(map[plural.Category]*template, len(t.templates))
+		for pc, tmpl := range t.templates {
+			templates[pc] = pseudoTemplate(tmpl, metadata.Markup)
+		}
+		return &pluralTranslation{t.id, templates, t.ordinal, metadata}
+	}
+	return t
+}
+
+// pseudoTemplate returns the pseudolocalized template of t.
+func pseudoTemplate(t *template, markup bool) *template {
+	if t == nil || t.src == "" {
+		return t
+	}
+	skip := pseudoActions
+	if markup {
+		skip = pseudoMarkup
+	}
+
+	var src []string
+	length := 0
+	last := 0
+	for _, loc := range skip.FindAllStringIndex(t.src, -1) {
+		text := t.src[last:loc[0]]
+		length += utf8.RuneCountInString(text)
+		src = append(src, pseudoAccents.Replace(text), t.src[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	text := t.src[last:]
+	length += utf8.RuneCountInString(text)
+	src = append(src, pseudoAccents.Replace(text))
+
+	padding := strings.Repeat("~", int(math.Ceil(float64(length)*pseudoExpansion)))
+	pseudo, err := newTemplate("[" + strings.Join(src, "") + padding + "]")
+	if err != nil {
+		return t
+	}
+	return pseudo
+}
@@ -0,0 +1,47 @@
+package translation
+
+import (
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
+	"testing"
+)
+
+func TestPseudolocalize(t *testing.T) {
+	tests := []struct {
+		data     map[string]interface{}
+		category plural.Category
+		src      string
+	}{
+		{map[string]interface{}{"id": "a", "translation": "Hello {{.Person}}"}, plural.Other, "[Ĥéļļö {{.Person}}~~]"},
+		{map[string]interface{}{"id": "b", "translation": "Wind"}, plural.Other, "[Ŵîñð~~]"},
+		{map[string]interface{}{"id": "c", "translation": "{{if .Gust}}Gust {{.Gust}}{{end}}"}, plural.Other, "[{{if .Gust}}Ĝûšţ {{.Gust}}{{end}}~~]"},
+		{map[string]interface{}{"id": "d", "translation": ""}, plural.Other, ""},
+		{map[string]interface{}{"id": "e", "translation": "<b>Wind &amp; Gust:</b>"}, plural.Other, "[<ƀ>Ŵîñð &áɱþ; Ĝûšţ:</ƀ>~~~~~~~]"},
+		{map[string]interface{}{"id": "f", "markup": true, "translation": "<b>Wind &amp; Gust:</b>"}, plural.Other, "[<b>Ŵîñð &amp; Ĝûšţ:</b>~~~~]"},
+		{map[string]interface{}{"id": "g", "translation": map[string]interface{}{"one": "{{.Count}} day", "other": "{{.Count}} days"}}, plural.One, "[{{.Count}} ðáý~~]"},
+		{map[string]interface{}{"id": "g", "translation": map[string]interface{}{"one": "{{.Count}} day", "other": "{{.Count}} days"}}, plural.Other, "[{{.Count}} ðáýš~~]"},
+	}
+	for _, test := range tests {
+		translation, err := NewTranslation(test.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pseudo := Pseudolocalize(translation)
+		if pseudo.ID() != translation.ID() || pseudo.Metadata().Markup != translation.Metadata().Markup {
+			t.Errorf("Pseudolocalize(%v) returned %v; expected the same id and metadata", test.data, pseudo.MarshalInterface())
+		}
+		if src := pseudo.Template(test.category).String(); src != test.src {
+			t.Errorf("Pseudolocalize(%v) template %s is %q; expected %q", test.data, test.category, src, test.src)
+		}
+	}
+}
+
+func TestPseudolocalizeExecute(t *testing.T) {
+	translation, err := NewTranslation(map[string]interface{}{"id": "a", "translation": "Hello {{.Person}}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "[Ĥéļļö Bob~~]"
+	if s := Pseudolocalize(translation).Template(plural.Other).Execute(map[string]string{"Person": "Bob"}); s != expected {
+		t.Errorf("Execute returned %q; expected %q", s, expected)
+	}
+}
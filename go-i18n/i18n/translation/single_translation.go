@@ -8,13 +8,14 @@ import (
 type singleTranslation struct {
 	id       string
 	template *template
+	metadata Metadata
 }
 
 func (st *singleTranslation) MarshalInterface() interface{} {
-	return map[string]interface{}{
+	return st.metadata.marshal(map[string]interface{}{
 		"id":          st.id,
 		"translation": st.template,
-	}
+	})
 }
 
 func (st *singleTranslation) ID() string {
@@ -26,7 +27,7 @@ func (st *singleTranslation) Template(pc plural.Category) *template {
 }
 
 func (st *singleTranslation) UntranslatedCopy() Translation {
-	return &singleTranslation{st.id, mustNewTemplate("")}
+	return &singleTranslation{st.id, mustNewTemplate(""), st.metadata}
 }
 
 func (st *singleTranslation) Normalize(language *language.Language) Translation {
@@ -37,6 +38,7 @@ func (st *singleTranslation) Backfill(src Translation) Translation {
 	if st.template == nil || st.template.src == "" {
 		st.template = src.Template(plural.Other)
 	}
+	st.metadata = src.Metadata().Merge(st.metadata)
 	return st
 }
 
@@ -48,6 +50,7 @@ func (st *singleTranslation) Merge(t Translation) Translation {
 	if other.template != nil && other.template.src != "" {
 		st.template = other.template
 	}
+	st.metadata = st.metadata.Merge(other.metadata)
 	return st
 }
 
@@ -55,4 +58,12 @@ func (st *singleTranslation) Incomplete(l *language.Language) bool {
 	return st.template == nil || st.template.src == ""
 }
 
+func (st *singleTranslation) Ordinal() bool {
+	return false
+}
+
+func (st *singleTranslation) Metadata() Metadata {
+	return st.metadata
+}
+
 var _ = Translation(&singleTranslation{})
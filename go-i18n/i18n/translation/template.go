@@ -3,14 +3,22 @@ package translation
 import (
 	"bytes"
 	"encoding"
+	"html"
+	htmltemplate "html/template"
 	"strings"
 	//"launchpad.net/goyaml"
 	gotemplate "text/template"
+	"text/template/parse"
 )
 
 type template struct {
 	tmpl *gotemplate.Template
 	src  string
+
+	// html is tmpl with its text escaped, so that it renders as HTML text.
+	html *htmltemplate.Template
+	// markup renders src as trusted HTML markup.
+	markup *htmltemplate.Template
 }
 
 func newTemplate(src string) (*template, error) {
@@ -42,6 +50,29 @@ func (t *template) Execute(args interface{}) string {
 	return buf.String()
 }
 
+// ExecuteHTML renders the template as HTML.
+//
+// The text of the template is escaped unless markup is true, in which case
+// the template is trusted HTML. Either way the data inserted by {{actions}}
+// is escaped for its context, except for values of type html/template.HTML.
+func (t *template) ExecuteHTML(args interface{}, markup bool) htmltemplate.HTML {
+	tmpl := t.html
+	if markup {
+		tmpl = t.markup
+	}
+	if tmpl == nil {
+		if markup {
+			return htmltemplate.HTML(t.src)
+		}
+		return htmltemplate.HTML(html.EscapeString(t.src))
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, args); err != nil {
+		return htmltemplate.HTML(html.EscapeString(err.Error()))
+	}
+	return htmltemplate.HTML(buf.String())
+}
+
 func (t *template) MarshalText() ([]byte, error) {
 	return []byte(t.src), nil
 }
@@ -53,11 +84,43 @@ func (t *template) UnmarshalText(src []byte) error {
 func (t *template) parseTemplate(src string) (err error) {
 	t.src = src
 	if strings.Contains(src, "{{") {
-		t.tmpl, err = gotemplate.New(src).Parse(src)
+		if t.tmpl, err = gotemplate.New(src).Parse(src); err != nil {
+			return
+		}
+		if t.markup, err = htmltemplate.New(src).Parse(src); err != nil {
+			return
+		}
+		tree := t.tmpl.Tree.Copy()
+		escapeText(tree.Root)
+		t.html, err = htmltemplate.New(src).AddParseTree(src, tree)
 	}
 	return
 }
 
+// escapeText escapes the text of node and its children as HTML.
+func escapeText(node parse.Node) {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return
+		}
+		for _, n := range node.Nodes {
+			escapeText(n)
+		}
+	case *parse.TextNode:
+		node.Text = []byte(html.EscapeString(string(node.Text)))
+	case *parse.IfNode:
+		escapeText(node.List)
+		escapeText(node.ElseList)
+	case *parse.RangeNode:
+		escapeText(node.List)
+		escapeText(node.ElseList)
+	case *parse.WithNode:
+		escapeText(node.List)
+		escapeText(node.ElseList)
+	}
+}
+
 var _ = encoding.TextMarshaler(&template{})
 var _ = encoding.TextUnmarshaler(&template{})
 
@@ -3,6 +3,7 @@ package translation
 import (
 	"bytes"
 	"fmt"
+	htmltemplate "html/template"
 	//"launchpad.net/goyaml"
 	"testing"
 	gotemplate "text/template"
@@ -42,6 +43,42 @@ func TestUnmarshalText(t *testing.T) {
 	}
 }
 
+func TestExecuteHTML(t *testing.T) {
+	tests := []struct {
+		src    string
+		markup bool
+		data   map[string]interface{}
+		result htmltemplate.HTML
+	}{
+		{"Fish & Chips", false, nil, "Fish &amp; Chips"},
+		{"<b>Wind Speed:</b>", true, nil, "<b>Wind Speed:</b>"},
+		{"<b>{{.Name}}</b> & {{.Other}}", false, map[string]interface{}{
+			"Name":  "Gulf <Station>",
+			"Other": htmltemplate.HTML("<i>nested</i>"),
+		}, "&lt;b&gt;Gulf &lt;Station&gt;&lt;/b&gt; &amp; <i>nested</i>"},
+		{`<a href="/buoy/{{.ID}}" title="{{.Name}}">{{.Name}}</a>`, true, map[string]interface{}{
+			"ID":   "42 002",
+			"Name": `"Gulf" <Station>`,
+		}, `<a href="/buoy/42%20002" title="&#34;Gulf&#34; &lt;Station&gt;">&#34;Gulf&#34; &lt;Station&gt;</a>`},
+		{"{{if .Count}}{{.Count}} < 10{{else}}none & more{{end}}", false, map[string]interface{}{
+			"Count": 3,
+		}, "3 &lt; 10"},
+		{"{{if .Count}}{{.Count}} < 10{{else}}none & more{{end}}", false, nil, "none &amp; more"},
+	}
+	for _, test := range tests {
+		tmpl := mustNewTemplate(test.src)
+		if result := tmpl.ExecuteHTML(test.data, test.markup); result != test.result {
+			t.Errorf("ExecuteHTML of %q with markup %t returned %q; expected %q", test.src, test.markup, result, test.result)
+		}
+	}
+
+	// The plain text rendering is unchanged.
+	tmpl := mustNewTemplate("<b>{{.Name}}</b>")
+	if result := tmpl.Execute(map[string]interface{}{"Name": "<Station>"}); result != "<b><Station></b>" {
+		t.Errorf("Execute returned %q; expected %q", result, "<b><Station></b>")
+	}
+}
+
 /*
 func TestYAMLMarshal(t *testing.T) {
 	src := "hello {{.World}}"
@@ -19,6 +19,11 @@ type Translation interface {
 	Backfill(src Translation) Translation
 	Merge(Translation) Translation
 	Incomplete(l *language.Language) bool
+	// Ordinal reports whether the templates of the translation
+	// are selected by ordinal instead of cardinal plural categories.
+	Ordinal() bool
+	// Metadata returns the description, context, maximum length and tags of the translation.
+	Metadata() Metadata
 }
 
 // SortableByID implements sort.Interface for a slice of translations.
@@ -32,18 +37,38 @@ func (a SortableByID) Less(i, j int) bool { return a[i].ID() < a[j].ID() }
 //
 // data["id"] must be a string and data["translation"] must be either a string
 // for a non-plural translation or a map[string]interface{} for a plural translation.
+//
+// A plural translation whose data["ordinal"] is true is keyed by ordinal plural categories
+// (e.g. 1st, 2nd, 3rd) instead of cardinal plural categories (e.g. 1 day, 2 days).
+//
+// The optional metadata keys are read by ParseMetadata.
 func NewTranslation(data map[string]interface{}) (Translation, error) {
 	id, ok := data["id"].(string)
 	if !ok {
 		return nil, fmt.Errorf(`missing "id" key`)
 	}
+	var ordinal bool
+	switch v := data["ordinal"].(type) {
+	case nil:
+	case bool:
+		ordinal = v
+	default:
+		return nil, fmt.Errorf(`unsupported type for "ordinal" key %T`, v)
+	}
+	metadata, err := ParseMetadata(data)
+	if err != nil {
+		return nil, err
+	}
 	switch translation := data["translation"].(type) {
 	case string:
+		if ordinal {
+			return nil, fmt.Errorf(`ordinal translation must have plural categories`)
+		}
 		tmpl, err := newTemplate(translation)
 		if err != nil {
 			return nil, err
 		}
-		return &singleTranslation{id, tmpl}, nil
+		return &singleTranslation{id, tmpl, metadata}, nil
 	case map[string]interface{}:
 		templates := make(map[plural.Category]*template, len(translation))
 		for k, v := range translation {
@@ -61,7 +86,7 @@ func NewTranslation(data map[string]interface{}) (Translation, error) {
 			}
 			templates[pc] = tmpl
 		}
-		return &pluralTranslation{id, templates}, nil
+		return &pluralTranslation{id, templates, ordinal, metadata}, nil
 	case nil:
 		return nil, fmt.Errorf(`missing "translation" key`)
 	default:
@@ -1,6 +1,8 @@
 package translation
 
 import (
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/language"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/plural"
 	"sort"
 	"testing"
 )
@@ -15,3 +17,123 @@ func TestNewSingleTranslation(t *testing.T) {
 func TestNewPluralTranslation(t *testing.T) {
 	t.Skipf("not implemented")
 }
+
+func TestNewOrdinalTranslation(t *testing.T) {
+	tr, err := NewTranslation(map[string]interface{}{
+		"id":      "rank",
+		"ordinal": true,
+		"translation": map[string]interface{}{
+			"one":   "{{.Count}}st",
+			"other": "{{.Count}}th",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tr.Ordinal() {
+		t.Errorf("expected translation to be ordinal")
+	}
+	data := tr.MarshalInterface().(map[string]interface{})
+	if data["ordinal"] != true {
+		t.Errorf("MarshalInterface returned %#v; expected ordinal", data)
+	}
+
+	en := language.LanguageWithID("en")
+	if !tr.Incomplete(en) {
+		t.Errorf("expected translation without two and few to be incomplete")
+	}
+	tr.Normalize(en)
+	for _, pc := range []plural.Category{plural.One, plural.Two, plural.Few, plural.Other} {
+		if tr.Template(pc) == nil {
+			t.Errorf("Normalize did not add ordinal category %s", pc)
+		}
+	}
+
+	_, err = NewTranslation(map[string]interface{}{
+		"id":          "rank",
+		"ordinal":     true,
+		"translation": "rank",
+	})
+	if err == nil {
+		t.Errorf("expected an error for an ordinal translation without plural categories")
+	}
+}
+
+func TestTranslationMetadata(t *testing.T) {
+	translations := []map[string]interface{}{
+		{
+			"id":          "close",
+			"translation": "Close",
+		},
+		{
+			"id": "d_days",
+			"translation": map[string]interface{}{
+				"one":   "{{.Count}} day",
+				"other": "{{.Count}} days",
+			},
+		},
+	}
+	expected := Metadata{
+		Description: "Button that closes the station dialog",
+		Context:     "dialog",
+		MaxLength:   12,
+		Tags:        []string{"buoy", "modal"},
+	}
+	for _, data := range translations {
+		data["description"] = expected.Description
+		data["context"] = expected.Context
+		data["maxLength"] = float64(expected.MaxLength)
+		data["tags"] = []interface{}{"buoy", "modal"}
+
+		tr, err := NewTranslation(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verifyDeepEqual(t, tr.Metadata(), expected)
+		verifyDeepEqual(t, tr.UntranslatedCopy().Metadata(), expected)
+
+		marshaled := tr.MarshalInterface().(map[string]interface{})
+		if marshaled["description"] != expected.Description || marshaled["context"] != expected.Context || marshaled["maxLength"] != expected.MaxLength {
+			t.Errorf("MarshalInterface returned %#v; expected metadata %#v", marshaled, expected)
+		}
+		verifyDeepEqual(t, marshaled["tags"], expected.Tags)
+
+		delete(data, "description")
+		delete(data, "tags")
+		data["maxLength"] = 20
+		other, err := NewTranslation(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		merged := expected
+		merged.MaxLength = 20
+		verifyDeepEqual(t, tr.Merge(other).Metadata(), merged)
+
+		bare, err := NewTranslation(map[string]interface{}{"id": data["id"], "translation": data["translation"]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if marshaled := bare.MarshalInterface().(map[string]interface{}); len(marshaled) != 2 {
+			t.Errorf("MarshalInterface returned %#v; expected no metadata", marshaled)
+		}
+		verifyDeepEqual(t, bare.Backfill(tr).Metadata(), merged)
+	}
+}
+
+func TestParseMetadataErrors(t *testing.T) {
+	tests := []map[string]interface{}{
+		{"description": 1},
+		{"context": true},
+		{"maxLength": 1.5},
+		{"maxLength": -1},
+		{"maxLength": "10"},
+		{"tags": "buoy"},
+		{"tags": []interface{}{"buoy", 1}},
+		{"markup": "true"},
+	}
+	for _, data := range tests {
+		if m, err := ParseMetadata(data); err == nil {
+			t.Errorf("ParseMetadata(%#v) returned %#v; expected an error", data, m)
+		}
+	}
+}
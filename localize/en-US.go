@@ -11,8 +11,146 @@ var EnUS = `[
 		"id": "application_error",
 		"translation": "An Application Error has occured."
 	},
+	{
+		"id": "wind_speed_mph",
+		"translation": "{{.Speed}} mph"
+	},
+	{
+		"id": "wind_speed_kmh",
+		"translation": "{{.Speed}} km/h"
+	},
+	{
+		"id": "wind_speed_knots",
+		"translation": "{{.Speed}} kn"
+	},
+	{
+		"id": "wind_speed_ms",
+		"translation": "{{.Speed}} m/s"
+	},
+	{
+		"id": "wind_speed_beaufort",
+		"translation": "Force {{.Speed}}"
+	},
+	{
+		"id": "compass_n",
+		"translation": "N"
+	},
+	{
+		"id": "compass_nne",
+		"translation": "NNE"
+	},
+	{
+		"id": "compass_ne",
+		"translation": "NE"
+	},
+	{
+		"id": "compass_ene",
+		"translation": "ENE"
+	},
+	{
+		"id": "compass_e",
+		"translation": "E"
+	},
+	{
+		"id": "compass_ese",
+		"translation": "ESE"
+	},
+	{
+		"id": "compass_se",
+		"translation": "SE"
+	},
+	{
+		"id": "compass_sse",
+		"translation": "SSE"
+	},
+	{
+		"id": "compass_s",
+		"translation": "S"
+	},
+	{
+		"id": "compass_ssw",
+		"translation": "SSW"
+	},
+	{
+		"id": "compass_sw",
+		"translation": "SW"
+	},
+	{
+		"id": "compass_wsw",
+		"translation": "WSW"
+	},
+	{
+		"id": "compass_w",
+		"translation": "W"
+	},
+	{
+		"id": "compass_wnw",
+		"translation": "WNW"
+	},
+	{
+		"id": "compass_nw",
+		"translation": "NW"
+	},
+	{
+		"id": "compass_nnw",
+		"translation": "NNW"
+	},
 	{
 		"id": "invalid_station_id",
 		"translation": "Invalid Station Id Or Missing"
+	},
+	{
+		"id": "invalid_station_ids",
+		"translation": "Between 1 And 100 Station Ids Of At Least 4 Characters Are Required"
+	},
+	{
+		"id": "invalid_region",
+		"translation": "Invalid Region Or Missing"
+	},
+	{
+		"id": "unauthorized",
+		"translation": "You Are Not Authorized To Perform This Action"
+	},
+	{
+		"id": "invalid_translation",
+		"translation": "The Translation Is Invalid"
+	},
+	{
+		"id": "invalid_translation_version",
+		"translation": "Invalid Translation Version Or Missing"
+	},
+	{
+		"id": "translation_version_conflict",
+		"translation": "The Translation Was Changed By Someone Else. Reload It And Try Again"
+	},
+	{
+		"id": "station_location_description_label",
+		"description": "Label of the location description in the station dialog",
+		"markup": true,
+		"translation": "<b>Location Description:</b>"
+	},
+	{
+		"id": "station_wind_speed_label",
+		"description": "Label of the wind speed in the station dialog",
+		"markup": true,
+		"translation": "<b>Wind Speed:</b>"
+	},
+	{
+		"id": "station_wind_direction_label",
+		"description": "Label of the wind direction in the station dialog",
+		"markup": true,
+		"translation": "<b>Wind Direction:</b>"
+	},
+	{
+		"id": "station_wind_gust_label",
+		"description": "Label of the wind gust in the station dialog",
+		"markup": true,
+		"translation": "<b>Wind Gust:</b>"
+	},
+	{
+		"id": "station_location_label",
+		"description": "Label of the coordinates in the station dialog",
+		"markup": true,
+		"translation": "<b>Location:</b>"
 	}
 ]`
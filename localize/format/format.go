@@ -0,0 +1,283 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+// Package format provides locale aware formatting of numbers, wind speeds,
+// wind directions and dates for the buoy readings.
+package format
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goinggo/beego-mgo/go-i18n/i18n"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+)
+
+//** TYPES
+
+type (
+	// Unit is a unit of wind speed.
+	Unit string
+
+	// Formatter formats values for a locale and a preferred wind speed unit.
+	Formatter struct {
+		LocaleID string
+		Unit     Unit
+		T        i18n.TranslateFunc
+		symbols  symbols
+		layout   string
+	}
+
+	// symbols are the separators used by the numbers of a locale.
+	symbols struct {
+		Decimal string
+		Group   string
+	}
+)
+
+//** CONSTANTS
+
+const (
+	// MilesPerHour is the unit the buoy readings are stored in.
+	MilesPerHour Unit = "mph"
+
+	// KilometersPerHour is the metric unit of wind speed.
+	KilometersPerHour Unit = "kmh"
+
+	// Knots is the nautical unit of wind speed.
+	Knots Unit = "knots"
+
+	// MetersPerSecond is the SI unit of wind speed.
+	MetersPerSecond Unit = "ms"
+
+	// Beaufort is the Beaufort wind force scale.
+	Beaufort Unit = "beaufort"
+)
+
+//** VARIABLES
+
+var (
+	// units maps each unit to its conversion from miles per hour.
+	units = map[Unit]float64{
+		MilesPerHour:      1,
+		KilometersPerHour: 1.609344,
+		Knots:             0.868976,
+		MetersPerSecond:   0.44704,
+		Beaufort:          0.44704,
+	}
+
+	// beaufortScale contains the lowest wind speed in m/s of the forces 1 to 12.
+	beaufortScale = []float64{0.3, 1.6, 3.4, 5.5, 8.0, 10.8, 13.9, 17.2, 20.8, 24.5, 28.5, 32.7}
+
+	// compassPoints contains the translation ids of the 16 compass points clockwise from north.
+	compassPoints = []string{
+		"compass_n", "compass_nne", "compass_ne", "compass_ene",
+		"compass_e", "compass_ese", "compass_se", "compass_sse",
+		"compass_s", "compass_ssw", "compass_sw", "compass_wsw",
+		"compass_w", "compass_wnw", "compass_nw", "compass_nnw",
+	}
+
+	// imperialRegions are the regions that report wind speeds in miles per hour.
+	imperialRegions = map[string]bool{
+		"US": true,
+		"GB": true,
+		"LR": true,
+		"MM": true,
+	}
+
+	// numberSymbols maps locales to their separators. Locales fall back to their parents.
+	numberSymbols = map[string]symbols{
+		"en":     {".", ","},
+		"ja":     {".", ","},
+		"ko":     {".", ","},
+		"zh":     {".", ","},
+		"he":     {".", ","},
+		"hi":     {".", ","},
+		"th":     {".", ","},
+		"es-MX":  {".", ","},
+		"es-US":  {".", ","},
+		"es-419": {".", ","},
+		"de-CH":  {".", "\u2019"},
+		"da":     {",", "."},
+		"de":     {",", "."},
+		"el":     {",", "."},
+		"es":     {",", "."},
+		"id":     {",", "."},
+		"it":     {",", "."},
+		"nl":     {",", "."},
+		"pt":     {",", "."},
+		"ro":     {",", "."},
+		"tr":     {",", "."},
+		"vi":     {",", "."},
+		"cs":     {",", "\u00a0"},
+		"fi":     {",", "\u00a0"},
+		"fr":     {",", "\u202f"},
+		"nb":     {",", "\u00a0"},
+		"pl":     {",", "\u00a0"},
+		"pt-PT":  {",", "\u00a0"},
+		"ru":     {",", "\u00a0"},
+		"sv":     {",", "\u00a0"},
+		"uk":     {",", "\u00a0"},
+	}
+
+	// dateLayouts maps locales to their time.Format layouts. Locales fall back to their parents.
+	dateLayouts = map[string]string{
+		"en":    "02/01/2006 15:04",
+		"en-US": "1/2/2006 3:04 PM",
+		"de":    "02.01.2006 15:04",
+		"es":    "02/01/2006 15:04",
+		"fr":    "02/01/2006 15:04",
+		"it":    "02/01/2006 15:04",
+		"ja":    "2006/01/02 15:04",
+		"nl":    "02-01-2006 15:04",
+		"pt":    "02/01/2006 15:04",
+		"ru":    "02.01.2006 15:04",
+		"sv":    "2006-01-02 15:04",
+		"zh":    "2006/01/02 15:04",
+	}
+)
+
+//** PUBLIC FUNCTIONS
+
+// New returns a Formatter for the locale that translates unit names and
+// compass points with T. An empty unit selects the default unit of the locale.
+func New(localeID string, unit Unit, T i18n.TranslateFunc) *Formatter {
+	if unit == "" {
+		unit = DefaultUnit(localeID)
+	}
+
+	formatter := Formatter{
+		LocaleID: localeID,
+		Unit:     unit,
+		T:        T,
+		symbols:  numberSymbols["en"],
+		layout:   "2006-01-02 15:04",
+	}
+
+	for _, id := range localeChain(localeID) {
+		if s, ok := numberSymbols[id]; ok {
+			formatter.symbols = s
+			break
+		}
+	}
+	for _, id := range localeChain(localeID) {
+		if layout, ok := dateLayouts[id]; ok {
+			formatter.layout = layout
+			break
+		}
+	}
+
+	return &formatter
+}
+
+// ParseUnit returns the unit named s (mph, kmh, knots, ms or beaufort).
+func ParseUnit(s string) (Unit, error) {
+	unit := Unit(strings.ToLower(s))
+	if _, ok := units[unit]; !ok {
+		return "", fmt.Errorf("Unknown Wind Speed Unit: %s", s)
+	}
+
+	return unit, nil
+}
+
+// DefaultUnit returns the wind speed unit commonly used in the region of the locale.
+func DefaultUnit(localeID string) Unit {
+	l, err := locale.New(localeID)
+	if err != nil {
+		return MilesPerHour
+	}
+
+	if l.Tag.Region == "" && l.Tag.Language == "en" || imperialRegions[l.Tag.Region] {
+		return MilesPerHour
+	}
+
+	return KilometersPerHour
+}
+
+// BeaufortForce returns the force on the Beaufort scale of a wind speed in miles per hour.
+func BeaufortForce(milesPerHour float64) int {
+	metersPerSecond := math.Floor(milesPerHour*units[MetersPerSecond]*10+0.5) / 10
+
+	force := 0
+	for force < len(beaufortScale) && metersPerSecond >= beaufortScale[force] {
+		force++
+	}
+
+	return force
+}
+
+//** PUBLIC METHODS
+
+// Decimal formats the value with the number of fraction digits
+// and the decimal and group separators of the locale.
+func (formatter *Formatter) Decimal(value float64, digits int) string {
+	number := strconv.FormatFloat(math.Abs(value), 'f', digits, 64)
+	negative := value < 0 && strings.Trim(number, "0.") != ""
+
+	integer, fraction := number, ""
+	if i := strings.Index(number, "."); i >= 0 {
+		integer, fraction = number[:i], number[i+1:]
+	}
+
+	var groups []string
+	for len(integer) > 3 {
+		groups = append([]string{integer[len(integer)-3:]}, groups...)
+		integer = integer[:len(integer)-3]
+	}
+	groups = append([]string{integer}, groups...)
+
+	number = strings.Join(groups, formatter.symbols.Group)
+	if fraction != "" {
+		number += formatter.symbols.Decimal + fraction
+	}
+	if negative {
+		number = "-" + number
+	}
+
+	return number
+}
+
+// WindSpeed converts a wind speed in miles per hour to the unit of the
+// formatter and formats it with the translated unit name.
+func (formatter *Formatter) WindSpeed(milesPerHour float64) string {
+	speed := formatter.Decimal(milesPerHour*units[formatter.Unit], 1)
+	if formatter.Unit == Beaufort {
+		speed = formatter.Decimal(float64(BeaufortForce(milesPerHour)), 0)
+	}
+
+	return formatter.T("wind_speed_"+string(formatter.Unit), map[string]interface{}{
+		"Speed": speed,
+	})
+}
+
+// WindDirection returns the translated compass point of a direction in degrees from north.
+func (formatter *Formatter) WindDirection(degrees int) string {
+	point := int(math.Floor(float64(degrees%360+360)/22.5+0.5)) % len(compassPoints)
+	return formatter.T(compassPoints[point])
+}
+
+// Date formats the date and time with the layout of the locale.
+func (formatter *Formatter) Date(date time.Time) string {
+	return date.Format(formatter.layout)
+}
+
+//** PRIVATE FUNCTIONS
+
+// localeChain returns the id of the locale followed by the ids of its parents.
+func localeChain(localeID string) []string {
+	l, err := locale.New(localeID)
+	if err != nil {
+		return nil
+	}
+
+	var ids []string
+	for ; l != nil; l = l.Parent() {
+		ids = append(ids, l.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,152 @@
+package format_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goinggo/beego-mgo/localize"
+	"github.com/goinggo/beego-mgo/localize/format"
+)
+
+func init() {
+	localize.Init("en-US")
+}
+
+// TestDecimal tests the separators of the locales.
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		localeID string
+		value    float64
+		digits   int
+		result   string
+	}{
+		{"en-US", 1234567.891, 2, "1,234,567.89"},
+		{"en-US", 12.5, 1, "12.5"},
+		{"en-US", -1234.6, 0, "-1,235"},
+		{"en-US", -0.01, 1, "0.0"},
+		{"de-DE", 1234567.891, 2, "1.234.567,89"},
+		{"de-CH", 1234.5, 1, "1\u2019234.5"},
+		{"fr-CA", 1234.5, 1, "1\u202f234,5"},
+		{"es-419", 1234.5, 1, "1,234.5"},
+		{"es-ES", 1234.5, 1, "1.234,5"},
+		{"pt-BR", 1234.5, 1, "1.234,5"},
+		{"pt-PT", 1234.5, 1, "1\u00a0234,5"},
+		{"invalid", 1234.5, 1, "1,234.5"},
+	}
+
+	for _, test := range tests {
+		formatter := format.New(test.localeID, format.MilesPerHour, localize.T)
+		if result := formatter.Decimal(test.value, test.digits); result != test.result {
+			t.Errorf("Decimal(%v, %d) in %s was %q; expected %q", test.value, test.digits, test.localeID, result, test.result)
+		}
+	}
+}
+
+// TestWindSpeed tests the conversion of wind speeds to the units.
+func TestWindSpeed(t *testing.T) {
+	tests := []struct {
+		localeID string
+		unit     format.Unit
+		result   string
+	}{
+		{"en-US", format.MilesPerHour, "20.0 mph"},
+		{"en-US", format.KilometersPerHour, "32.2 km/h"},
+		{"en-US", format.Knots, "17.4 kn"},
+		{"en-US", format.MetersPerSecond, "8.9 m/s"},
+		{"en-US", format.Beaufort, "Force 5"},
+		{"de-DE", format.KilometersPerHour, "32,2 km/h"},
+		{"de-DE", "", "32,2 km/h"},
+		{"en-GB", "", "20.0 mph"},
+	}
+
+	for _, test := range tests {
+		formatter := format.New(test.localeID, test.unit, localize.T)
+		if result := formatter.WindSpeed(20); result != test.result {
+			t.Errorf("WindSpeed(20) in %s %s was %q; expected %q", test.localeID, test.unit, result, test.result)
+		}
+	}
+}
+
+// TestParseUnit tests the names of the units.
+func TestParseUnit(t *testing.T) {
+	for _, name := range []string{"mph", "kmh", "knots", "ms", "beaufort", "KNOTS"} {
+		if _, err := format.ParseUnit(name); err != nil {
+			t.Errorf("ParseUnit(%q) returned error %s", name, err)
+		}
+	}
+
+	if _, err := format.ParseUnit("furlongs"); err == nil {
+		t.Errorf("ParseUnit(%q) returned nil error", "furlongs")
+	}
+}
+
+// TestBeaufortForce tests the limits of the Beaufort scale.
+func TestBeaufortForce(t *testing.T) {
+	tests := []struct {
+		milesPerHour float64
+		force        int
+	}{
+		{0, 0},
+		{0.5, 0},
+		{1, 1},
+		{7.5, 3},
+		{12.5, 4},
+		{38, 7},
+		{39, 8},
+		{73, 11},
+		{74, 12},
+		{150, 12},
+	}
+
+	for _, test := range tests {
+		if force := format.BeaufortForce(test.milesPerHour); force != test.force {
+			t.Errorf("BeaufortForce(%v) was %d; expected %d", test.milesPerHour, force, test.force)
+		}
+	}
+}
+
+// TestWindDirection tests the compass points of directions.
+func TestWindDirection(t *testing.T) {
+	tests := []struct {
+		degrees int
+		result  string
+	}{
+		{0, "N"},
+		{11, "N"},
+		{12, "NNE"},
+		{90, "E"},
+		{200, "SSW"},
+		{349, "N"},
+		{360, "N"},
+		{-90, "W"},
+	}
+
+	formatter := format.New("en-US", format.MilesPerHour, localize.T)
+	for _, test := range tests {
+		if result := formatter.WindDirection(test.degrees); result != test.result {
+			t.Errorf("WindDirection(%d) was %q; expected %q", test.degrees, result, test.result)
+		}
+	}
+}
+
+// TestDate tests the date layouts of the locales.
+func TestDate(t *testing.T) {
+	date := time.Date(2014, time.March, 7, 15, 4, 0, 0, time.UTC)
+	tests := []struct {
+		localeID string
+		result   string
+	}{
+		{"en-US", "3/7/2014 3:04 PM"},
+		{"en-GB", "07/03/2014 15:04"},
+		{"de-DE", "07.03.2014 15:04"},
+		{"zh-Hant-TW", "2014/03/07 15:04"},
+		{"sw", "2014-03-07 15:04"},
+	}
+
+	for _, test := range tests {
+		formatter := format.New(test.localeID, format.MilesPerHour, localize.T)
+		if result := formatter.Date(date); result != test.result {
+			t.Errorf("Date in %s was %q; expected %q", test.localeID, result, test.result)
+		}
+	}
+}
@@ -7,6 +7,10 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/goinggo/beego-mgo/go-i18n/i18n"
 	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
@@ -14,9 +18,28 @@ import (
 	"github.com/goinggo/tracelog"
 )
 
+const (
+	// PseudoLocale is the locale QA can select with the lang parameter or the
+	// Accept-Language header to see the pseudolocalized default locale translations,
+	// which shows hard-coded strings and text that is truncated when it gets longer
+	PseudoLocale = "en-XA"
+)
+
 var (
 	// T is the translate function for the default locale
 	T i18n.TranslateFunc
+
+	// DefaultLocale is the locale used when a request has no supported locale
+	DefaultLocale string
+
+	// builtIn contains the translations loaded by LoadJSON by locale
+	builtIn = make(map[string][]translation.Translation)
+
+	// stored contains the locales of the translations set by SetTranslations
+	stored = make(map[string]bool)
+
+	// mutex guards builtIn and stored
+	mutex sync.Mutex
 )
 
 // Init initializes the local environment
@@ -30,6 +53,22 @@ func Init(defaultLocale string) error {
 		return fmt.Errorf("Unsupported Locale: %s", defaultLocale)
 	}
 
+	DefaultLocale = defaultLocale
+
+	// Fall back to the default locale for strings missing from a user locale
+	if err := i18n.SetDefaultLocale(defaultLocale); err != nil {
+		return err
+	}
+
+	// Synthesize the pseudo locale from the default locale for QA
+	if err := i18n.SetPseudoLocale(PseudoLocale, defaultLocale); err != nil {
+		return err
+	}
+
+	i18n.SetMissingTranslationHandler(func(localeID string, translationID string) {
+		tracelog.Warning("localize", "Missing", "Locale[%s] TranslationID[%s]", localeID, translationID)
+	})
+
 	// Obtain the default translation function for use
 	var err error
 	if T, err = NewTranslation(defaultLocale, defaultLocale); err != nil {
@@ -43,7 +82,80 @@ func Init(defaultLocale string) error {
 // NewTranslation obtains a translation function object for the
 // specified locales
 func NewTranslation(userLocale string, defaultLocale string) (i18n.TranslateFunc, error) {
-	return i18n.Tfunc(userLocale, userLocale)
+	return i18n.Tfunc(userLocale, defaultLocale)
+}
+
+// NewHTMLTranslation obtains a translation function object for the
+// specified locales that renders translations as HTML for the views
+func NewHTMLTranslation(userLocale string, defaultLocale string) (i18n.HTMLTranslateFunc, error) {
+	return i18n.HTMLTfunc(userLocale, defaultLocale)
+}
+
+// RequestLocale returns the first supported locale of the candidates, which are
+// usually taken from the request parameters, cookies and Accept-Language header
+func RequestLocale(candidates ...string) string {
+	for _, candidate := range candidates {
+		if l, err := locale.New(candidate); err == nil {
+			return l.ID
+		}
+	}
+
+	return DefaultLocale
+}
+
+// Direction returns the text direction of the locale, ltr or rtl, for the dir
+// attribute of the views
+func Direction(localeID string) string {
+	l, err := locale.New(localeID)
+	if err != nil {
+		return string(locale.LeftToRight)
+	}
+
+	return string(l.Direction())
+}
+
+// AcceptLanguage returns the language tags of an Accept-Language header
+// ordered by their quality values
+func AcceptLanguage(header string) []string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		languages = append(languages, language{tag, quality})
+	}
+
+	sort.SliceStable(languages, func(i int, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	tags := make([]string, len(languages))
+	for i, l := range languages {
+		tags[i] = l.tag
+	}
+
+	return tags
 }
 
 // LoadJSON takes a json document of translations and manually
@@ -57,6 +169,9 @@ func LoadJSON(userLocale string, translationDocument string) error {
 		return err
 	}
 
+	mutex.Lock()
+	defer mutex.Unlock()
+
 	for _, tranDocument := range tranDocuments {
 		tran, err := translation.NewTranslation(tranDocument)
 		if err != nil {
@@ -65,6 +180,7 @@ func LoadJSON(userLocale string, translationDocument string) error {
 		}
 
 		i18n.AddTranslation(locale.MustNew(userLocale), tran)
+		builtIn[userLocale] = append(builtIn[userLocale], tran)
 	}
 
 	tracelog.Completed("localize", "LoadJSON")
@@ -153,3 +269,41 @@ func loadTranslationFiles(directory string) {
 		i18n.MustLoadTranslationFile(fileName)
 	}
 }
+
+// SetTranslations replaces the stored translations, which are usually edited at runtime,
+// with translations keyed by locale. Stored translations take precedence over the
+// translations loaded by LoadJSON. Locales missing from translations lose their stored translations.
+func SetTranslations(translations map[string][]translation.Translation) error {
+	tracelog.Startedf("localize", "SetTranslations", "locales[%d]", len(translations))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	localeIDs := make(map[string]bool)
+	for localeID := range stored {
+		localeIDs[localeID] = true
+	}
+	for localeID := range translations {
+		localeIDs[localeID] = true
+	}
+
+	for localeID := range localeIDs {
+		l, err := locale.New(localeID)
+		if err != nil {
+			tracelog.CompletedError(err, "localize", "SetTranslations")
+			return err
+		}
+
+		localeTranslations := append([]translation.Translation{}, builtIn[localeID]...)
+		localeTranslations = append(localeTranslations, translations[localeID]...)
+		i18n.SetTranslations(l, localeTranslations...)
+	}
+
+	stored = make(map[string]bool, len(translations))
+	for localeID := range translations {
+		stored[localeID] = true
+	}
+
+	tracelog.Completed("localize", "SetTranslations")
+	return nil
+}
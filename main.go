@@ -8,7 +8,10 @@ package main
 import (
 	"github.com/astaxie/beego"
 	"github.com/goinggo/beego-mgo/localize"
+	"github.com/goinggo/beego-mgo/migrations"
 	_ "github.com/goinggo/beego-mgo/routes"
+	"github.com/goinggo/beego-mgo/services/buoyService"
+	"github.com/goinggo/beego-mgo/services/translationService"
 	"github.com/goinggo/beego-mgo/utilities/helper"
 	"github.com/goinggo/beego-mgo/utilities/mongo"
 	"github.com/goinggo/tracelog"
@@ -26,9 +29,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Ensure the declared indexes, the migrations are run with the migrate command
+	if err := migrations.EnsureIndexes(helper.MainGoRoutine); err != nil {
+		tracelog.Error(err, helper.MainGoRoutine, "initApp")
+	}
+
+	// Complete the transactions that were pending when the application stopped
+	buoyService.ResumeTxns(helper.MainGoRoutine)
+
+	// Push the condition updates of the stations to the browsers
+	buoyService.StartWatcher(helper.MainGoRoutine)
+
 	// Load message strings
 	localize.Init("en-US")
 
+	// Load the published translations and watch for new ones
+	translationService.StartWatcher(helper.MainGoRoutine)
+
 	beego.Run()
 
 	tracelog.Completed(helper.MainGoRoutine, "Website Shutdown")
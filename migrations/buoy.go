@@ -0,0 +1,153 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+package migrations
+
+import (
+	"strings"
+
+	"github.com/goinggo/beego-mgo/services/buoyService"
+	"github.com/goinggo/beego-mgo/utilities/mongo"
+	log "github.com/goinggo/tracelog"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+//** CONSTANTS
+
+const (
+	// stationIDIndex is the name of the unique index on station_id.
+	stationIDIndex = "station_id_unique"
+)
+
+//** INIT
+
+func init() {
+	RegisterIndexes(IndexSpec{
+		Database:   buoyService.Config.Database,
+		Collection: "buoy_stations",
+		Indexes: []mgo.Index{
+			{Key: []string{"station_id"}, Name: stationIDIndex, Unique: true},
+			{Key: []string{"region"}, Name: "region"},
+			{Key: []string{"$2dsphere:location"}, Name: "location_2dsphere"},
+		},
+	})
+
+	RegisterIndexes(IndexSpec{
+		Database:   buoyService.Config.Database,
+		Collection: "buoy_station_history",
+		Indexes: []mgo.Index{
+			{Key: []string{"station_id", "-recorded_at"}, Name: "station_id_recorded_at"},
+		},
+	})
+
+	Register(Migration{
+		Version:     1,
+		Description: "Move buoy stations with duplicate station ids to buoy_stations_duplicates",
+		Up:          moveDuplicateStations,
+		Down:        restoreDuplicateStations,
+	})
+}
+
+//** PRIVATE FUNCTIONS
+
+// moveDuplicateStations keeps the oldest station of each station id and moves the
+// others aside, so that the unique index on station_id can be built.
+func moveDuplicateStations(sessionID string, mongoSession *mgo.Session) error {
+	stations := mongo.GetCollection(mongoSession, buoyService.Config.Database, "buoy_stations")
+	duplicates := mongo.GetCollection(mongoSession, buoyService.Config.Database, "buoy_stations_duplicates")
+
+	pipeline := []bson.M{
+		{"$group": bson.M{"_id": "$station_id", "ids": bson.M{"$push": "$_id"}, "count": bson.M{"$sum": 1}}},
+		{"$match": bson.M{"count": bson.M{"$gt": 1}}},
+	}
+
+	var groups []struct {
+		StationID string          `bson:"_id"`
+		IDs       []bson.ObjectId `bson:"ids"`
+	}
+
+	log.Trace(sessionID, "moveDuplicateStations", "MGO : db.buoy_stations.aggregate(%s)", mongo.ToString(pipeline))
+	if err := stations.Pipe(pipeline).All(&groups); err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		// Object ids start with their creation time, so the smallest is the oldest station.
+		ids := group.IDs
+		oldest := 0
+		for i := range ids {
+			if ids[i] < ids[oldest] {
+				oldest = i
+			}
+		}
+
+		for i, id := range ids {
+			if i == oldest {
+				continue
+			}
+
+			var station bson.M
+			if err := stations.FindId(id).One(&station); err != nil {
+				return err
+			}
+
+			// Upsert so a station that was copied before a failed remove is moved again when the migration is rerun.
+			log.Trace(sessionID, "moveDuplicateStations", "MGO : db.buoy_stations_duplicates.update({_id: %q}, {station_id: %q, ...}, {upsert: true})", id.Hex(), group.StationID)
+			if _, err := duplicates.UpsertId(id, station); err != nil {
+				return err
+			}
+
+			if err := stations.RemoveId(id); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// restoreDuplicateStations moves the duplicate stations back, which
+// requires dropping the unique index on station_id first.
+func restoreDuplicateStations(sessionID string, mongoSession *mgo.Session) error {
+	stations := mongo.GetCollection(mongoSession, buoyService.Config.Database, "buoy_stations")
+	duplicates := mongo.GetCollection(mongoSession, buoyService.Config.Database, "buoy_stations_duplicates")
+
+	log.Trace(sessionID, "restoreDuplicateStations", "MGO : db.buoy_stations.dropIndex(%q)", stationIDIndex)
+	if err := stations.DropIndexName(stationIDIndex); err != nil && isNotFound(err) == false {
+		return err
+	}
+
+	var station bson.M
+	iter := duplicates.Find(nil).Iter()
+	for iter.Next(&station) {
+		// Upsert so the stations restored before a failed drop are restored again when the migration is rerun.
+		log.Trace(sessionID, "restoreDuplicateStations", "MGO : db.buoy_stations.update({_id: %v}, {...}, {upsert: true})", station["_id"])
+		if _, err := stations.UpsertId(station["_id"], station); err != nil {
+			iter.Close()
+			return err
+		}
+
+		station = nil
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+
+	log.Trace(sessionID, "restoreDuplicateStations", "MGO : db.buoy_stations_duplicates.drop()")
+	if err := duplicates.DropCollection(); err != nil && isNotFound(err) == false {
+		return err
+	}
+
+	return nil
+}
+
+// isNotFound reports whether dropping an index or collection failed because it does not exist.
+func isNotFound(err error) bool {
+	if queryError, ok := err.(*mgo.QueryError); ok && (queryError.Code == 26 || queryError.Code == 27) {
+		return true
+	}
+
+	return strings.HasPrefix(err.Error(), "index not found") || err.Error() == "ns not found"
+}
@@ -0,0 +1,152 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+// Package main provides the command that runs the schema migrations.
+//
+// The connection is configured with the same MGO_ environment variables as the
+// web application and the migrations are recorded in MIGRATION_DATABASE.
+//
+//	migrate up [-to version]      applies the pending migrations and ensures the indexes
+//	migrate down [-to version]    reverts the latest migration or those newer than version
+//	migrate status                lists the migrations and when they were applied
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/goinggo/beego-mgo/migrations"
+	"github.com/goinggo/beego-mgo/utilities/helper"
+	"github.com/goinggo/beego-mgo/utilities/mongo"
+	"github.com/goinggo/tracelog"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `migrate runs the schema migrations of the buoy database.
+
+Usage:
+
+    migrate up [-to version]
+        Applies the pending migrations up to version, or all of them, and ensures the indexes.
+
+    migrate down [-to version]
+        Reverts the migrations newer than version, or the latest migration.
+
+    migrate status
+        Lists the migrations and when they were applied.
+
+`)
+	os.Exit(2)
+}
+
+func main() {
+	flag.Usage = usage
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	flags.Usage = usage
+	target := flags.Int("to", -1, "the target version")
+	verbose := flags.Bool("v", false, "log the mongo calls")
+	flags.Parse(os.Args[2:])
+	if flags.NArg() > 0 || *target < -1 {
+		usage()
+	}
+
+	level := tracelog.LevelError
+	if *verbose {
+		level = tracelog.LevelTrace
+	}
+	tracelog.Start(level)
+	defer tracelog.Stop()
+
+	if err := mongo.Startup(helper.MainGoRoutine); err != nil {
+		exit(err)
+	}
+	defer mongo.Shutdown(helper.MainGoRoutine)
+
+	var err error
+	switch command {
+	case "up":
+		if *target == -1 {
+			*target = 0
+		}
+		err = migrations.Up(helper.MainGoRoutine, *target)
+
+	case "down":
+		if *target == -1 {
+			*target = previousVersion()
+		}
+		err = migrations.Down(helper.MainGoRoutine, *target)
+
+	case "status":
+		if *target != -1 {
+			usage()
+		}
+		err = printStatus()
+
+	default:
+		usage()
+	}
+
+	if err != nil {
+		mongo.Shutdown(helper.MainGoRoutine)
+		exit(err)
+	}
+}
+
+// previousVersion returns the version before the latest applied migration,
+// so that down reverts a single migration by default.
+func previousVersion() int {
+	statuses, err := migrations.Status(helper.MainGoRoutine)
+	if err != nil {
+		exit(err)
+	}
+
+	previous := 0
+	latest := 0
+	for _, status := range statuses {
+		if status.AppliedAt != nil {
+			previous = latest
+			latest = status.Version
+		}
+	}
+
+	if latest == 0 {
+		exit(fmt.Errorf("No Migrations Have Been Applied"))
+	}
+
+	return previous
+}
+
+// printStatus prints the migrations and when they were applied.
+func printStatus() error {
+	statuses, err := migrations.Status(helper.MainGoRoutine)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tAPPLIED\tDESCRIPTION")
+	for _, status := range statuses {
+		applied := "pending"
+		if status.AppliedAt != nil {
+			applied = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, applied, status.Description)
+	}
+
+	return w.Flush()
+}
+
+// exit prints the error and exits with a failure status.
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err.Error())
+	tracelog.Stop()
+	os.Exit(1)
+}
@@ -0,0 +1,366 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+// Package migrations implements versioned schema migrations and declarative indexes.
+//
+// Migrations are registered in the init functions of this package and run in
+// the order of their versions. The versions that have been applied are recorded
+// in the schema_migrations collection. The indexes are declared per collection
+// and ensured after the migrations have run.
+package migrations
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/goinggo/beego-mgo/utilities/helper"
+	"github.com/goinggo/beego-mgo/utilities/mongo"
+	log "github.com/goinggo/tracelog"
+	"github.com/kelseyhightower/envconfig"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+//** TYPES
+
+type (
+	// migrationConfiguration contains settings for running the migrations.
+	migrationConfiguration struct {
+		Database string
+	}
+
+	// MigrationFunc changes the schema or data using the session.
+	MigrationFunc func(sessionID string, mongoSession *mgo.Session) error
+
+	// Migration is a versioned change to the schema or data.
+	// Down reverts Up and is nil for migrations that can not be reverted.
+	Migration struct {
+		Version     int
+		Description string
+		Up          MigrationFunc
+		Down        MigrationFunc
+	}
+
+	// IndexSpec declares the indexes of a collection.
+	IndexSpec struct {
+		Database   string
+		Collection string
+		Indexes    []mgo.Index
+	}
+
+	// MigrationStatus reports whether a migration has been applied.
+	MigrationStatus struct {
+		Version     int        `json:"version"`
+		Description string     `json:"description"`
+		AppliedAt   *time.Time `json:"applied_at,omitempty"`
+	}
+
+	// schemaMigration is the document recording an applied migration.
+	schemaMigration struct {
+		Version     int       `bson:"_id"`
+		Description string    `bson:"description"`
+		AppliedAt   time.Time `bson:"applied_at"`
+	}
+)
+
+//** CONSTANTS
+
+const (
+	// schemaMigrationsCollection contains a document per applied migration.
+	schemaMigrationsCollection = "schema_migrations"
+)
+
+//** PACKAGE VARIABLES
+
+// Config provides migration configuration.
+var Config migrationConfiguration
+
+var (
+	// registered contains the migrations ordered by version.
+	registered []Migration
+
+	// indexSpecs contains the declared indexes.
+	indexSpecs []IndexSpec
+)
+
+//** INIT
+
+func init() {
+	// Pull in the configuration.
+	if err := envconfig.Process("migration", &Config); err != nil {
+		log.CompletedError(err, helper.MainGoRoutine, "Init")
+	}
+}
+
+//** PUBLIC FUNCTIONS
+
+// Register adds a migration. It panics if the version is not positive or already registered,
+// since that is a programming error.
+func Register(migration Migration) {
+	if migration.Version <= 0 || migration.Up == nil {
+		panic(fmt.Sprintf("migrations: invalid migration %d", migration.Version))
+	}
+
+	for _, m := range registered {
+		if m.Version == migration.Version {
+			panic(fmt.Sprintf("migrations: version %d registered twice", migration.Version))
+		}
+	}
+
+	registered = append(registered, migration)
+	sort.Sort(byVersion(registered))
+}
+
+// RegisterIndexes declares the indexes of a collection.
+func RegisterIndexes(spec IndexSpec) {
+	indexSpecs = append(indexSpecs, spec)
+}
+
+// Migrations returns the registered migrations ordered by version.
+func Migrations() []Migration {
+	return append([]Migration{}, registered...)
+}
+
+// Up applies the pending migrations up to and including the target version,
+// or all pending migrations if target is 0, and then ensures the indexes.
+func Up(sessionID string, target int) error {
+	log.Startedf(sessionID, "Up", "Target[%d]", target)
+
+	mongoSession, err := mongo.CopyMasterSession(sessionID)
+	if err != nil {
+		log.CompletedError(err, sessionID, "Up")
+		return err
+	}
+	defer mongo.CloseSession(sessionID, mongoSession)
+
+	applied, err := appliedMigrations(sessionID, mongoSession)
+	if err != nil {
+		log.CompletedError(err, sessionID, "Up")
+		return err
+	}
+
+	for _, migration := range pending(registered, applied, target) {
+		log.Trace(sessionID, "Up", "Migration[%d] %s", migration.Version, migration.Description)
+
+		if err := migration.Up(sessionID, mongoSession); err != nil {
+			err = fmt.Errorf("Migration %d Failed : %v", migration.Version, err)
+			log.CompletedError(err, sessionID, "Up")
+			return err
+		}
+
+		record := schemaMigration{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+		}
+
+		log.Trace(sessionID, "Up", "MGO : db.schema_migrations.insert(%s)", mongo.ToString(record))
+		if err := migrationsCollection(mongoSession).Insert(&record); err != nil {
+			log.CompletedError(err, sessionID, "Up")
+			return err
+		}
+	}
+
+	if err := ensureIndexes(sessionID, mongoSession); err != nil {
+		log.CompletedError(err, sessionID, "Up")
+		return err
+	}
+
+	log.Completed(sessionID, "Up")
+	return nil
+}
+
+// Down reverts the applied migrations newer than the target version, newest first.
+func Down(sessionID string, target int) error {
+	log.Startedf(sessionID, "Down", "Target[%d]", target)
+
+	mongoSession, err := mongo.CopyMasterSession(sessionID)
+	if err != nil {
+		log.CompletedError(err, sessionID, "Down")
+		return err
+	}
+	defer mongo.CloseSession(sessionID, mongoSession)
+
+	applied, err := appliedMigrations(sessionID, mongoSession)
+	if err != nil {
+		log.CompletedError(err, sessionID, "Down")
+		return err
+	}
+
+	migrations, err := revertible(registered, applied, target)
+	if err != nil {
+		log.CompletedError(err, sessionID, "Down")
+		return err
+	}
+
+	for _, migration := range migrations {
+		log.Trace(sessionID, "Down", "Migration[%d] %s", migration.Version, migration.Description)
+
+		if err := migration.Down(sessionID, mongoSession); err != nil {
+			err = fmt.Errorf("Reverting Migration %d Failed : %v", migration.Version, err)
+			log.CompletedError(err, sessionID, "Down")
+			return err
+		}
+
+		log.Trace(sessionID, "Down", "MGO : db.schema_migrations.remove({_id: %d})", migration.Version)
+		if err := migrationsCollection(mongoSession).RemoveId(migration.Version); err != nil {
+			log.CompletedError(err, sessionID, "Down")
+			return err
+		}
+	}
+
+	log.Completed(sessionID, "Down")
+	return nil
+}
+
+// Status returns the registered migrations and when they were applied.
+func Status(sessionID string) ([]MigrationStatus, error) {
+	log.Started(sessionID, "Status")
+
+	mongoSession, err := mongo.CopyMasterSession(sessionID)
+	if err != nil {
+		log.CompletedError(err, sessionID, "Status")
+		return nil, err
+	}
+	defer mongo.CloseSession(sessionID, mongoSession)
+
+	applied, err := appliedMigrations(sessionID, mongoSession)
+	if err != nil {
+		log.CompletedError(err, sessionID, "Status")
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(registered))
+	for i, migration := range registered {
+		statuses[i] = MigrationStatus{
+			Version:     migration.Version,
+			Description: migration.Description,
+		}
+
+		if record, ok := applied[migration.Version]; ok {
+			statuses[i].AppliedAt = &record.AppliedAt
+		}
+	}
+
+	log.Completedf(sessionID, "Status", "Migrations[%d] Applied[%d]", len(registered), len(applied))
+	return statuses, nil
+}
+
+// EnsureIndexes creates the declared indexes that do not exist yet.
+func EnsureIndexes(sessionID string) error {
+	log.Started(sessionID, "EnsureIndexes")
+
+	mongoSession, err := mongo.CopyMasterSession(sessionID)
+	if err != nil {
+		log.CompletedError(err, sessionID, "EnsureIndexes")
+		return err
+	}
+	defer mongo.CloseSession(sessionID, mongoSession)
+
+	if err := ensureIndexes(sessionID, mongoSession); err != nil {
+		log.CompletedError(err, sessionID, "EnsureIndexes")
+		return err
+	}
+
+	log.Completed(sessionID, "EnsureIndexes")
+	return nil
+}
+
+//** PRIVATE FUNCTIONS
+
+// byVersion orders migrations by version.
+type byVersion []Migration
+
+func (a byVersion) Len() int           { return len(a) }
+func (a byVersion) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a byVersion) Less(i, j int) bool { return a[i].Version < a[j].Version }
+
+// pending returns the migrations that are not applied, up to and including the target
+// version or all of them if target is 0, in the order they must be applied.
+func pending(migrations []Migration, applied map[int]schemaMigration, target int) []Migration {
+	var result []Migration
+	for _, migration := range migrations {
+		if target > 0 && migration.Version > target {
+			break
+		}
+
+		if _, ok := applied[migration.Version]; ok == false {
+			result = append(result, migration)
+		}
+	}
+
+	return result
+}
+
+// revertible returns the applied migrations newer than the target version in the order
+// they must be reverted. It fails if one of them can not be reverted or is not registered.
+func revertible(migrations []Migration, applied map[int]schemaMigration, target int) ([]Migration, error) {
+	byVersion := make(map[int]Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	var versions []int
+	for version := range applied {
+		if version > target {
+			versions = append(versions, version)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	result := make([]Migration, len(versions))
+	for i, version := range versions {
+		migration, ok := byVersion[version]
+		if ok == false {
+			return nil, fmt.Errorf("Applied Migration %d Is Not Registered", version)
+		}
+
+		if migration.Down == nil {
+			return nil, fmt.Errorf("Migration %d Can Not Be Reverted", version)
+		}
+
+		result[i] = migration
+	}
+
+	return result, nil
+}
+
+// migrationsCollection returns the collection recording the applied migrations.
+func migrationsCollection(mongoSession *mgo.Session) *mgo.Collection {
+	return mongo.GetCollection(mongoSession, Config.Database, schemaMigrationsCollection)
+}
+
+// appliedMigrations retrieves the applied migrations by version.
+func appliedMigrations(sessionID string, mongoSession *mgo.Session) (map[int]schemaMigration, error) {
+	var records []schemaMigration
+
+	log.Trace(sessionID, "appliedMigrations", "MGO : db.schema_migrations.find().sort({_id: 1})")
+	if err := migrationsCollection(mongoSession).Find(nil).Sort("_id").All(&records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]schemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	return applied, nil
+}
+
+// ensureIndexes creates the declared indexes that do not exist yet.
+func ensureIndexes(sessionID string, mongoSession *mgo.Session) error {
+	for _, spec := range indexSpecs {
+		collection := mongo.GetCollection(mongoSession, spec.Database, spec.Collection)
+
+		for _, index := range spec.Indexes {
+			log.Trace(sessionID, "ensureIndexes", "MGO : db.%s.ensureIndex(%s, %s)", spec.Collection, mongo.ToString(index.Key), mongo.ToString(bson.M{"name": index.Name, "unique": index.Unique}))
+			if err := collection.EnsureIndex(index); err != nil {
+				return fmt.Errorf("Unable To Ensure Index %v On %s : %v", index.Key, spec.Collection, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+package migrations
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/mgo.v2"
+)
+
+// testMigrations returns migrations with the versions, where the negative ones can not be reverted.
+func testMigrations(versions ...int) []Migration {
+	noop := func(sessionID string, mongoSession *mgo.Session) error { return nil }
+
+	migrations := make([]Migration, len(versions))
+	for i, version := range versions {
+		migrations[i] = Migration{Version: version, Up: noop, Down: noop}
+		if version < 0 {
+			migrations[i].Version = -version
+			migrations[i].Down = nil
+		}
+	}
+
+	return migrations
+}
+
+// versions returns the versions of the migrations.
+func versions(migrations []Migration) []int {
+	result := []int{}
+	for _, migration := range migrations {
+		result = append(result, migration.Version)
+	}
+
+	return result
+}
+
+// applied returns the records of the applied versions.
+func applied(versions ...int) map[int]schemaMigration {
+	result := make(map[int]schemaMigration)
+	for _, version := range versions {
+		result[version] = schemaMigration{Version: version}
+	}
+
+	return result
+}
+
+// TestPending checks the pending migrations are applied in order up to the target.
+func TestPending(t *testing.T) {
+	migrations := testMigrations(1, 2, 3, 5)
+
+	tests := []struct {
+		applied  map[int]schemaMigration
+		target   int
+		expected []int
+	}{
+		{applied(), 0, []int{1, 2, 3, 5}},
+		{applied(1, 3), 0, []int{2, 5}},
+		{applied(1), 3, []int{2, 3}},
+		{applied(1), 4, []int{2, 3}},
+		{applied(1, 2, 3, 5), 0, []int{}},
+	}
+
+	for _, test := range tests {
+		if result := versions(pending(migrations, test.applied, test.target)); reflect.DeepEqual(result, test.expected) == false {
+			t.Errorf("pending(%v, %d) returned %v; expected %v", test.applied, test.target, result, test.expected)
+		}
+	}
+}
+
+// TestRevertible checks the applied migrations are reverted newest first down to the target.
+func TestRevertible(t *testing.T) {
+	migrations := testMigrations(-1, 2, 3, 5)
+
+	tests := []struct {
+		applied  map[int]schemaMigration
+		target   int
+		expected []int
+		err      bool
+	}{
+		{applied(1, 2, 3, 5), 3, []int{5}, false},
+		{applied(1, 2, 3, 5), 1, []int{5, 3, 2}, false},
+		{applied(1, 3), 1, []int{3}, false},
+		{applied(1, 2), 5, []int{}, false},
+		{applied(1, 2), 0, nil, true},
+		{applied(2, 4), 1, nil, true},
+	}
+
+	for _, test := range tests {
+		result, err := revertible(migrations, test.applied, test.target)
+		if (err != nil) != test.err {
+			t.Errorf("revertible(%v, %d) returned error %v", test.applied, test.target, err)
+			continue
+		}
+		if err == nil && reflect.DeepEqual(versions(result), test.expected) == false {
+			t.Errorf("revertible(%v, %d) returned %v; expected %v", test.applied, test.target, versions(result), test.expected)
+		}
+	}
+}
+
+// TestRegistered checks the registered migrations are ordered and have unique versions.
+func TestRegistered(t *testing.T) {
+	migrations := Migrations()
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i-1].Version >= migrations[i].Version {
+			t.Errorf("migration %d is registered after %d", migrations[i].Version, migrations[i-1].Version)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register of a registered version did not panic")
+		}
+	}()
+	Register(migrations[0])
+}
@@ -0,0 +1,23 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+package migrations
+
+import (
+	"github.com/goinggo/beego-mgo/services/translationService"
+	"gopkg.in/mgo.v2"
+)
+
+//** INIT
+
+func init() {
+	// Saving a new translation relies on the unique index to detect concurrent edits.
+	RegisterIndexes(IndexSpec{
+		Database:   translationService.Config.Database,
+		Collection: "translations",
+		Indexes: []mgo.Index{
+			{Key: []string{"locale", "translation_id"}, Unique: true},
+		},
+	})
+}
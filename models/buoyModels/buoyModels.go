@@ -6,7 +6,7 @@
 package buoyModels
 
 import (
-	"fmt"
+	"time"
 
 	"gopkg.in/mgo.v2/bson"
 )
@@ -27,23 +27,46 @@ type (
 		Coordinates []float64 `bson:"coordinates" json:"coordinates"`
 	}
 
+	// BuoyDisplay contains the conditions of a station formatted for a locale.
+	BuoyDisplay struct {
+		WindSpeed     string `json:"wind_speed"`
+		WindDirection string `json:"wind_direction"`
+		WindGust      string `json:"gust_wind_speed"`
+	}
+
 	// BuoyStation contains information for an individual station.
 	BuoyStation struct {
 		ID        bson.ObjectId `bson:"_id,omitempty"`
 		StationID string        `bson:"station_id" json:"station_id"`
 		Name      string        `bson:"name" json:"name"`
 		LocDesc   string        `bson:"location_desc" json:"location_desc"`
+		Region    string        `bson:"region" json:"region"`
 		Condition BuoyCondition `bson:"condition" json:"condition"`
 		Location  BuoyLocation  `bson:"location" json:"location"`
+		UpdatedAt *time.Time    `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+	}
+
+	// BuoyStationBatch contains the stations found for a list of station ids
+	// and the ids of the stations that do not exist.
+	BuoyStationBatch struct {
+		Stations []BuoyStation `json:"stations"`
+		Unknown  []string      `json:"unknown"`
 	}
-)
 
-// DisplayWindSpeed pretty prints wind speed.
-func (buoyCondition *BuoyCondition) DisplayWindSpeed() string {
-	return fmt.Sprintf("%.2f", buoyCondition.WindSpeed)
-}
+	// BuoyConditionHistory contains a condition that was recorded for a station.
+	BuoyConditionHistory struct {
+		ID         bson.ObjectId `bson:"_id,omitempty" json:"-"`
+		StationID  string        `bson:"station_id" json:"station_id"`
+		Condition  BuoyCondition `bson:"condition" json:"condition"`
+		RecordedAt time.Time     `bson:"recorded_at" json:"recorded_at"`
+	}
 
-// DisplayWindGust pretty prints wind gust.
-func (buoyCondition *BuoyCondition) DisplayWindGust() string {
-	return fmt.Sprintf("%.2f", buoyCondition.WindGust)
-}
+	// BuoyEvent contains a condition update that is pushed to the browsers.
+	BuoyEvent struct {
+		ID         bson.ObjectId `bson:"_id,omitempty" json:"-"`
+		StationID  string        `bson:"station_id" json:"station_id"`
+		Region     string        `bson:"region" json:"region"`
+		Condition  BuoyCondition `bson:"condition" json:"condition"`
+		RecordedAt time.Time     `bson:"recorded_at" json:"recorded_at"`
+	}
+)
@@ -0,0 +1,89 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+// Package translationModels contains the models for the translation service.
+package translationModels
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+//** TYPES
+
+type (
+	// TranslationContent contains a translation in the form of a translation file entry.
+	// A non-plural translation has Text, a plural translation has Plural forms keyed by plural category.
+	TranslationContent struct {
+		Text        string            `bson:"text,omitempty" json:"text,omitempty"`
+		Plural      map[string]string `bson:"plural,omitempty" json:"plural,omitempty"`
+		Ordinal     bool              `bson:"ordinal,omitempty" json:"ordinal,omitempty"`
+		Markup      bool              `bson:"markup,omitempty" json:"markup,omitempty"`
+		Description string            `bson:"description,omitempty" json:"description,omitempty"`
+		Context     string            `bson:"context,omitempty" json:"context,omitempty"`
+		MaxLength   int               `bson:"max_length,omitempty" json:"max_length,omitempty"`
+		Tags        []string          `bson:"tags,omitempty" json:"tags,omitempty"`
+	}
+
+	// Translation is the document of a translation for a locale. Edits change the
+	// Draft and increment the Version. Publishing copies the Draft to Published.
+	Translation struct {
+		ID               bson.ObjectId       `bson:"_id,omitempty" json:"-"`
+		Locale           string              `bson:"locale" json:"locale"`
+		TranslationID    string              `bson:"translation_id" json:"translation_id"`
+		Version          int                 `bson:"version" json:"version"`
+		Draft            TranslationContent  `bson:"draft" json:"draft"`
+		PublishedVersion int                 `bson:"published_version" json:"published_version"`
+		Published        *TranslationContent `bson:"published,omitempty" json:"published,omitempty"`
+		UpdatedBy        string              `bson:"updated_by" json:"updated_by"`
+		UpdatedAt        time.Time           `bson:"updated_at" json:"updated_at"`
+		PublishedAt      *time.Time          `bson:"published_at,omitempty" json:"published_at,omitempty"`
+	}
+
+	// TranslationRevision is incremented every time a translation is published,
+	// so running instances know when to reload the published translations.
+	TranslationRevision struct {
+		ID       string `bson:"_id" json:"-"`
+		Revision int    `bson:"revision" json:"revision"`
+	}
+)
+
+//** PUBLIC METHODS
+
+// Document returns the content in the form of a translation file entry
+// that can be passed to translation.NewTranslation.
+func (content *TranslationContent) Document(translationID string) map[string]interface{} {
+	document := map[string]interface{}{
+		"id":          translationID,
+		"translation": content.Text,
+	}
+
+	if content.Plural != nil {
+		plural := make(map[string]interface{}, len(content.Plural))
+		for category, text := range content.Plural {
+			plural[category] = text
+		}
+		document["translation"] = plural
+		document["ordinal"] = content.Ordinal
+	}
+
+	if content.Markup {
+		document["markup"] = true
+	}
+	if content.Description != "" {
+		document["description"] = content.Description
+	}
+	if content.Context != "" {
+		document["context"] = content.Context
+	}
+	if content.MaxLength != 0 {
+		document["maxLength"] = content.MaxLength
+	}
+	if len(content.Tags) > 0 {
+		document["tags"] = content.Tags
+	}
+
+	return document
+}
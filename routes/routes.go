@@ -14,4 +14,12 @@ func init() {
 	beego.Router("/", new(controllers.BuoyController), "get:Index")
 	beego.Router("/buoy/retrievestation", new(controllers.BuoyController), "post:RetrieveStation")
 	beego.Router("/buoy/station/:stationId", new(controllers.BuoyController), "get,post:RetrieveStationJSON")
+	beego.Router("/buoy/stations/batch", new(controllers.BuoyController), "post:RetrieveStations")
+	beego.Router("/buoy/region/:region/export", new(controllers.BuoyController), "get:ExportRegion")
+	beego.Router("/buoy/events", new(controllers.BuoyController), "get:Events")
+	beego.Router("/buoy/socket", new(controllers.BuoyController), "get:Socket")
+
+	beego.Router("/admin/translations", new(controllers.TranslationController), "get:List")
+	beego.Router("/admin/translations/:locale/:translationId", new(controllers.TranslationController), "post:Save")
+	beego.Router("/admin/translations/:locale/:translationId/publish", new(controllers.TranslationController), "post:Publish")
 }
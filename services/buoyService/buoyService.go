@@ -6,14 +6,18 @@
 package buoyService
 
 import (
+	"time"
+
 	"github.com/goinggo/beego-mgo/models/buoyModels"
 	"github.com/goinggo/beego-mgo/services"
+	"github.com/goinggo/beego-mgo/utilities/cache"
 	"github.com/goinggo/beego-mgo/utilities/helper"
 	"github.com/goinggo/beego-mgo/utilities/mongo"
 	log "github.com/goinggo/tracelog"
 	"github.com/kelseyhightower/envconfig"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
 )
 
 //** TYPES
@@ -21,15 +25,44 @@ import (
 type (
 	// buoyConfiguration contains settings for running the buoy service.
 	buoyConfiguration struct {
-		Database string
+		Database         string
+		Watch            string
+		CacheSize        int
+		CacheTTL         time.Duration
+		CacheNotFoundTTL time.Duration
+		AllowedOrigins   []string
 	}
 )
 
+//** CONSTANTS
+
+const (
+	// defaultCacheSize is used when no cache size is configured.
+	defaultCacheSize = 1000
+
+	// defaultCacheTTL is used when no cache ttl is configured.
+	defaultCacheTTL = time.Minute
+
+	// defaultCacheNotFoundTTL is used when no cache ttl of unknown stations is configured.
+	defaultCacheNotFoundTTL = 5 * time.Second
+)
+
 //** PACKAGE VARIABLES
 
 // Config provides buoy configuration.
 var Config buoyConfiguration
 
+var (
+	// stations queries the buoy_stations collection.
+	stations services.Repository
+
+	// events records the condition updates for the watcher.
+	events services.Repository
+
+	// stationCache contains the stations by station id.
+	stationCache *cache.Cache
+)
+
 //** INIT
 
 func init() {
@@ -37,31 +70,103 @@ func init() {
 	if err := envconfig.Process("buoy", &Config); err != nil {
 		log.CompletedError(err, helper.MainGoRoutine, "Init")
 	}
+
+	if Config.Watch == "" {
+		Config.Watch = WatchEvents
+	}
+
+	if Config.CacheSize <= 0 {
+		Config.CacheSize = defaultCacheSize
+	}
+	if Config.CacheTTL <= 0 {
+		Config.CacheTTL = defaultCacheTTL
+	}
+	if Config.CacheNotFoundTTL <= 0 {
+		Config.CacheNotFoundTTL = defaultCacheNotFoundTTL
+	}
+
+	stationCache = cache.New("buoy_station_cache", Config.CacheSize, Config.CacheTTL, Config.CacheNotFoundTTL)
+	stations = services.Repository{Database: Config.Database, Collection: "buoy_stations"}
+	events = services.Repository{Database: Config.Database, Collection: eventsCollection}
 }
 
 //** PUBLIC FUNCTIONS
 
-// FindStation retrieves the specified station
+// FindStation retrieves the specified station. Stations are cached for BUOY_CACHETTL
+// and concurrent lookups of the same station share a single query.
 func FindStation(service *services.Service, stationID string) (*buoyModels.BuoyStation, error) {
 	log.Startedf(service.UserID, "FindStation", "stationID[%s]", stationID)
 
-	var buoyStation buoyModels.BuoyStation
-	f := func(collection *mgo.Collection) error {
-		queryMap := bson.M{"station_id": stationID}
+	value, err := stationCache.Get(stationID, func() (interface{}, error) {
+		var buoyStation buoyModels.BuoyStation
+		found, err := stations.FindOne(service, bson.M{"station_id": stationID}, &buoyStation)
+		if err != nil {
+			return nil, err
+		}
+		if found == false {
+			return nil, cache.ErrNotFound
+		}
+
+		return buoyStation, nil
+	})
 
-		log.Trace(service.UserID, "FindStation", "MGO : db.buoy_stations.find(%s).limit(1)", mongo.ToString(queryMap))
-		return collection.Find(queryMap).One(&buoyStation)
+	// A station that does not exist is returned empty.
+	if err == cache.ErrNotFound {
+		log.Completedf(service.UserID, "FindStation", "Not Found")
+		return &buoyModels.BuoyStation{}, nil
 	}
 
-	if err := service.DBAction(Config.Database, "buoy_stations", f); err != nil {
-		if err != mgo.ErrNotFound {
-			log.CompletedError(err, service.UserID, "FindStation")
-			return nil, err
+	if err != nil {
+		log.CompletedError(err, service.UserID, "FindStation")
+		return nil, err
+	}
+
+	// Every caller gets its own copy of the cached station.
+	cached := value.(buoyModels.BuoyStation)
+	buoyStation := copyStation(&cached)
+
+	log.Completedf(service.UserID, "FindStation", "buoyStation%+v", buoyStation)
+	return buoyStation, nil
+}
+
+// FindStations retrieves the specified stations with a single query. The stations are
+// returned in the order of the ids, followed by the ids of the stations that do not exist.
+func FindStations(service *services.Service, stationIDs []string) (*buoyModels.BuoyStationBatch, error) {
+	log.Startedf(service.UserID, "FindStations", "stationIDs%v", stationIDs)
+
+	var buoyStations []buoyModels.BuoyStation
+	if err := stations.FindMany(service, bson.M{"station_id": bson.M{"$in": stationIDs}}, &buoyStations); err != nil {
+		log.CompletedError(err, service.UserID, "FindStations")
+		return nil, err
+	}
+
+	byID := make(map[string]buoyModels.BuoyStation, len(buoyStations))
+	for _, buoyStation := range buoyStations {
+		byID[buoyStation.StationID] = buoyStation
+	}
+
+	batch := buoyModels.BuoyStationBatch{
+		Stations: []buoyModels.BuoyStation{},
+		Unknown:  []string{},
+	}
+
+	seen := make(map[string]bool, len(stationIDs))
+	for _, stationID := range stationIDs {
+		if seen[stationID] {
+			continue
 		}
+		seen[stationID] = true
+
+		buoyStation, ok := byID[stationID]
+		if ok == false {
+			batch.Unknown = append(batch.Unknown, stationID)
+			continue
+		}
+		batch.Stations = append(batch.Stations, buoyStation)
 	}
 
-	log.Completedf(service.UserID, "FindStation", "buoyStation%+v", &buoyStation)
-	return &buoyStation, nil
+	log.Completedf(service.UserID, "FindStations", "Found[%d] Unknown%v", len(batch.Stations), batch.Unknown)
+	return &batch, nil
 }
 
 // FindRegion retrieves the stations for the specified region
@@ -69,14 +174,7 @@ func FindRegion(service *services.Service, region string) ([]buoyModels.BuoyStat
 	log.Startedf(service.UserID, "FindRegion", "region[%s]", region)
 
 	var buoyStations []buoyModels.BuoyStation
-	f := func(collection *mgo.Collection) error {
-		queryMap := bson.M{"region": region}
-
-		log.Trace(service.UserID, "FindRegion", "Query : db.buoy_stations.find(%s)", mongo.ToString(queryMap))
-		return collection.Find(queryMap).All(&buoyStations)
-	}
-
-	if err := service.DBAction(Config.Database, "buoy_stations", f); err != nil {
+	if err := stations.FindMany(service, bson.M{"region": region}, &buoyStations); err != nil {
 		log.CompletedError(err, service.UserID, "FindRegion")
 		return nil, err
 	}
@@ -84,3 +182,117 @@ func FindRegion(service *services.Service, region string) ([]buoyModels.BuoyStat
 	log.Completedf(service.UserID, "FindRegion", "buoyStations%+v", buoyStations)
 	return buoyStations, nil
 }
+
+// UpdateCondition records the current condition of the specified station and appends it
+// to the station's history in one transaction, so the two never disagree. The update is
+// then pushed to the subscriptions of the station and its region.
+func UpdateCondition(service *services.Service, stationID string, condition buoyModels.BuoyCondition) error {
+	log.Startedf(service.UserID, "UpdateCondition", "stationID[%s] condition%+v", stationID, condition)
+
+	recordedAt := time.Now().UTC()
+	var buoyStation buoyModels.BuoyStation
+	f := func(database *mgo.Database) ([]txn.Op, error) {
+		queryMap := bson.M{"station_id": stationID}
+
+		log.Trace(service.UserID, "UpdateCondition", "MGO : db.buoy_stations.find(%s, {_id: 1, region: 1}).limit(1)", mongo.ToString(queryMap))
+		if err := database.C("buoy_stations").Find(queryMap).Select(bson.M{"_id": 1, "region": 1}).One(&buoyStation); err != nil {
+			return nil, err
+		}
+
+		history := buoyModels.BuoyConditionHistory{
+			ID:         bson.NewObjectId(),
+			StationID:  stationID,
+			Condition:  condition,
+			RecordedAt: recordedAt,
+		}
+
+		ops := []txn.Op{
+			{
+				C:      "buoy_stations",
+				Id:     buoyStation.ID,
+				Assert: txn.DocExists,
+				Update: bson.M{"$set": bson.M{"condition": condition, "updated_at": recordedAt}},
+			},
+			{
+				C:      "buoy_station_history",
+				Id:     history.ID,
+				Assert: txn.DocMissing,
+				Insert: history,
+			},
+		}
+		return ops, nil
+	}
+
+	if err := service.TxnAction(Config.Database, f); err != nil {
+		log.CompletedError(err, service.UserID, "UpdateCondition")
+		return err
+	}
+	stationCache.Delete(stationID)
+
+	// The oplog watcher finds the change itself.
+	if Config.Watch == WatchEvents {
+		event := buoyModels.BuoyEvent{
+			ID:         bson.NewObjectId(),
+			StationID:  stationID,
+			Region:     buoyStation.Region,
+			Condition:  condition,
+			RecordedAt: recordedAt,
+		}
+
+		if err := events.Insert(service, []interface{}{&event}); err != nil {
+			log.CompletedError(err, service.UserID, "UpdateCondition")
+			return err
+		}
+	}
+
+	log.Completed(service.UserID, "UpdateCondition")
+	return nil
+}
+
+// SetCacheBackend shares the cached stations with the other application processes.
+func SetCacheBackend(backend cache.Backend) {
+	stationCache.SetBackend(backend)
+}
+
+// CacheStats returns the hits and misses of the station cache.
+func CacheStats() cache.Stats {
+	return stationCache.Stats()
+}
+
+// ResumeTxns completes the station transactions that were pending when the application stopped.
+func ResumeTxns(sessionID string) {
+	log.Started(sessionID, "ResumeTxns")
+
+	service := services.Service{UserID: sessionID}
+	if err := service.Prepare(); err != nil {
+		log.CompletedError(err, sessionID, "ResumeTxns")
+		return
+	}
+	defer service.Finish()
+
+	if err := service.ResumeTxns(Config.Database); err != nil {
+		log.CompletedError(err, sessionID, "ResumeTxns")
+		return
+	}
+
+	log.Completed(sessionID, "ResumeTxns")
+}
+
+//** PRIVATE FUNCTIONS
+
+// copyStation returns a copy of the cached station that shares no memory with it,
+// so a caller changing its station does not change the station of the others.
+func copyStation(cached *buoyModels.BuoyStation) *buoyModels.BuoyStation {
+	buoyStation := *cached
+
+	if cached.Location.Coordinates != nil {
+		buoyStation.Location.Coordinates = append([]float64{}, cached.Location.Coordinates...)
+	}
+
+	if cached.UpdatedAt != nil {
+		updatedAt := *cached.UpdatedAt
+		buoyStation.UpdatedAt = &updatedAt
+	}
+
+	return &buoyStation
+}
@@ -0,0 +1,38 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+package buoyService
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/goinggo/beego-mgo/models/buoyModels"
+)
+
+func TestCopyStation(t *testing.T) {
+	updatedAt := time.Date(2014, 3, 1, 12, 30, 0, 0, time.UTC)
+	cached := buoyModels.BuoyStation{
+		StationID: "42002",
+		Location:  buoyModels.BuoyLocation{Type: "Point", Coordinates: []float64{-93.666, 25.79}},
+		UpdatedAt: &updatedAt,
+	}
+
+	buoyStation := copyStation(&cached)
+	if reflect.DeepEqual(buoyStation, &cached) == false {
+		t.Fatalf("copyStation() = %+v; expected %+v", buoyStation, cached)
+	}
+
+	// Changing the copy must not change the cached station.
+	buoyStation.Location.Coordinates[0] = 0
+	*buoyStation.UpdatedAt = time.Time{}
+	if cached.Location.Coordinates[0] != -93.666 || cached.UpdatedAt.Equal(updatedAt) == false {
+		t.Errorf("cached station = %+v; expected it to be unchanged", cached)
+	}
+
+	if empty := copyStation(&buoyModels.BuoyStation{}); empty.Location.Coordinates != nil || empty.UpdatedAt != nil {
+		t.Errorf("copyStation() = %+v; expected an empty station", empty)
+	}
+}
@@ -0,0 +1,351 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+package buoyService
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/goinggo/beego-mgo/models/buoyModels"
+	"github.com/goinggo/beego-mgo/utilities/helper"
+	"github.com/goinggo/beego-mgo/utilities/mongo"
+	log "github.com/goinggo/tracelog"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+//** TYPES
+
+type (
+	// Subscription receives the condition updates of the regions and stations it subscribed to.
+	Subscription struct {
+		regions  map[string]bool
+		stations map[string]bool
+		events   chan buoyModels.BuoyEvent
+	}
+
+	// eventResume finds the events that were not published yet when the events
+	// collection is tailed again. ObjectIds of concurrent writers are not in the
+	// order the events were inserted, so the events are read in $natural order and
+	// skipped until the last published event is read again.
+	eventResume struct {
+		last     bson.ObjectId
+		skipping bool
+	}
+
+	// oplogEntry contains the fields of an oplog entry the watcher needs.
+	oplogEntry struct {
+		Timestamp bson.MongoTimestamp `bson:"ts"`
+		Operation string              `bson:"op"`
+		Object    bson.M              `bson:"o"`
+		Object2   bson.M              `bson:"o2"`
+	}
+)
+
+//** CONSTANTS
+
+const (
+	// WatchEvents tails the capped buoy_events collection, which works on standalone servers.
+	WatchEvents = "events"
+
+	// WatchOplog tails the oplog of a replica set for changes to buoy_stations.
+	WatchOplog = "oplog"
+
+	// eventsCollection is the capped collection UpdateCondition records the updates in.
+	eventsCollection = "buoy_events"
+
+	// eventsSize is the size in bytes of the capped collection.
+	eventsSize = 1024 * 1024
+
+	// subscriptionBuffer is how many updates a subscriber may fall behind before updates are dropped.
+	subscriptionBuffer = 32
+
+	// tailTimeout is how long a tailable cursor waits for new documents.
+	tailTimeout = 5 * time.Second
+
+	// retryInterval is how long the watcher waits after an error before it tails again.
+	retryInterval = 5 * time.Second
+)
+
+//** PACKAGE VARIABLES
+
+// hub contains the open subscriptions.
+var hub = struct {
+	sync.Mutex
+	subscriptions map[*Subscription]bool
+}{subscriptions: make(map[*Subscription]bool)}
+
+//** PUBLIC FUNCTIONS
+
+// Subscribe returns a subscription to the condition updates of the regions and stations.
+// It receives the updates of all stations when both are empty. The subscription must be
+// closed with Unsubscribe.
+func Subscribe(regions []string, stationIDs []string) *Subscription {
+	subscription := Subscription{
+		regions:  make(map[string]bool),
+		stations: make(map[string]bool),
+		events:   make(chan buoyModels.BuoyEvent, subscriptionBuffer),
+	}
+
+	for _, region := range regions {
+		subscription.regions[region] = true
+	}
+	for _, stationID := range stationIDs {
+		subscription.stations[stationID] = true
+	}
+
+	hub.Lock()
+	hub.subscriptions[&subscription] = true
+	hub.Unlock()
+
+	return &subscription
+}
+
+// Events returns the channel of the updates, which is closed by Unsubscribe.
+func (subscription *Subscription) Events() <-chan buoyModels.BuoyEvent {
+	return subscription.events
+}
+
+// Unsubscribe stops the updates and closes the channel.
+func (subscription *Subscription) Unsubscribe() {
+	hub.Lock()
+	defer hub.Unlock()
+
+	if hub.subscriptions[subscription] {
+		delete(hub.subscriptions, subscription)
+		close(subscription.events)
+	}
+}
+
+// StartWatcher tails the changes to the stations in the background and publishes
+// them to the subscriptions. BUOY_WATCH selects how the changes are found.
+func StartWatcher(sessionID string) {
+	log.Startedf(sessionID, "StartWatcher", "Watch[%s]", Config.Watch)
+
+	watch := watchEvents
+	if Config.Watch == WatchOplog {
+		watch = watchOplog
+	}
+
+	go func() {
+		for {
+			if err := watch(sessionID); err != nil {
+				log.Error(err, sessionID, "StartWatcher")
+			}
+			time.Sleep(retryInterval)
+		}
+	}()
+
+	log.Completed(sessionID, "StartWatcher")
+}
+
+//** PRIVATE FUNCTIONS
+
+// matches checks if the subscription wants the update.
+func (subscription *Subscription) matches(event *buoyModels.BuoyEvent) bool {
+	if len(subscription.regions) == 0 && len(subscription.stations) == 0 {
+		return true
+	}
+
+	return subscription.regions[event.Region] || subscription.stations[event.StationID]
+}
+
+// publish sends the update to the matching subscriptions. The update is dropped for
+// subscribers that are too far behind, so a slow browser never blocks the watcher.
+func publish(sessionID string, event buoyModels.BuoyEvent) {
+	hub.Lock()
+	defer hub.Unlock()
+
+	for subscription := range hub.subscriptions {
+		if subscription.matches(&event) == false {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			log.Warning(sessionID, "publish", "Subscriber Behind : Dropped StationID[%s]", event.StationID)
+		}
+	}
+}
+
+// watchEvents tails the capped events collection until an error occurs.
+func watchEvents(sessionID string) (err error) {
+	defer helper.CatchPanic(&err, sessionID, "watchEvents")
+
+	mongoSession, err := mongo.CopyMasterSession(sessionID)
+	if err != nil {
+		return err
+	}
+	defer mongo.CloseSession(sessionID, mongoSession)
+
+	collection := mongo.GetCollection(mongoSession, Config.Database, eventsCollection)
+
+	log.Trace(sessionID, "watchEvents", "MGO : db.createCollection(%q, {capped: true, size: %d})", eventsCollection, eventsSize)
+	if err := collection.Create(&mgo.CollectionInfo{Capped: true, MaxBytes: eventsSize}); err != nil && isAlreadyExists(err) == false {
+		return err
+	}
+
+	// Only updates recorded from now on are published.
+	var last buoyModels.BuoyEvent
+	log.Trace(sessionID, "watchEvents", "MGO : db.%s.find().sort({$natural: -1}).limit(1)", eventsCollection)
+	if err := collection.Find(nil).Sort("-$natural").One(&last); err != nil && err != mgo.ErrNotFound {
+		return err
+	}
+	resume := eventResume{last: last.ID}
+
+	for {
+		// The last published event is gone once the capped collection wrapped around,
+		// then every event in the collection is newer.
+		found := false
+		if resume.last != "" {
+			log.Trace(sessionID, "watchEvents", "MGO : db.%s.find({_id: %s}).count()", eventsCollection, mongo.ToString(resume.last))
+			count, err := collection.FindId(resume.last).Count()
+			if err != nil {
+				return err
+			}
+			found = count > 0
+		}
+		resume.restart(found)
+
+		log.Trace(sessionID, "watchEvents", "MGO : db.%s.find().sort({$natural: 1}).addOption(DBQuery.Option.tailable)", eventsCollection)
+		iter := collection.Find(nil).Sort("$natural").Tail(tailTimeout)
+
+		var event buoyModels.BuoyEvent
+		for {
+			for iter.Next(&event) {
+				if resume.next(&event) == false {
+					continue
+				}
+
+				// The update may have been made by another process.
+				stationCache.Delete(event.StationID)
+				publish(sessionID, event)
+			}
+
+			if iter.Err() != nil || iter.Timeout() == false {
+				break
+			}
+		}
+
+		// A capped collection that wraps around past the cursor kills it,
+		// the next cursor starts over from the oldest event.
+		if err := iter.Close(); err != nil {
+			return err
+		}
+
+		// The cursor of an empty capped collection dies at once.
+		time.Sleep(time.Second)
+	}
+}
+
+// restart prepares for a cursor that reads the events from the oldest one. The
+// events are skipped up to the last published one if it is still in the collection.
+func (resume *eventResume) restart(found bool) {
+	resume.skipping = found && resume.last != ""
+}
+
+// next checks if an event read by the cursor must be published and remembers it as the last one.
+func (resume *eventResume) next(event *buoyModels.BuoyEvent) bool {
+	if resume.skipping {
+		if event.ID == resume.last {
+			resume.skipping = false
+		}
+		return false
+	}
+
+	resume.last = event.ID
+	return true
+}
+
+// watchOplog tails the oplog for changes to the stations until an error occurs.
+// A transaction changes a station several times, so an update is only published
+// when the condition of the station differs from the one published last.
+func watchOplog(sessionID string) (err error) {
+	defer helper.CatchPanic(&err, sessionID, "watchOplog")
+
+	mongoSession, err := mongo.CopyMasterSession(sessionID)
+	if err != nil {
+		return err
+	}
+	defer mongo.CloseSession(sessionID, mongoSession)
+
+	oplog := mongoSession.DB("local").C("oplog.rs")
+	stationsCollection := mongo.GetCollection(mongoSession, Config.Database, "buoy_stations")
+
+	// Only changes made from now on are published.
+	var last oplogEntry
+	log.Trace(sessionID, "watchOplog", "MGO : db.oplog.rs.find().sort({$natural: -1}).limit(1)")
+	if err := oplog.Find(nil).Sort("-$natural").One(&last); err != nil {
+		if err == mgo.ErrNotFound {
+			return fmt.Errorf("The Oplog Is Empty, Is The Server A Replica Set Member")
+		}
+		return err
+	}
+
+	published := make(map[string]buoyModels.BuoyCondition)
+	for {
+		query := bson.M{
+			"ns": Config.Database + ".buoy_stations",
+			"op": bson.M{"$in": []string{"i", "u"}},
+			"ts": bson.M{"$gt": last.Timestamp},
+		}
+
+		log.Trace(sessionID, "watchOplog", "MGO : db.oplog.rs.find(%s).addOption(DBQuery.Option.tailable | DBQuery.Option.oplogReplay)", mongo.ToString(query))
+		iter := oplog.Find(query).LogReplay().Tail(tailTimeout)
+
+		var entry oplogEntry
+		for {
+			for iter.Next(&entry) {
+				last = entry
+
+				id := entry.Object["_id"]
+				if entry.Operation == "u" {
+					id = entry.Object2["_id"]
+				}
+
+				var buoyStation buoyModels.BuoyStation
+				if err := stationsCollection.FindId(id).Select(bson.M{"station_id": 1, "region": 1, "condition": 1}).One(&buoyStation); err != nil {
+					if err == mgo.ErrNotFound {
+						continue
+					}
+					iter.Close()
+					return err
+				}
+
+				stationCache.Delete(buoyStation.StationID)
+
+				if condition, ok := published[buoyStation.StationID]; ok && condition == buoyStation.Condition {
+					continue
+				}
+				published[buoyStation.StationID] = buoyStation.Condition
+
+				publish(sessionID, buoyModels.BuoyEvent{
+					StationID:  buoyStation.StationID,
+					Region:     buoyStation.Region,
+					Condition:  buoyStation.Condition,
+					RecordedAt: time.Now().UTC(),
+				})
+			}
+
+			if iter.Err() != nil || iter.Timeout() == false {
+				break
+			}
+		}
+
+		if err := iter.Close(); err != nil {
+			return err
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+// isAlreadyExists checks if the error reports that the collection exists.
+func isAlreadyExists(err error) bool {
+	queryError, ok := err.(*mgo.QueryError)
+	return ok && queryError.Code == 48
+}
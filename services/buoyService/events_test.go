@@ -0,0 +1,116 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+package buoyService
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/goinggo/beego-mgo/models/buoyModels"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestPublish(t *testing.T) {
+	all := Subscribe(nil, nil)
+	defer all.Unsubscribe()
+	gulf := Subscribe([]string{"Gulf Of Mexico"}, nil)
+	defer gulf.Unsubscribe()
+	station := Subscribe(nil, []string{"42002"})
+	defer station.Unsubscribe()
+
+	publish("test", buoyModels.BuoyEvent{StationID: "42001", Region: "Gulf Of Mexico"})
+	publish("test", buoyModels.BuoyEvent{StationID: "42002", Region: "Caribbean"})
+
+	tests := []struct {
+		name         string
+		subscription *Subscription
+		expected     []string
+	}{
+		{"all", all, []string{"42001", "42002"}},
+		{"region", gulf, []string{"42001"}},
+		{"station", station, []string{"42002"}},
+	}
+
+	for _, test := range tests {
+		var received []string
+		for len(test.subscription.Events()) > 0 {
+			event := <-test.subscription.Events()
+			received = append(received, event.StationID)
+		}
+
+		if len(received) != len(test.expected) {
+			t.Errorf("%s received %v; expected %v", test.name, received, test.expected)
+			continue
+		}
+		for i := range received {
+			if received[i] != test.expected[i] {
+				t.Errorf("%s received %v; expected %v", test.name, received, test.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestPublishDropsForSlowSubscribers(t *testing.T) {
+	subscription := Subscribe(nil, []string{"42003"})
+
+	for i := 0; i < subscriptionBuffer+5; i++ {
+		publish("test", buoyModels.BuoyEvent{StationID: "42003"})
+	}
+
+	if received := len(subscription.Events()); received != subscriptionBuffer {
+		t.Errorf("buffered %d updates; expected %d", received, subscriptionBuffer)
+	}
+
+	subscription.Unsubscribe()
+	subscription.Unsubscribe()
+
+	for range subscription.Events() {
+	}
+}
+
+func TestEventResume(t *testing.T) {
+	// Another process created its ObjectId first but inserted its event last,
+	// so the events are out of _id order in the capped collection.
+	earlier := bson.NewObjectIdWithTime(time.Unix(1000, 0))
+	later := bson.NewObjectIdWithTime(time.Unix(2000, 0))
+	latest := bson.NewObjectIdWithTime(time.Unix(3000, 0))
+	natural := []bson.ObjectId{latest, later, earlier}
+
+	read := func(resume *eventResume, ids []bson.ObjectId) []bson.ObjectId {
+		var published []bson.ObjectId
+		for _, id := range ids {
+			if resume.next(&buoyModels.BuoyEvent{ID: id}) {
+				published = append(published, id)
+			}
+		}
+		return published
+	}
+
+	// The cursor died after publishing later, the event inserted after it has a smaller _id.
+	resume := eventResume{last: later}
+	resume.restart(true)
+	if published := read(&resume, natural); reflect.DeepEqual(published, []bson.ObjectId{earlier}) == false {
+		t.Errorf("published %v; expected only the event inserted after the last one %v", published, earlier)
+	}
+	if resume.last != earlier {
+		t.Errorf("last = %v; expected %v", resume.last, earlier)
+	}
+
+	// The last published event was overwritten, so every event is new.
+	resume = eventResume{last: bson.NewObjectIdWithTime(time.Unix(4000, 0))}
+	resume.restart(false)
+	if published := read(&resume, natural); reflect.DeepEqual(published, natural) == false {
+		t.Errorf("published %v; expected %v", published, natural)
+	}
+
+	// Nothing was published yet.
+	resume = eventResume{}
+	resume.restart(false)
+	if published := read(&resume, natural); reflect.DeepEqual(published, natural) == false {
+		t.Errorf("published %v; expected %v", published, natural)
+	}
+}
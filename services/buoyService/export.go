@@ -0,0 +1,344 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+package buoyService
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goinggo/beego-mgo/models/buoyModels"
+	"github.com/goinggo/beego-mgo/services"
+	log "github.com/goinggo/tracelog"
+	"gopkg.in/mgo.v2/bson"
+)
+
+//** TYPES
+
+type (
+	// Exporter writes stations in an export format as they are read.
+	Exporter interface {
+		// ContentType returns the media type of the format.
+		ContentType() string
+
+		// Extension returns the file extension of the format.
+		Extension() string
+
+		// Begin writes what comes before the stations.
+		Begin(region string) error
+
+		// Write writes a station.
+		Write(buoyStation *buoyModels.BuoyStation) error
+
+		// End writes what comes after the stations and flushes the output.
+		End() error
+	}
+
+	// csvExporter writes a row per station with the selected columns.
+	csvExporter struct {
+		writer  *csv.Writer
+		columns []string
+	}
+
+	// geoJSONExporter writes a GeoJSON FeatureCollection with a Point feature per station.
+	geoJSONExporter struct {
+		w        io.Writer
+		features int
+	}
+
+	// geoJSONFeature is a station as a GeoJSON feature.
+	geoJSONFeature struct {
+		Type       string                  `json:"type"`
+		ID         string                  `json:"id"`
+		Geometry   buoyModels.BuoyLocation `json:"geometry"`
+		Properties geoJSONProperties       `json:"properties"`
+	}
+
+	// geoJSONProperties are the properties of a station feature.
+	geoJSONProperties struct {
+		StationID string `json:"station_id"`
+		Name      string `json:"name"`
+		LocDesc   string `json:"location_desc"`
+		buoyModels.BuoyCondition
+	}
+
+	// kmlExporter writes a KML document with a placemark per station for Google Earth.
+	kmlExporter struct {
+		w       io.Writer
+		encoder *xml.Encoder
+	}
+
+	// kmlPlacemark is a station as a KML placemark.
+	kmlPlacemark struct {
+		XMLName     xml.Name  `xml:"Placemark"`
+		ID          string    `xml:"id,attr"`
+		Name        string    `xml:"name"`
+		Description string    `xml:"description"`
+		Coordinates string    `xml:"Point>coordinates"`
+		Data        []kmlData `xml:"ExtendedData>Data"`
+	}
+
+	// kmlData is a value of the extended data of a placemark.
+	kmlData struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value"`
+	}
+)
+
+//** CONSTANTS
+
+const (
+	// The export formats.
+	FormatCSV     = "csv"
+	FormatGeoJSON = "geojson"
+	FormatKML     = "kml"
+)
+
+//** PACKAGE VARIABLES
+
+// csvColumns returns the value of each CSV column of a station.
+var csvColumns = map[string]func(buoyStation *buoyModels.BuoyStation) string{
+	"station_id":    func(s *buoyModels.BuoyStation) string { return s.StationID },
+	"name":          func(s *buoyModels.BuoyStation) string { return s.Name },
+	"location_desc": func(s *buoyModels.BuoyStation) string { return s.LocDesc },
+	"region":        func(s *buoyModels.BuoyStation) string { return s.Region },
+	"longitude":     func(s *buoyModels.BuoyStation) string { return coordinate(s, 0) },
+	"latitude":      func(s *buoyModels.BuoyStation) string { return coordinate(s, 1) },
+	"wind_speed_milehour": func(s *buoyModels.BuoyStation) string {
+		return strconv.FormatFloat(s.Condition.WindSpeed, 'f', -1, 64)
+	},
+	"wind_direction_degnorth": func(s *buoyModels.BuoyStation) string {
+		return strconv.Itoa(s.Condition.WindDirection)
+	},
+	"gust_wind_speed_milehour": func(s *buoyModels.BuoyStation) string {
+		return strconv.FormatFloat(s.Condition.WindGust, 'f', -1, 64)
+	},
+	"updated_at": func(s *buoyModels.BuoyStation) string {
+		if s.UpdatedAt == nil {
+			return ""
+		}
+		return s.UpdatedAt.UTC().Format(time.RFC3339)
+	},
+}
+
+// DefaultCSVColumns are the CSV columns when none are selected.
+var DefaultCSVColumns = []string{
+	"station_id", "name", "location_desc", "region", "longitude", "latitude",
+	"wind_speed_milehour", "wind_direction_degnorth", "gust_wind_speed_milehour", "updated_at",
+}
+
+//** PUBLIC FUNCTIONS
+
+// NewExporter returns the exporter of the format writing to w. The columns select
+// the CSV columns and their order, all of them are written when there are none.
+func NewExporter(format string, w io.Writer, columns []string) (Exporter, error) {
+	switch format {
+	case FormatCSV:
+		if len(columns) == 0 {
+			columns = DefaultCSVColumns
+		}
+		for _, column := range columns {
+			if _, ok := csvColumns[column]; ok == false {
+				return nil, fmt.Errorf("Unknown Column %s, The Columns Are %s", column, strings.Join(DefaultCSVColumns, ", "))
+			}
+		}
+		return &csvExporter{writer: csv.NewWriter(w), columns: columns}, nil
+
+	case FormatGeoJSON:
+		return &geoJSONExporter{w: w}, nil
+
+	case FormatKML:
+		return &kmlExporter{w: w, encoder: xml.NewEncoder(w)}, nil
+	}
+
+	return nil, fmt.Errorf("Unknown Format %q, The Formats Are csv, geojson And kml", format)
+}
+
+// ExportRegion writes the stations of the region with the exporter as they are
+// read from the database, ordered by station id.
+func ExportRegion(service *services.Service, region string, exporter Exporter) error {
+	log.Startedf(service.UserID, "ExportRegion", "region[%s] exporter[%T]", region, exporter)
+
+	if err := exporter.Begin(region); err != nil {
+		log.CompletedError(err, service.UserID, "ExportRegion")
+		return err
+	}
+
+	var exported int
+	var buoyStation buoyModels.BuoyStation
+	each := func() error {
+		err := exporter.Write(&buoyStation)
+
+		// Decoding the next station must not keep fields of this one.
+		buoyStation = buoyModels.BuoyStation{}
+		exported++
+		return err
+	}
+
+	if err := stations.ForEach(service, bson.M{"region": region}, &buoyStation, each, services.FindOptions{Sort: []string{"station_id"}}); err != nil {
+		log.CompletedError(err, service.UserID, "ExportRegion")
+		return err
+	}
+
+	if err := exporter.End(); err != nil {
+		log.CompletedError(err, service.UserID, "ExportRegion")
+		return err
+	}
+
+	log.Completedf(service.UserID, "ExportRegion", "Exported[%d]", exported)
+	return nil
+}
+
+//** CSV
+
+// ContentType implements the Exporter interface.
+func (exporter *csvExporter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+// Extension implements the Exporter interface.
+func (exporter *csvExporter) Extension() string {
+	return "csv"
+}
+
+// Begin writes the header row.
+func (exporter *csvExporter) Begin(region string) error {
+	return exporter.writer.Write(exporter.columns)
+}
+
+// Write writes the row of the station.
+func (exporter *csvExporter) Write(buoyStation *buoyModels.BuoyStation) error {
+	row := make([]string, len(exporter.columns))
+	for i, column := range exporter.columns {
+		row[i] = csvColumns[column](buoyStation)
+	}
+
+	return exporter.writer.Write(row)
+}
+
+// End flushes the rows.
+func (exporter *csvExporter) End() error {
+	exporter.writer.Flush()
+	return exporter.writer.Error()
+}
+
+//** GEOJSON
+
+// ContentType implements the Exporter interface.
+func (exporter *geoJSONExporter) ContentType() string {
+	return "application/geo+json"
+}
+
+// Extension implements the Exporter interface.
+func (exporter *geoJSONExporter) Extension() string {
+	return "geojson"
+}
+
+// Begin opens the feature collection.
+func (exporter *geoJSONExporter) Begin(region string) error {
+	_, err := io.WriteString(exporter.w, `{"type":"FeatureCollection","features":[`)
+	return err
+}
+
+// Write writes the feature of the station.
+func (exporter *geoJSONExporter) Write(buoyStation *buoyModels.BuoyStation) error {
+	feature := geoJSONFeature{
+		Type:     "Feature",
+		ID:       buoyStation.StationID,
+		Geometry: buoyStation.Location,
+		Properties: geoJSONProperties{
+			StationID:     buoyStation.StationID,
+			Name:          buoyStation.Name,
+			LocDesc:       buoyStation.LocDesc,
+			BuoyCondition: buoyStation.Condition,
+		},
+	}
+
+	data, err := json.Marshal(&feature)
+	if err != nil {
+		return err
+	}
+
+	if exporter.features > 0 {
+		data = append([]byte{','}, data...)
+	}
+	exporter.features++
+
+	_, err = exporter.w.Write(data)
+	return err
+}
+
+// End closes the feature collection.
+func (exporter *geoJSONExporter) End() error {
+	_, err := io.WriteString(exporter.w, "]}\n")
+	return err
+}
+
+//** KML
+
+// ContentType implements the Exporter interface.
+func (exporter *kmlExporter) ContentType() string {
+	return "application/vnd.google-earth.kml+xml"
+}
+
+// Extension implements the Exporter interface.
+func (exporter *kmlExporter) Extension() string {
+	return "kml"
+}
+
+// Begin opens the document named after the region.
+func (exporter *kmlExporter) Begin(region string) error {
+	if _, err := io.WriteString(exporter.w, xml.Header+`<kml xmlns="http://www.opengis.net/kml/2.2"><Document><name>`); err != nil {
+		return err
+	}
+	if err := xml.EscapeText(exporter.w, []byte(region)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(exporter.w, "</name>\n")
+	return err
+}
+
+// Write writes the placemark of the station.
+func (exporter *kmlExporter) Write(buoyStation *buoyModels.BuoyStation) error {
+	placemark := kmlPlacemark{
+		ID:          buoyStation.StationID,
+		Name:        buoyStation.Name,
+		Description: buoyStation.LocDesc,
+		Coordinates: coordinate(buoyStation, 0) + "," + coordinate(buoyStation, 1),
+	}
+
+	for _, column := range []string{"station_id", "wind_speed_milehour", "wind_direction_degnorth", "gust_wind_speed_milehour"} {
+		placemark.Data = append(placemark.Data, kmlData{Name: column, Value: csvColumns[column](buoyStation)})
+	}
+
+	if err := exporter.encoder.Encode(&placemark); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(exporter.w, "\n")
+	return err
+}
+
+// End closes the document.
+func (exporter *kmlExporter) End() error {
+	_, err := io.WriteString(exporter.w, "</Document></kml>\n")
+	return err
+}
+
+//** PRIVATE FUNCTIONS
+
+// coordinate returns the longitude (0) or latitude (1) of the station.
+func coordinate(buoyStation *buoyModels.BuoyStation, i int) string {
+	if i >= len(buoyStation.Location.Coordinates) {
+		return ""
+	}
+
+	return strconv.FormatFloat(buoyStation.Location.Coordinates[i], 'f', -1, 64)
+}
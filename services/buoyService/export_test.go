@@ -0,0 +1,145 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+package buoyService
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/goinggo/beego-mgo/models/buoyModels"
+)
+
+var exportStations = []buoyModels.BuoyStation{
+	{
+		StationID: "42001",
+		Name:      "Mid Gulf",
+		LocDesc:   "180 nm South of Southwest Pass, LA",
+		Region:    "Gulf Of Mexico",
+		Condition: buoyModels.BuoyCondition{WindSpeed: 12.5, WindDirection: 90, WindGust: 15},
+		Location:  buoyModels.BuoyLocation{Type: "Point", Coordinates: []float64{-89.668, 25.888}},
+	},
+	{
+		StationID: "42002",
+		Name:      "West Gulf, \"Brownsville\" & <Galveston>",
+		Region:    "Gulf Of Mexico",
+		Location:  buoyModels.BuoyLocation{Type: "Point", Coordinates: []float64{-93.666, 25.79}},
+	},
+}
+
+// export writes the stations in the format.
+func export(t *testing.T, format string, columns []string) string {
+	var buf bytes.Buffer
+	exporter, err := NewExporter(format, &buf, columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := exporter.Begin("Gulf Of Mexico"); err != nil {
+		t.Fatal(err)
+	}
+	for i := range exportStations {
+		if err := exporter.Write(&exportStations[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := exporter.End(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.String()
+}
+
+func TestExportCSV(t *testing.T) {
+	expected := "station_id,wind_speed_milehour,name\n" +
+		"42001,12.5,Mid Gulf\n" +
+		"42002,0,\"West Gulf, \"\"Brownsville\"\" & <Galveston>\"\n"
+
+	if output := export(t, FormatCSV, []string{"station_id", "wind_speed_milehour", "name"}); output != expected {
+		t.Errorf("csv = %q; expected %q", output, expected)
+	}
+
+	if output := export(t, FormatCSV, nil); strings.HasPrefix(output, strings.Join(DefaultCSVColumns, ",")+"\n") == false {
+		t.Errorf("csv = %q; expected the default columns", output)
+	}
+
+	if _, err := NewExporter(FormatCSV, &bytes.Buffer{}, []string{"station_id", "password"}); err == nil {
+		t.Error("NewExporter() expected an error for an unknown column")
+	}
+}
+
+func TestExportGeoJSON(t *testing.T) {
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Type     string `json:"type"`
+			ID       string `json:"id"`
+			Geometry struct {
+				Type        string    `json:"type"`
+				Coordinates []float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+
+	output := export(t, FormatGeoJSON, nil)
+	if err := json.Unmarshal([]byte(output), &collection); err != nil {
+		t.Fatalf("geojson = %s : %v", output, err)
+	}
+
+	if collection.Type != "FeatureCollection" || len(collection.Features) != 2 {
+		t.Fatalf("geojson = %s; expected a collection of 2 features", output)
+	}
+
+	feature := collection.Features[0]
+	if feature.Type != "Feature" || feature.ID != "42001" || feature.Geometry.Type != "Point" || feature.Geometry.Coordinates[0] != -89.668 {
+		t.Errorf("feature = %+v", feature)
+	}
+	if feature.Properties["wind_speed_milehour"] != 12.5 || feature.Properties["name"] != "Mid Gulf" {
+		t.Errorf("properties = %v; expected the condition and station", feature.Properties)
+	}
+}
+
+func TestExportKML(t *testing.T) {
+	var kml struct {
+		Document struct {
+			Name       string `xml:"name"`
+			Placemarks []struct {
+				ID          string `xml:"id,attr"`
+				Name        string `xml:"name"`
+				Coordinates string `xml:"Point>coordinates"`
+				Data        []struct {
+					Name  string `xml:"name,attr"`
+					Value string `xml:"value"`
+				} `xml:"ExtendedData>Data"`
+			} `xml:"Placemark"`
+		} `xml:"Document"`
+	}
+
+	output := export(t, FormatKML, nil)
+	if err := xml.Unmarshal([]byte(output), &kml); err != nil {
+		t.Fatalf("kml = %s : %v", output, err)
+	}
+
+	if kml.Document.Name != "Gulf Of Mexico" || len(kml.Document.Placemarks) != 2 {
+		t.Fatalf("kml = %s; expected a document with 2 placemarks", output)
+	}
+
+	placemark := kml.Document.Placemarks[1]
+	if placemark.ID != "42002" || placemark.Name != exportStations[1].Name || placemark.Coordinates != "-93.666,25.79" {
+		t.Errorf("placemark = %+v", placemark)
+	}
+	if len(placemark.Data) != 4 || placemark.Data[1].Name != "wind_speed_milehour" {
+		t.Errorf("extended data = %+v", placemark.Data)
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	if _, err := NewExporter("shapefile", &bytes.Buffer{}, nil); err == nil {
+		t.Error("NewExporter() expected an error for an unknown format")
+	}
+}
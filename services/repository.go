@@ -0,0 +1,313 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of service source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+package services
+
+import (
+	"expvar"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goinggo/beego-mgo/utilities/mongo"
+	log "github.com/goinggo/tracelog"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+//** TYPES
+
+type (
+	// Repository runs the common queries and commands against a collection,
+	// with consistent logging, not found handling and metrics.
+	Repository struct {
+		Database   string
+		Collection string
+	}
+
+	// FindOptions change which documents are found and how they are returned.
+	FindOptions struct {
+		// Select limits the fields that are returned (e.g. bson.M{"name": 1}).
+		Select bson.M
+		// Sort orders the documents by fields, descending when prefixed with - (e.g. -name).
+		Sort  []string
+		Skip  int
+		Limit int
+
+		mongo.DBOptions
+	}
+
+	// WriteOptions change how documents are updated and deleted.
+	WriteOptions struct {
+		// Multi updates or deletes every matching document instead of the first one.
+		Multi bool
+
+		mongo.DBOptions
+	}
+)
+
+//** PACKAGE VARIABLES
+
+// metrics counts the calls, not found results and errors of the repository operations
+// and their total milliseconds by collection and operation (e.g. buoy_stations.FindOne.calls).
+// They are published with expvar.
+var metrics = expvar.NewMap("repository")
+
+//** PUBLIC FUNCTIONS
+
+// FindOne decodes the first document matching the query into result.
+// It returns false if there is no such document.
+func (repository Repository) FindOne(service *Service, query bson.M, result interface{}, options ...FindOptions) (found bool, err error) {
+	findOptions := mergeFindOptions(options)
+
+	f := func(collection *mgo.Collection) error {
+		log.Trace(service.UserID, "FindOne", "MGO : db.%s.find(%s%s)%s.limit(1)", repository.Collection, mongo.ToString(query), findOptions.projection(), findOptions.cursor(false))
+		return findOptions.apply(collection.Find(query)).One(result)
+	}
+
+	err = repository.execute(service, "FindOne", findOptions.DBOptions, f)
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// FindMany decodes the documents matching the query into results, which must be a pointer to a slice.
+func (repository Repository) FindMany(service *Service, query bson.M, results interface{}, options ...FindOptions) error {
+	findOptions := mergeFindOptions(options)
+
+	f := func(collection *mgo.Collection) error {
+		log.Trace(service.UserID, "FindMany", "MGO : db.%s.find(%s%s)%s", repository.Collection, mongo.ToString(query), findOptions.projection(), findOptions.cursor(true))
+		return findOptions.apply(collection.Find(query)).All(results)
+	}
+
+	return repository.execute(service, "FindMany", findOptions.DBOptions, f)
+}
+
+// ForEach decodes the documents matching the query into result one at a time and calls
+// each after every document, so they are never all in memory. It stops at the first error of each.
+func (repository Repository) ForEach(service *Service, query bson.M, result interface{}, each func() error, options ...FindOptions) error {
+	findOptions := mergeFindOptions(options)
+
+	f := func(collection *mgo.Collection) error {
+		log.Trace(service.UserID, "ForEach", "MGO : db.%s.find(%s%s)%s", repository.Collection, mongo.ToString(query), findOptions.projection(), findOptions.cursor(true))
+		iter := findOptions.apply(collection.Find(query)).Iter()
+		for iter.Next(result) {
+			if err := each(); err != nil {
+				iter.Close()
+				return err
+			}
+		}
+
+		return iter.Close()
+	}
+
+	return repository.execute(service, "ForEach", findOptions.DBOptions, f)
+}
+
+// Count returns the number of documents matching the query.
+func (repository Repository) Count(service *Service, query bson.M, options ...mongo.DBOptions) (count int, err error) {
+	f := func(collection *mgo.Collection) error {
+		log.Trace(service.UserID, "Count", "MGO : db.%s.find(%s).count()", repository.Collection, mongo.ToString(query))
+		count, err = collection.Find(query).Count()
+		return err
+	}
+
+	err = repository.execute(service, "Count", mergeDBOptions(options), f)
+	return count, err
+}
+
+// Insert inserts the documents.
+func (repository Repository) Insert(service *Service, documents []interface{}, options ...mongo.DBOptions) error {
+	f := func(collection *mgo.Collection) error {
+		log.Trace(service.UserID, "Insert", "MGO : db.%s.insert(%s)", repository.Collection, mongo.ToString(documents))
+		return collection.Insert(documents...)
+	}
+
+	return repository.execute(service, "Insert", mergeDBOptions(options), f)
+}
+
+// Update applies the update to the first document matching the selector, or to all of
+// them with the Multi option, and returns the number of documents that were updated.
+func (repository Repository) Update(service *Service, selector bson.M, update interface{}, options ...WriteOptions) (updated int, err error) {
+	writeOptions := mergeWriteOptions(options)
+
+	f := func(collection *mgo.Collection) error {
+		log.Trace(service.UserID, "Update", "MGO : db.%s.update(%s, %s, {multi: %v})", repository.Collection, mongo.ToString(selector), mongo.ToString(update), writeOptions.Multi)
+		if writeOptions.Multi {
+			changeInfo, err := collection.UpdateAll(selector, update)
+			if changeInfo != nil {
+				updated = changeInfo.Updated
+			}
+			return err
+		}
+
+		if err := collection.Update(selector, update); err != nil {
+			return err
+		}
+		updated = 1
+		return nil
+	}
+
+	err = repository.execute(service, "Update", writeOptions.DBOptions, f)
+	if err == mgo.ErrNotFound {
+		return 0, nil
+	}
+
+	return updated, err
+}
+
+// Upsert applies the update to the first document matching the selector,
+// or inserts a document if there is none.
+func (repository Repository) Upsert(service *Service, selector bson.M, update interface{}, options ...mongo.DBOptions) (changeInfo *mgo.ChangeInfo, err error) {
+	f := func(collection *mgo.Collection) error {
+		log.Trace(service.UserID, "Upsert", "MGO : db.%s.update(%s, %s, {upsert: true})", repository.Collection, mongo.ToString(selector), mongo.ToString(update))
+		changeInfo, err = collection.Upsert(selector, update)
+		return err
+	}
+
+	err = repository.execute(service, "Upsert", mergeDBOptions(options), f)
+	return changeInfo, err
+}
+
+// Delete removes the first document matching the selector, or all of
+// them with the Multi option, and returns the number of documents that were removed.
+func (repository Repository) Delete(service *Service, selector bson.M, options ...WriteOptions) (removed int, err error) {
+	writeOptions := mergeWriteOptions(options)
+
+	f := func(collection *mgo.Collection) error {
+		log.Trace(service.UserID, "Delete", "MGO : db.%s.remove(%s, {justOne: %v})", repository.Collection, mongo.ToString(selector), writeOptions.Multi == false)
+		if writeOptions.Multi {
+			changeInfo, err := collection.RemoveAll(selector)
+			if changeInfo != nil {
+				removed = changeInfo.Removed
+			}
+			return err
+		}
+
+		if err := collection.Remove(selector); err != nil {
+			return err
+		}
+		removed = 1
+		return nil
+	}
+
+	err = repository.execute(service, "Delete", writeOptions.DBOptions, f)
+	if err == mgo.ErrNotFound {
+		return 0, nil
+	}
+
+	return removed, err
+}
+
+// Aggregate runs the pipeline and decodes its documents into results, which must be a pointer to a slice.
+func (repository Repository) Aggregate(service *Service, pipeline []bson.M, results interface{}, options ...mongo.DBOptions) error {
+	f := func(collection *mgo.Collection) error {
+		log.Trace(service.UserID, "Aggregate", "MGO : db.%s.aggregate(%s)", repository.Collection, mongo.ToString(pipeline))
+		return collection.Pipe(pipeline).All(results)
+	}
+
+	return repository.execute(service, "Aggregate", mergeDBOptions(options), f)
+}
+
+//** PRIVATE FUNCTIONS
+
+// execute runs the operation with DBAction and records its metrics.
+func (repository Repository) execute(service *Service, operation string, options mongo.DBOptions, f mongo.DBCall) error {
+	key := repository.Collection + "." + operation
+	start := time.Now()
+
+	err := service.DBAction(repository.Database, repository.Collection, f, options)
+
+	metrics.Add(key+".calls", 1)
+	metrics.AddFloat(key+".milliseconds", float64(time.Since(start))/float64(time.Millisecond))
+	switch {
+	case err == mgo.ErrNotFound:
+		metrics.Add(key+".not_found", 1)
+	case err != nil:
+		metrics.Add(key+".errors", 1)
+	}
+
+	return err
+}
+
+// apply sets the options on the query.
+func (options FindOptions) apply(query *mgo.Query) *mgo.Query {
+	if options.Select != nil {
+		query = query.Select(options.Select)
+	}
+	if len(options.Sort) > 0 {
+		query = query.Sort(options.Sort...)
+	}
+	if options.Skip > 0 {
+		query = query.Skip(options.Skip)
+	}
+	if options.Limit > 0 {
+		query = query.Limit(options.Limit)
+	}
+
+	return query
+}
+
+// projection returns the projection argument of the find command for logging.
+func (options FindOptions) projection() string {
+	if options.Select == nil {
+		return ""
+	}
+
+	return ", " + mongo.ToString(options.Select)
+}
+
+// cursor returns the cursor methods of the options for logging.
+func (options FindOptions) cursor(limit bool) string {
+	var cursor []string
+	if len(options.Sort) > 0 {
+		fields := make([]string, len(options.Sort))
+		for i, field := range options.Sort {
+			if strings.HasPrefix(field, "-") {
+				fields[i] = fmt.Sprintf("%q:-1", field[1:])
+				continue
+			}
+			fields[i] = fmt.Sprintf("%q:1", strings.TrimPrefix(field, "+"))
+		}
+		cursor = append(cursor, fmt.Sprintf(".sort({%s})", strings.Join(fields, ",")))
+	}
+	if options.Skip > 0 {
+		cursor = append(cursor, fmt.Sprintf(".skip(%d)", options.Skip))
+	}
+	if limit && options.Limit > 0 {
+		cursor = append(cursor, fmt.Sprintf(".limit(%d)", options.Limit))
+	}
+
+	return strings.Join(cursor, "")
+}
+
+// mergeFindOptions returns the last of the options or the zero options.
+func mergeFindOptions(options []FindOptions) FindOptions {
+	if len(options) == 0 {
+		return FindOptions{}
+	}
+
+	return options[len(options)-1]
+}
+
+// mergeWriteOptions returns the last of the options or the zero options.
+func mergeWriteOptions(options []WriteOptions) WriteOptions {
+	if len(options) == 0 {
+		return WriteOptions{}
+	}
+
+	return options[len(options)-1]
+}
+
+// mergeDBOptions merges the options in order.
+func mergeDBOptions(options []mongo.DBOptions) mongo.DBOptions {
+	var dbOptions mongo.DBOptions
+	for _, option := range options {
+		dbOptions = dbOptions.Merge(option)
+	}
+
+	return dbOptions
+}
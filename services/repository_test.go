@@ -0,0 +1,50 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of service source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+package services
+
+import (
+	"testing"
+
+	"github.com/goinggo/beego-mgo/utilities/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestFindOptionsLogging(t *testing.T) {
+	tests := []struct {
+		options    FindOptions
+		limit      bool
+		projection string
+		cursor     string
+	}{
+		{FindOptions{}, true, "", ""},
+		{FindOptions{Select: bson.M{"name": 1}}, true, `, {"name":1}`, ""},
+		{FindOptions{Sort: []string{"-recorded_at", "name"}, Skip: 10, Limit: 5}, true, "", `.sort({"recorded_at":-1,"name":1}).skip(10).limit(5)`},
+		{FindOptions{Limit: 5}, false, "", ""},
+	}
+
+	for _, test := range tests {
+		if projection := test.options.projection(); projection != test.projection {
+			t.Errorf("%+v projection() = %q; expected %q", test.options, projection, test.projection)
+		}
+		if cursor := test.options.cursor(test.limit); cursor != test.cursor {
+			t.Errorf("%+v cursor(%v) = %q; expected %q", test.options, test.limit, cursor, test.cursor)
+		}
+	}
+}
+
+func TestMergeOptions(t *testing.T) {
+	if options := mergeFindOptions(nil); options.Limit != 0 || options.Select != nil {
+		t.Errorf("mergeFindOptions(nil) = %+v; expected the zero options", options)
+	}
+
+	if options := mergeWriteOptions([]WriteOptions{{}, {Multi: true}}); options.Multi == false {
+		t.Errorf("mergeWriteOptions() = %+v; expected the last options", options)
+	}
+
+	options := mergeDBOptions([]mongo.DBOptions{mongo.MajorityWrites, mongo.SecondaryReads})
+	if options.WriteConcern == nil || options.WriteConcern.W != "majority" || options.ReadPreference == nil {
+		t.Errorf("mergeDBOptions() = %s; expected majority writes and secondary reads", options)
+	}
+}
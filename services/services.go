@@ -47,7 +47,24 @@ func (service *Service) Finish() (err error) {
 	return err
 }
 
-// DBAction executes the MongoDB literal function
-func (service *Service) DBAction(databaseName string, collectionName string, dbCall mongo.DBCall) (err error) {
-	return mongo.Execute(service.UserID, service.MongoSession, databaseName, collectionName, dbCall)
+// DBAction executes the MongoDB literal function. Options change the write concern
+// and read preference of the call, e.g. mongo.MajorityWrites for updates that must not be lost.
+func (service *Service) DBAction(databaseName string, collectionName string, dbCall mongo.DBCall, options ...mongo.DBOptions) (err error) {
+	var dbOptions mongo.DBOptions
+	for _, option := range options {
+		dbOptions = dbOptions.Merge(option)
+	}
+
+	return mongo.ExecuteWithOptions(service.UserID, service.MongoSession, databaseName, collectionName, dbOptions, dbCall)
+}
+
+// TxnAction executes the MongoDB transaction function, which changes documents
+// of several collections atomically.
+func (service *Service) TxnAction(databaseName string, txnCall mongo.TxnCall) (err error) {
+	return mongo.ExecuteTxn(service.UserID, service.MongoSession, databaseName, txnCall)
+}
+
+// ResumeTxns completes the transactions that were pending when the application stopped.
+func (service *Service) ResumeTxns(databaseName string) (err error) {
+	return mongo.ResumeTxns(service.UserID, service.MongoSession, databaseName)
 }
@@ -0,0 +1,336 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+// Package translationService implements the service for the runtime editable translations.
+package translationService
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/locale"
+	"github.com/goinggo/beego-mgo/go-i18n/i18n/translation"
+	"github.com/goinggo/beego-mgo/localize"
+	"github.com/goinggo/beego-mgo/models/translationModels"
+	"github.com/goinggo/beego-mgo/services"
+	"github.com/goinggo/beego-mgo/utilities/helper"
+	"github.com/goinggo/beego-mgo/utilities/mongo"
+	log "github.com/goinggo/tracelog"
+	"github.com/kelseyhightower/envconfig"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+//** TYPES
+
+type (
+	// translationConfiguration contains settings for running the translation service.
+	translationConfiguration struct {
+		Database        string
+		AdminToken      string
+		RefreshInterval time.Duration
+	}
+)
+
+//** CONSTANTS
+
+const (
+	// translationsCollection contains a document per locale and translation id.
+	translationsCollection = "translations"
+
+	// revisionsCollection contains the revision of the published translations.
+	revisionsCollection = "translation_revisions"
+
+	// revisionID is the id of the revision document.
+	revisionID = "translations"
+
+	// defaultRefreshInterval is used when no refresh interval is configured.
+	defaultRefreshInterval = 30 * time.Second
+)
+
+//** PACKAGE VARIABLES
+
+// Config provides translation configuration.
+var Config translationConfiguration
+
+// ErrVersionConflict is returned when a translation was changed since the version was read.
+var ErrVersionConflict = fmt.Errorf("Translation Version Conflict")
+
+// loadedRevision is the revision of the translations the watcher loaded last.
+var loadedRevision = -1
+
+//** INIT
+
+func init() {
+	// Pull in the configuration.
+	if err := envconfig.Process("translation", &Config); err != nil {
+		log.CompletedError(err, helper.MainGoRoutine, "Init")
+	}
+
+	if Config.RefreshInterval <= 0 {
+		Config.RefreshInterval = defaultRefreshInterval
+	}
+}
+
+//** PUBLIC FUNCTIONS
+
+// ValidateDraft makes sure that a draft is a valid translation for a valid locale.
+func ValidateDraft(localeID string, translationID string, content *translationModels.TranslationContent) error {
+	if _, err := locale.New(localeID); err != nil {
+		return err
+	}
+
+	if translationID == "" {
+		return fmt.Errorf("Missing Translation Id")
+	}
+
+	if _, err := translation.NewTranslation(content.Document(translationID)); err != nil {
+		return fmt.Errorf("Invalid Translation %s : %v", translationID, err)
+	}
+
+	return nil
+}
+
+// FindTranslations retrieves the translations of the specified locale or of all locales.
+func FindTranslations(service *services.Service, localeID string) ([]translationModels.Translation, error) {
+	log.Startedf(service.UserID, "FindTranslations", "localeID[%s]", localeID)
+
+	queryMap := bson.M{}
+	if localeID != "" {
+		l, err := locale.New(localeID)
+		if err != nil {
+			log.CompletedError(err, service.UserID, "FindTranslations")
+			return nil, err
+		}
+		queryMap["locale"] = l.ID
+	}
+
+	var translations []translationModels.Translation
+	f := func(collection *mgo.Collection) error {
+		log.Trace(service.UserID, "FindTranslations", "MGO : db.translations.find(%s).sort({locale: 1, translation_id: 1})", mongo.ToString(queryMap))
+		return collection.Find(queryMap).Sort("locale", "translation_id").All(&translations)
+	}
+
+	if err := service.DBAction(Config.Database, translationsCollection, f); err != nil {
+		log.CompletedError(err, service.UserID, "FindTranslations")
+		return nil, err
+	}
+
+	log.Completedf(service.UserID, "FindTranslations", "translations[%d]", len(translations))
+	return translations, nil
+}
+
+// SaveDraft stores the draft of a translation. The version must be the version
+// of the translation that was edited, or zero for a new translation.
+// ErrVersionConflict is returned if the translation has changed since.
+func SaveDraft(service *services.Service, localeID string, translationID string, content translationModels.TranslationContent, version int) (*translationModels.Translation, error) {
+	log.Startedf(service.UserID, "SaveDraft", "localeID[%s] translationID[%s] version[%d]", localeID, translationID, version)
+
+	if err := ValidateDraft(localeID, translationID, &content); err != nil {
+		log.CompletedError(err, service.UserID, "SaveDraft")
+		return nil, err
+	}
+	l := locale.MustNew(localeID)
+
+	now := time.Now().UTC()
+	var saved translationModels.Translation
+	f := func(collection *mgo.Collection) error {
+		if version == 0 {
+			saved = translationModels.Translation{
+				ID:            bson.NewObjectId(),
+				Locale:        l.ID,
+				TranslationID: translationID,
+				Version:       1,
+				Draft:         content,
+				UpdatedBy:     service.UserID,
+				UpdatedAt:     now,
+			}
+
+			log.Trace(service.UserID, "SaveDraft", "MGO : db.translations.insert(%s)", mongo.ToString(saved))
+			return collection.Insert(&saved)
+		}
+
+		queryMap := bson.M{"locale": l.ID, "translation_id": translationID, "version": version}
+		change := mgo.Change{
+			Update: bson.M{
+				"$set": bson.M{"draft": content, "updated_by": service.UserID, "updated_at": now},
+				"$inc": bson.M{"version": 1},
+			},
+			ReturnNew: true,
+		}
+
+		log.Trace(service.UserID, "SaveDraft", "MGO : db.translations.findAndModify(%s)", mongo.ToString(queryMap))
+		_, err := collection.Find(queryMap).Apply(change, &saved)
+		return err
+	}
+
+	if err := service.DBAction(Config.Database, translationsCollection, f, mongo.MajorityWrites); err != nil {
+		if err == mgo.ErrNotFound || mgo.IsDup(err) {
+			err = ErrVersionConflict
+		}
+		log.CompletedError(err, service.UserID, "SaveDraft")
+		return nil, err
+	}
+
+	log.Completedf(service.UserID, "SaveDraft", "version[%d]", saved.Version)
+	return &saved, nil
+}
+
+// Publish makes the draft of the specified version of a translation the published translation
+// and increments the revision so running instances reload the translations.
+// ErrVersionConflict is returned if the translation has changed since.
+func Publish(service *services.Service, localeID string, translationID string, version int) (*translationModels.Translation, error) {
+	log.Startedf(service.UserID, "Publish", "localeID[%s] translationID[%s] version[%d]", localeID, translationID, version)
+
+	l, err := locale.New(localeID)
+	if err != nil {
+		log.CompletedError(err, service.UserID, "Publish")
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var published translationModels.Translation
+	f := func(collection *mgo.Collection) error {
+		queryMap := bson.M{"locale": l.ID, "translation_id": translationID, "version": version}
+
+		log.Trace(service.UserID, "Publish", "MGO : db.translations.find(%s).limit(1)", mongo.ToString(queryMap))
+		if err := collection.Find(queryMap).One(&published); err != nil {
+			return err
+		}
+
+		published.Published = &published.Draft
+		published.PublishedVersion = published.Version
+		published.PublishedAt = &now
+		updateMap := bson.M{
+			"$set": bson.M{
+				"published":         published.Published,
+				"published_version": published.PublishedVersion,
+				"published_at":      published.PublishedAt,
+			},
+		}
+
+		// The version is part of the query so a concurrent edit is not published.
+		log.Trace(service.UserID, "Publish", "MGO : db.translations.update(%s, %s)", mongo.ToString(queryMap), mongo.ToString(updateMap))
+		return collection.Update(queryMap, updateMap)
+	}
+
+	if err := service.DBAction(Config.Database, translationsCollection, f, mongo.MajorityWrites); err != nil {
+		if err == mgo.ErrNotFound {
+			err = ErrVersionConflict
+		}
+		log.CompletedError(err, service.UserID, "Publish")
+		return nil, err
+	}
+
+	f = func(collection *mgo.Collection) error {
+		log.Trace(service.UserID, "Publish", "MGO : db.translation_revisions.update({_id: %q}, {$inc: {revision: 1}}, {upsert: true})", revisionID)
+		_, err := collection.UpsertId(revisionID, bson.M{"$inc": bson.M{"revision": 1}})
+		return err
+	}
+
+	if err := service.DBAction(Config.Database, revisionsCollection, f, mongo.MajorityWrites); err != nil {
+		log.CompletedError(err, service.UserID, "Publish")
+		return nil, err
+	}
+
+	log.Completedf(service.UserID, "Publish", "version[%d]", published.PublishedVersion)
+	return &published, nil
+}
+
+// FindRevision retrieves the revision of the published translations.
+func FindRevision(service *services.Service) (int, error) {
+	log.Started(service.UserID, "FindRevision")
+
+	var revision translationModels.TranslationRevision
+	f := func(collection *mgo.Collection) error {
+		log.Trace(service.UserID, "FindRevision", "MGO : db.translation_revisions.find({_id: %q}).limit(1)", revisionID)
+		return collection.FindId(revisionID).One(&revision)
+	}
+
+	if err := service.DBAction(Config.Database, revisionsCollection, f); err != nil {
+		if err != mgo.ErrNotFound {
+			log.CompletedError(err, service.UserID, "FindRevision")
+			return 0, err
+		}
+	}
+
+	log.Completedf(service.UserID, "FindRevision", "revision[%d]", revision.Revision)
+	return revision.Revision, nil
+}
+
+// Load replaces the stored translations of the localize package with the published translations.
+// Translations that are no longer valid are skipped.
+func Load(service *services.Service) error {
+	log.Started(service.UserID, "Load")
+
+	var documents []translationModels.Translation
+	f := func(collection *mgo.Collection) error {
+		queryMap := bson.M{"published": bson.M{"$exists": true}}
+
+		log.Trace(service.UserID, "Load", "MGO : db.translations.find(%s)", mongo.ToString(queryMap))
+		return collection.Find(queryMap).All(&documents)
+	}
+
+	if err := service.DBAction(Config.Database, translationsCollection, f); err != nil {
+		log.CompletedError(err, service.UserID, "Load")
+		return err
+	}
+
+	translations := make(map[string][]translation.Translation)
+	for _, document := range documents {
+		t, err := translation.NewTranslation(document.Published.Document(document.TranslationID))
+		if err != nil {
+			log.Warning(service.UserID, "Load", "Locale[%s] TranslationID[%s] : %v", document.Locale, document.TranslationID, err)
+			continue
+		}
+
+		translations[document.Locale] = append(translations[document.Locale], t)
+	}
+
+	if err := localize.SetTranslations(translations); err != nil {
+		log.CompletedError(err, service.UserID, "Load")
+		return err
+	}
+
+	log.Completedf(service.UserID, "Load", "translations[%d]", len(documents))
+	return nil
+}
+
+// StartWatcher loads the published translations and reloads them
+// whenever the revision changes, which is checked every Config.RefreshInterval.
+func StartWatcher(sessionID string) {
+	log.Startedf(sessionID, "StartWatcher", "RefreshInterval[%v]", Config.RefreshInterval)
+
+	refresh(sessionID)
+	go func() {
+		for range time.Tick(Config.RefreshInterval) {
+			refresh(sessionID)
+		}
+	}()
+
+	log.Completed(sessionID, "StartWatcher")
+}
+
+//** PRIVATE FUNCTIONS
+
+// refresh reloads the published translations if the revision has changed.
+func refresh(sessionID string) {
+	defer helper.CatchPanic(nil, sessionID, "refresh")
+
+	service := services.Service{UserID: sessionID}
+	if err := service.Prepare(); err != nil {
+		return
+	}
+	defer service.Finish()
+
+	revision, err := FindRevision(&service)
+	if err != nil || revision == loadedRevision {
+		return
+	}
+
+	if err := Load(&service); err != nil {
+		return
+	}
+	loadedRevision = revision
+}
@@ -13,6 +13,8 @@ $(document).ready(function() {
 	});
 	
 	LoadStationJson();
+	
+	StartLiveUpdates();
 });
 
 function Standard_Callback() {
@@ -110,3 +112,59 @@ function LoadStationJsonOwnTab() {
 	url = "/buoy/station/" + $('#station-names-json').val();
 	window.open(url);
 }
+
+function StartLiveUpdates() {
+	try {
+		var table = $('#stations-table');
+		var query = $.param({ region: table.attr('data-region'), lang: table.attr('data-lang') });
+		
+		// Server-Sent Events reconnect by themselves, WebSockets are the fallback
+		if (window.EventSource) {
+			var source = new EventSource("/buoy/events?" + query);
+			source.addEventListener('condition', function(e) {
+				UpdateStation(JSON.parse(e.data));
+			});
+			return;
+		}
+		
+		if (window.WebSocket) {
+			OpenStationSocket(query);
+		}
+	}
+	
+	catch (e) {
+		alert(e);
+	}
+}
+
+function OpenStationSocket(query) {
+	var scheme = window.location.protocol == "https:" ? "wss://" : "ws://";
+	var socket = new WebSocket(scheme + window.location.host + "/buoy/socket?" + query);
+	
+	socket.onmessage = function(e) {
+		UpdateStation(JSON.parse(e.data));
+	};
+	
+	socket.onclose = function() {
+		setTimeout(function() { OpenStationSocket(query); }, 5000);
+	};
+}
+
+function UpdateStation(update) {
+	var row = $('#stations-table tr[data-station="' + update.station_id + '"]');
+	if (row.length == 0) {
+		return;
+	}
+	
+	row.find('.wind-speed').text(update.display.wind_speed);
+	row.find('.wind-direction').text(update.display.wind_direction);
+	row.find('.wind-gust').text(update.display.gust_wind_speed);
+	
+	row.addClass('success');
+	setTimeout(function() { row.removeClass('success'); }, 2000);
+	
+	// Keep the JSON example current when it shows the station
+	if ($('#station-names-json').val() == update.station_id) {
+		LoadStationJson();
+	}
+}
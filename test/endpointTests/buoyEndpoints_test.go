@@ -8,6 +8,7 @@ package endpointTests
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"encoding/json"
@@ -118,3 +119,81 @@ func TestMissingStation(t *testing.T) {
 		})
 	})
 }
+
+// TestInvalidStationInBatch checks that a batch with an invalid
+// station id returns the validation error of the other endpoints
+func TestInvalidStationInBatch(t *testing.T) {
+	r, _ := http.NewRequest("POST", "/buoy/stations/batch", strings.NewReader(`{"station_ids": ["42002", "420"]}`))
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	log.Trace("testing", "TestInvalidStationInBatch", "Code[%d]\n%s", w.Code, w.Body.String())
+
+	var err struct {
+		Errors []string `json:"errors"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &err)
+
+	Convey("Subject: Test Stations Batch Endpoint\n", t, func() {
+		Convey("Status Code Should Be 409", func() {
+			So(w.Code, ShouldEqual, 409)
+		})
+		Convey("The Should Be An Error In The Result", func() {
+			So(len(err.Errors), ShouldEqual, 1)
+		})
+	})
+}
+
+// TestStationNotModified checks that a station is not sent again
+// when the client has the current version
+func TestStationNotModified(t *testing.T) {
+	r, _ := http.NewRequest("GET", "/buoy/station/42002", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	etag := w.Header().Get("ETag")
+
+	r, _ = http.NewRequest("GET", "/buoy/station/42002", nil)
+	r.Header.Set("If-None-Match", etag)
+	conditional := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(conditional, r)
+
+	log.Trace("testing", "TestStationNotModified", "ETag[%s] Code[%d]", etag, conditional.Code)
+
+	Convey("Subject: Test Station Endpoint Conditional GET\n", t, func() {
+		Convey("The Response Should Have An ETag", func() {
+			So(etag, ShouldNotBeBlank)
+		})
+		Convey("The Response Should Be Revalidated", func() {
+			So(w.Header().Get("Cache-Control"), ShouldEqual, "public, no-cache, max-age=0")
+		})
+		Convey("Status Code Should Be 304", func() {
+			So(conditional.Code, ShouldEqual, 304)
+		})
+		Convey("The Result Should Be Empty", func() {
+			So(conditional.Body.Len(), ShouldEqual, 0)
+		})
+	})
+}
+
+// TestExportRegion checks that the stations of a region are exported as csv
+func TestExportRegion(t *testing.T) {
+	r, _ := http.NewRequest("GET", "/buoy/region/Gulf%20Of%20Mexico/export?format=csv&columns=station_id,name", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	log.Trace("testing", "TestExportRegion", "Code[%d]\n%s", w.Code, w.Body.String())
+
+	Convey("Subject: Test Region Export Endpoint\n", t, func() {
+		Convey("Status Code Should Be 200", func() {
+			So(w.Code, ShouldEqual, 200)
+		})
+		Convey("The Result Should Be A CSV File", func() {
+			So(w.Header().Get("Content-Type"), ShouldStartWith, "text/csv")
+			So(w.Header().Get("Content-Disposition"), ShouldEqual, `attachment; filename="Gulf_Of_Mexico.csv"`)
+		})
+		Convey("The Result Should Start With The Columns", func() {
+			So(w.Body.String(), ShouldStartWith, "station_id,name\n")
+		})
+	})
+}
@@ -49,3 +49,26 @@ func Test_Region(t *testing.T) {
 		})
 	})
 }
+
+// Test_Stations checks the batch station service call is working
+func Test_Stations(t *testing.T) {
+	service := Prepare()
+	defer Finish(service)
+
+	stationIDs := []string{"42002", "000000", "42002"}
+
+	batch, err := buoyService.FindStations(service, stationIDs)
+
+	Convey("Subject: Test Stations Service", t, func() {
+		Convey("Should Be Able To Perform A Search", func() {
+			So(err, ShouldEqual, nil)
+		})
+		Convey("Should Have Station Data Once", func() {
+			So(len(batch.Stations), ShouldEqual, 1)
+			So(batch.Stations[0].StationID, ShouldEqual, "42002")
+		})
+		Convey("Should Report The Unknown Station", func() {
+			So(batch.Unknown, ShouldResemble, []string{"000000"})
+		})
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE handle.
+
+// Package serviceTests implements tests for the translation services.
+package serviceTests
+
+import (
+	"testing"
+
+	"github.com/goinggo/beego-mgo/models/translationModels"
+	"github.com/goinggo/beego-mgo/services/translationService"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// Test_Translations checks the translations can be retrieved and loaded
+func Test_Translations(t *testing.T) {
+	service := Prepare()
+	defer Finish(service)
+
+	translations, findErr := translationService.FindTranslations(service, "en-US")
+	_, revisionErr := translationService.FindRevision(service)
+	loadErr := translationService.Load(service)
+
+	Convey("Subject: Test Translation Service", t, func() {
+		Convey("Should Be Able To Perform A Search", func() {
+			So(findErr, ShouldEqual, nil)
+		})
+		Convey("Should Only Have Translations For The Locale", func() {
+			for _, translation := range translations {
+				So(translation.Locale, ShouldEqual, "en-US")
+			}
+		})
+		Convey("Should Be Able To Find The Revision", func() {
+			So(revisionErr, ShouldEqual, nil)
+		})
+		Convey("Should Be Able To Load The Published Translations", func() {
+			So(loadErr, ShouldEqual, nil)
+		})
+	})
+}
+
+// Test_ValidateDraft checks drafts are validated before they are stored
+func Test_ValidateDraft(t *testing.T) {
+	text := translationModels.TranslationContent{Text: "Hello {{.Person}}"}
+	plural := translationModels.TranslationContent{Plural: map[string]string{"one": "{{.Count}} buoy", "other": "{{.Count}} buoys"}}
+	invalidTemplate := translationModels.TranslationContent{Text: "Hello {{.Person"}
+	invalidCategory := translationModels.TranslationContent{Plural: map[string]string{"some": "{{.Count}} buoys"}}
+
+	Convey("Subject: Test Draft Validation", t, func() {
+		Convey("Should Accept Valid Translations", func() {
+			So(translationService.ValidateDraft("fr-FR", "person_greeting", &text), ShouldEqual, nil)
+			So(translationService.ValidateDraft("fr-FR", "buoys", &plural), ShouldEqual, nil)
+		})
+		Convey("Should Reject Invalid Translations", func() {
+			So(translationService.ValidateDraft("fr-FR", "person_greeting", &invalidTemplate), ShouldNotEqual, nil)
+			So(translationService.ValidateDraft("fr-FR", "buoys", &invalidCategory), ShouldNotEqual, nil)
+		})
+		Convey("Should Reject Invalid Locales And Ids", func() {
+			So(translationService.ValidateDraft("invalid", "person_greeting", &text), ShouldNotEqual, nil)
+			So(translationService.ValidateDraft("fr-FR", "", &text), ShouldNotEqual, nil)
+		})
+	})
+}
@@ -0,0 +1,279 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cache implements a read-through cache with an in-process LRU of entries
+// that expire, an optional shared backend and coalescing of concurrent misses.
+package cache
+
+import (
+	"container/list"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+)
+
+//** TYPES
+
+type (
+	// LoadFunc loads the value of a key on a miss. It returns ErrNotFound
+	// if the key has no value.
+	LoadFunc func() (interface{}, error)
+
+	// Backend is a cache shared by the processes, e.g. memcached or redis. The
+	// backend encodes the values itself. The in-process entries are checked first.
+	Backend interface {
+		Get(key string) (value interface{}, found bool, err error)
+		Set(key string, value interface{}, ttl time.Duration) error
+		Delete(key string) error
+	}
+
+	// Stats counts how the values were found.
+	Stats struct {
+		Hits        uint64 `json:"hits"`
+		Misses      uint64 `json:"misses"`
+		BackendHits uint64 `json:"backend_hits"`
+		Coalesced   uint64 `json:"coalesced"`
+		NotFound    uint64 `json:"not_found"`
+		Evictions   uint64 `json:"evictions"`
+		Expirations uint64 `json:"expirations"`
+		Errors      uint64 `json:"errors"`
+		Entries     int    `json:"entries"`
+	}
+
+	// Cache is a read-through cache of at most capacity entries, which expire after ttl.
+	// Keys without a value expire after the shorter notFoundTTL, so a value that is
+	// created later is soon found.
+	Cache struct {
+		capacity    int
+		ttl         time.Duration
+		notFoundTTL time.Duration
+		backend     Backend
+
+		lock    sync.Mutex
+		entries map[string]*list.Element
+		lru     *list.List
+		flights map[string]*flight
+
+		stats Stats
+	}
+
+	// entry is a cached value, or ErrNotFound for a key without a value.
+	entry struct {
+		key     string
+		value   interface{}
+		err     error
+		expires time.Time
+	}
+
+	// flight is a load in progress that concurrent misses of the key wait for.
+	flight struct {
+		done  chan struct{}
+		value interface{}
+		err   error
+
+		// stale is set when the key is deleted during the load, so the value is not stored.
+		stale bool
+	}
+)
+
+//** PACKAGE VARIABLES
+
+// now returns the current time, tests replace it.
+var now = time.Now
+
+// ErrNotFound is returned for a key without a value. It is only cached in process
+// for the not found ttl and never shared through the backend.
+var ErrNotFound = fmt.Errorf("Not Found")
+
+//** PUBLIC FUNCTIONS
+
+// New creates a cache and publishes its stats with expvar under the name, if it is not empty.
+// Keys without a value are not cached when notFoundTTL is 0.
+func New(name string, capacity int, ttl time.Duration, notFoundTTL time.Duration) *Cache {
+	cache := Cache{
+		capacity:    capacity,
+		ttl:         ttl,
+		notFoundTTL: notFoundTTL,
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+		flights:     make(map[string]*flight),
+	}
+
+	if name != "" {
+		expvar.Publish(name, expvar.Func(func() interface{} { return cache.Stats() }))
+	}
+
+	return &cache
+}
+
+// SetBackend shares the entries with other processes through the backend.
+func (cache *Cache) SetBackend(backend Backend) {
+	cache.lock.Lock()
+	cache.backend = backend
+	cache.lock.Unlock()
+}
+
+// Get returns the value of the key, loading it on a miss. Concurrent misses of
+// the same key wait for a single load. Errors are returned and not cached,
+// except ErrNotFound.
+func (cache *Cache) Get(key string, load LoadFunc) (interface{}, error) {
+	cache.lock.Lock()
+	if e, ok := cache.lookup(key); ok {
+		cache.stats.Hits++
+		cache.lock.Unlock()
+		return e.value, e.err
+	}
+
+	if f, ok := cache.flights[key]; ok {
+		cache.stats.Coalesced++
+		cache.lock.Unlock()
+		<-f.done
+		return f.value, f.err
+	}
+
+	cache.stats.Misses++
+	f := flight{done: make(chan struct{})}
+	cache.flights[key] = &f
+	backend := cache.backend
+	cache.lock.Unlock()
+
+	fromBackend := false
+	if backend != nil {
+		value, found, err := backend.Get(key)
+		switch {
+		case err != nil:
+			cache.countError()
+		case found:
+			f.value = value
+			fromBackend = true
+		}
+	}
+
+	if fromBackend == false {
+		f.value, f.err = cache.load(key, &f, load)
+	}
+
+	cache.lock.Lock()
+	delete(cache.flights, key)
+	switch {
+	case f.stale:
+	case f.err == nil:
+		if fromBackend {
+			cache.stats.BackendHits++
+		}
+		cache.store(key, f.value, nil, cache.ttl)
+	case f.err == ErrNotFound:
+		cache.stats.NotFound++
+		if cache.notFoundTTL > 0 {
+			cache.store(key, nil, ErrNotFound, cache.notFoundTTL)
+		}
+	}
+	cache.lock.Unlock()
+
+	if backend != nil && fromBackend == false && f.err == nil && f.stale == false {
+		if err := backend.Set(key, f.value, cache.ttl); err != nil {
+			cache.countError()
+		}
+	}
+
+	close(f.done)
+	return f.value, f.err
+}
+
+// Delete invalidates the key, e.g. after its value was written. A load of the key
+// in progress is still returned to its callers but not stored.
+func (cache *Cache) Delete(key string) {
+	cache.lock.Lock()
+	if element, ok := cache.entries[key]; ok {
+		cache.remove(element)
+	}
+	if f, ok := cache.flights[key]; ok {
+		f.stale = true
+	}
+	backend := cache.backend
+	cache.lock.Unlock()
+
+	if backend != nil {
+		if err := backend.Delete(key); err != nil {
+			cache.countError()
+		}
+	}
+}
+
+// Stats returns how the values were found so far.
+func (cache *Cache) Stats() Stats {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	stats := cache.stats
+	stats.Entries = cache.lru.Len()
+	return stats
+}
+
+//** PRIVATE FUNCTIONS
+
+// load calls the load function. If it panics, the callers waiting for the
+// flight are released with an error before the panic continues.
+func (cache *Cache) load(key string, f *flight, load LoadFunc) (interface{}, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			cache.lock.Lock()
+			delete(cache.flights, key)
+			cache.lock.Unlock()
+
+			f.err = fmt.Errorf("Cache Load Of %s Panicked : %v", key, r)
+			close(f.done)
+			panic(r)
+		}
+	}()
+
+	return load()
+}
+
+// countError counts an error of the backend.
+func (cache *Cache) countError() {
+	cache.lock.Lock()
+	cache.stats.Errors++
+	cache.lock.Unlock()
+}
+
+// lookup returns the entry of the key if it has not expired and marks it as recently used.
+func (cache *Cache) lookup(key string) (*entry, bool) {
+	element, ok := cache.entries[key]
+	if ok == false {
+		return nil, false
+	}
+
+	e := element.Value.(*entry)
+	if now().After(e.expires) {
+		cache.stats.Expirations++
+		cache.remove(element)
+		return nil, false
+	}
+
+	cache.lru.MoveToFront(element)
+	return e, true
+}
+
+// store adds the value, or the error of a key without a value, which expires after ttl
+// and evicts the least recently used entry when the cache is full.
+func (cache *Cache) store(key string, value interface{}, err error, ttl time.Duration) {
+	if element, ok := cache.entries[key]; ok {
+		cache.remove(element)
+	}
+
+	cache.entries[key] = cache.lru.PushFront(&entry{key: key, value: value, err: err, expires: now().Add(ttl)})
+
+	for cache.lru.Len() > cache.capacity {
+		cache.stats.Evictions++
+		cache.remove(cache.lru.Back())
+	}
+}
+
+// remove removes the entry of the element.
+func (cache *Cache) remove(element *list.Element) {
+	cache.lru.Remove(element)
+	delete(cache.entries, element.Value.(*entry).key)
+}
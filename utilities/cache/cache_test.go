@@ -0,0 +1,204 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryBackend is a shared backend for the tests.
+type memoryBackend map[string]interface{}
+
+func (b memoryBackend) Get(key string) (interface{}, bool, error) {
+	value, ok := b[key]
+	return value, ok, nil
+}
+
+func (b memoryBackend) Set(key string, value interface{}, ttl time.Duration) error {
+	b[key] = value
+	return nil
+}
+
+func (b memoryBackend) Delete(key string) error {
+	delete(b, key)
+	return nil
+}
+
+// loader returns a load function that counts its calls.
+func loader(value interface{}, calls *int) LoadFunc {
+	return func() (interface{}, error) {
+		*calls++
+		return value, nil
+	}
+}
+
+func TestHitsAndEvictions(t *testing.T) {
+	cache := New("", 2, time.Minute, 0)
+	var calls int
+
+	cache.Get("42001", loader("a", &calls))
+	cache.Get("42002", loader("b", &calls))
+	if value, _ := cache.Get("42001", loader("x", &calls)); value != "a" {
+		t.Errorf("Get(42001) = %v; expected the cached a", value)
+	}
+
+	// 42002 is the least recently used.
+	cache.Get("42003", loader("c", &calls))
+	if value, _ := cache.Get("42002", loader("b2", &calls)); value != "b2" {
+		t.Errorf("Get(42002) = %v; expected the evicted value to be loaded again", value)
+	}
+
+	stats := cache.Stats()
+	if calls != 4 || stats.Hits != 1 || stats.Misses != 4 || stats.Evictions != 2 || stats.Entries != 2 {
+		t.Errorf("calls = %d stats = %+v", calls, stats)
+	}
+}
+
+func TestExpiration(t *testing.T) {
+	defer func() { now = time.Now }()
+	start := time.Now()
+	now = func() time.Time { return start }
+
+	cache := New("", 10, time.Minute, 0)
+	var calls int
+
+	cache.Get("42001", loader("a", &calls))
+	now = func() time.Time { return start.Add(2 * time.Minute) }
+	cache.Get("42001", loader("b", &calls))
+
+	if stats := cache.Stats(); calls != 2 || stats.Expirations != 1 {
+		t.Errorf("calls = %d stats = %+v; expected the entry to expire", calls, stats)
+	}
+}
+
+func TestErrorsAreNotCached(t *testing.T) {
+	cache := New("", 10, time.Minute, 0)
+
+	_, err := cache.Get("42001", func() (interface{}, error) { return nil, fmt.Errorf("Unreachable") })
+	if err == nil {
+		t.Fatal("Get() expected the error of the load")
+	}
+
+	var calls int
+	if value, err := cache.Get("42001", loader("a", &calls)); err != nil || value != "a" || calls != 1 {
+		t.Errorf("Get() = %v, %v; expected the value to be loaded after an error", value, err)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	defer func() { now = time.Now }()
+	start := time.Now()
+	now = func() time.Time { return start }
+
+	cache := New("", 10, time.Minute, 5*time.Second)
+	var calls int
+	notFound := func() (interface{}, error) {
+		calls++
+		return nil, ErrNotFound
+	}
+
+	cache.Get("42001", notFound)
+	if _, err := cache.Get("42001", notFound); err != ErrNotFound || calls != 1 {
+		t.Errorf("Get() = %v calls = %d; expected the cached ErrNotFound", err, calls)
+	}
+
+	// The station was inserted, it is found once the short ttl expired.
+	now = func() time.Time { return start.Add(10 * time.Second) }
+	if value, err := cache.Get("42001", loader("a", &calls)); err != nil || value != "a" || calls != 2 {
+		t.Errorf("Get() = %v, %v calls = %d; expected the value after the not found ttl", value, err, calls)
+	}
+	if stats := cache.Stats(); stats.NotFound != 1 || stats.Hits != 1 {
+		t.Errorf("stats = %+v; expected a not found load and a hit", stats)
+	}
+
+	// Without a not found ttl every lookup loads.
+	cache = New("", 10, time.Minute, 0)
+	calls = 0
+	cache.Get("42001", notFound)
+	cache.Get("42001", notFound)
+	if calls != 2 {
+		t.Errorf("calls = %d; expected ErrNotFound not to be cached", calls)
+	}
+}
+
+func TestCoalescing(t *testing.T) {
+	cache := New("", 10, time.Minute, 0)
+
+	release := make(chan struct{})
+	var lock sync.Mutex
+	var calls int
+	load := func() (interface{}, error) {
+		<-release
+		lock.Lock()
+		calls++
+		lock.Unlock()
+		return "a", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if value, _ := cache.Get("42001", load); value != "a" {
+				t.Errorf("Get() = %v", value)
+			}
+		}()
+	}
+
+	// Wait until all of the callers wait for the load.
+	for {
+		stats := cache.Stats()
+		if stats.Misses+stats.Coalesced == 10 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if stats := cache.Stats(); calls != 1 || stats.Coalesced != 9 {
+		t.Errorf("calls = %d stats = %+v; expected a single load", calls, stats)
+	}
+}
+
+func TestDeleteDuringLoad(t *testing.T) {
+	cache := New("", 10, time.Minute, 0)
+	var calls int
+
+	cache.Get("42001", func() (interface{}, error) {
+		cache.Delete("42001")
+		return "stale", nil
+	})
+
+	if value, _ := cache.Get("42001", loader("fresh", &calls)); value != "fresh" {
+		t.Errorf("Get() = %v; expected the value loaded during the delete not to be stored", value)
+	}
+}
+
+func TestBackend(t *testing.T) {
+	backend := memoryBackend{}
+	first := New("", 10, time.Minute, 0)
+	first.SetBackend(backend)
+	second := New("", 10, time.Minute, 0)
+	second.SetBackend(backend)
+
+	var calls int
+	first.Get("42001", loader("a", &calls))
+	if value, _ := second.Get("42001", loader("x", &calls)); value != "a" || calls != 1 {
+		t.Errorf("Get() = %v; expected the value of the backend", value)
+	}
+	if stats := second.Stats(); stats.BackendHits != 1 {
+		t.Errorf("stats = %+v; expected a backend hit", stats)
+	}
+
+	first.Delete("42001")
+	if _, ok := backend["42001"]; ok {
+		t.Error("Delete() expected the key to be deleted from the backend")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package httpcache implements the HTTP caching headers and the
+// conditional requests of RFC 7232 for the JSON API.
+package httpcache
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//** TYPES
+
+// Policy describes the Cache-Control header of a route.
+type Policy struct {
+	// MaxAge is how long a response may be used without asking the server.
+	MaxAge time.Duration
+
+	// Private responses may only be cached by the browser, e.g. when they depend on the locale.
+	Private bool
+
+	// Revalidate makes the cache ask the server every time, which answers
+	// with 304 Not Modified if the response has not changed.
+	Revalidate bool
+
+	// NoStore responses must not be cached at all.
+	NoStore bool
+}
+
+//** PACKAGE VARIABLES
+
+var (
+	// NoStore is the policy of responses that must never be cached, e.g. admin data.
+	NoStore = Policy{NoStore: true}
+
+	// Revalidate is the policy of responses that change often and are polled.
+	Revalidate = Policy{Revalidate: true}
+)
+
+//** PUBLIC FUNCTIONS
+
+// String returns the Cache-Control header of the policy.
+func (policy Policy) String() string {
+	if policy.NoStore {
+		return "no-store"
+	}
+
+	directives := []string{"public"}
+	if policy.Private {
+		directives[0] = "private"
+	}
+	if policy.Revalidate {
+		directives = append(directives, "no-cache")
+	}
+	directives = append(directives, "max-age="+strconv.Itoa(int(policy.MaxAge/time.Second)))
+
+	return strings.Join(directives, ", ")
+}
+
+// ETag returns a strong entity tag of the response body.
+func ETag(body []byte) string {
+	hash := sha1.Sum(body)
+	return `"` + hex.EncodeToString(hash[:16]) + `"`
+}
+
+// NotModified checks the If-None-Match and If-Modified-Since headers of a GET or HEAD
+// request against the entity tag and modification time of the response. If-Modified-Since
+// is ignored when If-None-Match is present, and lastModified is ignored when it is zero.
+func NotModified(request *http.Request, etag string, lastModified time.Time) bool {
+	if request.Method != "GET" && request.Method != "HEAD" {
+		return false
+	}
+
+	if ifNoneMatch := request.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return matchETag(ifNoneMatch, etag)
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(request.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	// The header has a resolution of seconds.
+	return lastModified.Truncate(time.Second).After(ifModifiedSince) == false
+}
+
+//** PRIVATE FUNCTIONS
+
+// matchETag checks if the list of entity tags of an If-None-Match header
+// contains the entity tag, using the weak comparison.
+func matchETag(ifNoneMatch string, etag string) bool {
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
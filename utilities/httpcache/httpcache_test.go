@@ -0,0 +1,85 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpcache
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPolicy(t *testing.T) {
+	tests := []struct {
+		policy   Policy
+		expected string
+	}{
+		{NoStore, "no-store"},
+		{Revalidate, "public, no-cache, max-age=0"},
+		{Policy{MaxAge: time.Minute, Private: true}, "private, max-age=60"},
+	}
+
+	for _, test := range tests {
+		if header := test.policy.String(); header != test.expected {
+			t.Errorf("%+v String() = %q; expected %q", test.policy, header, test.expected)
+		}
+	}
+}
+
+func TestETag(t *testing.T) {
+	first := ETag([]byte(`{"station_id":"42002"}`))
+	if first != ETag([]byte(`{"station_id":"42002"}`)) {
+		t.Error("ETag() expected the same tag for the same body")
+	}
+	if first == ETag([]byte(`{"station_id":"42001"}`)) {
+		t.Error("ETag() expected a different tag for a different body")
+	}
+	if len(first) != 34 || first[0] != '"' {
+		t.Errorf("ETag() = %s; expected a quoted strong tag", first)
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	modified := time.Date(2014, 3, 1, 12, 30, 15, 500, time.UTC)
+	etag := `"abc"`
+
+	tests := []struct {
+		method          string
+		ifNoneMatch     string
+		ifModifiedSince string
+		expected        bool
+	}{
+		{"GET", "", "", false},
+		{"GET", `"abc"`, "", true},
+		{"GET", `"xyz", W/"abc"`, "", true},
+		{"GET", "*", "", true},
+		{"GET", `"xyz"`, "Sat, 01 Mar 2014 12:30:15 GMT", false},
+		{"GET", "", "Sat, 01 Mar 2014 12:30:15 GMT", true},
+		{"GET", "", "Sat, 01 Mar 2014 12:30:14 GMT", false},
+		{"GET", "", "not a date", false},
+		{"HEAD", `"abc"`, "", true},
+		{"POST", `"abc"`, "", false},
+	}
+
+	for _, test := range tests {
+		request, _ := http.NewRequest(test.method, "/buoy/station/42002", nil)
+		if test.ifNoneMatch != "" {
+			request.Header.Set("If-None-Match", test.ifNoneMatch)
+		}
+		if test.ifModifiedSince != "" {
+			request.Header.Set("If-Modified-Since", test.ifModifiedSince)
+		}
+
+		if notModified := NotModified(request, etag, modified); notModified != test.expected {
+			t.Errorf("%s If-None-Match[%s] If-Modified-Since[%s] NotModified() = %v; expected %v",
+				test.method, test.ifNoneMatch, test.ifModifiedSince, notModified, test.expected)
+		}
+	}
+
+	request, _ := http.NewRequest("GET", "/buoy/station/42002", nil)
+	request.Header.Set("If-Modified-Since", "Sat, 01 Mar 2014 12:30:15 GMT")
+	if NotModified(request, etag, time.Time{}) {
+		t.Error("NotModified() expected false without a modification time")
+	}
+}
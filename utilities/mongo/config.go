@@ -0,0 +1,223 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mongo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+const (
+	// defaultTimeout is used when no dial timeout is configured.
+	defaultTimeout = 60 * time.Second
+
+	// mechanismX509 authenticates with the subject of the client certificate.
+	mechanismX509 = "MONGODB-X509"
+)
+
+// mechanisms are the authentication mechanisms mgo supports.
+var mechanisms = map[string]bool{
+	"SCRAM-SHA-1": true,
+	"MONGODB-CR":  true,
+	mechanismX509: true,
+	"PLAIN":       true,
+	"GSSAPI":      true,
+}
+
+type (
+	// mongoConfiguration contains settings for initialization.
+	mongoConfiguration struct {
+		// URI is a mongodb:// connection string, which overrides the settings below.
+		URI string
+
+		Hosts    string
+		Database string
+		UserName string
+		Password string
+
+		// AuthSource is the database the user is defined in, defaulting to Database.
+		AuthSource string
+		// Mechanism is the authentication mechanism (e.g. SCRAM-SHA-1 or MONGODB-X509).
+		Mechanism string
+		// ReplicaSet is the name of the replica set the hosts must belong to.
+		ReplicaSet string
+		// Direct connects to the hosts only instead of discovering the cluster.
+		Direct bool
+		// PoolLimit is the maximum number of sockets per server, zero being the mgo default.
+		PoolLimit int
+
+		// Timeout is the dial timeout, SocketTimeout the timeout of socket reads and writes
+		// and SyncTimeout how long to wait for a server to become available.
+		Timeout       time.Duration
+		SocketTimeout time.Duration
+		SyncTimeout   time.Duration
+
+		// TLS dials the servers over TLS, verifying them against TLSCAFile or the system roots.
+		// TLSCertFile and TLSKeyFile contain the client certificate for X.509 authentication.
+		TLS                   bool
+		TLSCAFile             string
+		TLSCertFile           string
+		TLSKeyFile            string
+		TLSInsecureSkipVerify bool
+
+		// ReadPreference is the read preference of the monotonic session (e.g. secondaryPreferred).
+		ReadPreference string
+		// W is the write concern, a number of servers or a mode such as majority, WTimeout
+		// how long to wait for it and Journal whether writes must be journaled.
+		W        string
+		WTimeout time.Duration
+		Journal  bool
+	}
+)
+
+// DialInfo builds the dial information for the configuration.
+func (config *mongoConfiguration) DialInfo() (*mgo.DialInfo, error) {
+	hosts := strings.Split(config.Hosts, ",")
+	for i := range hosts {
+		hosts[i] = strings.TrimSpace(hosts[i])
+		if hosts[i] == "" {
+			return nil, fmt.Errorf("Invalid Hosts %q", config.Hosts)
+		}
+	}
+
+	if config.Mechanism != "" && mechanisms[config.Mechanism] == false {
+		return nil, fmt.Errorf("Unsupported Auth Mechanism %s", config.Mechanism)
+	}
+
+	if config.PoolLimit < 0 {
+		return nil, fmt.Errorf("Invalid Pool Limit %d", config.PoolLimit)
+	}
+
+	if _, err := config.readMode(); err != nil {
+		return nil, err
+	}
+
+	if _, err := config.safe(); err != nil {
+		return nil, err
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	dialInfo := mgo.DialInfo{
+		Addrs:          hosts,
+		Direct:         config.Direct,
+		Timeout:        timeout,
+		Database:       config.Database,
+		ReplicaSetName: config.ReplicaSet,
+		Source:         config.AuthSource,
+		Mechanism:      config.Mechanism,
+		Username:       config.UserName,
+		Password:       config.Password,
+		PoolLimit:      config.PoolLimit,
+	}
+
+	if config.TLS == false {
+		if config.Mechanism == mechanismX509 {
+			return nil, fmt.Errorf("Auth Mechanism %s Requires TLS", mechanismX509)
+		}
+		if config.TLSCAFile != "" || config.TLSCertFile != "" {
+			return nil, fmt.Errorf("TLS Files Are Configured But TLS Is Disabled")
+		}
+
+		return &dialInfo, nil
+	}
+
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	dialInfo.DialServer = func(addr *mgo.ServerAddr) (net.Conn, error) {
+		dialer := net.Dialer{Timeout: timeout}
+		return tls.DialWithDialer(&dialer, "tcp", addr.String(), tlsConfig)
+	}
+
+	if config.Mechanism == mechanismX509 {
+		if len(tlsConfig.Certificates) == 0 {
+			return nil, fmt.Errorf("Auth Mechanism %s Requires A TLS Client Certificate", mechanismX509)
+		}
+
+		// The user is the subject of the client certificate and is defined in $external.
+		if dialInfo.Username == "" {
+			certificate, err := x509.ParseCertificate(tlsConfig.Certificates[0].Certificate[0])
+			if err != nil {
+				return nil, fmt.Errorf("Unable To Parse TLS Client Certificate : %v", err)
+			}
+			dialInfo.Username = certificate.Subject.String()
+		}
+		dialInfo.Password = ""
+		if dialInfo.Source == "" {
+			dialInfo.Source = "$external"
+		}
+	}
+
+	return &dialInfo, nil
+}
+
+// tlsConfig loads the certificate authority and client certificate files of the configuration.
+func (config *mongoConfiguration) tlsConfig() (*tls.Config, error) {
+	tlsConfig := tls.Config{
+		InsecureSkipVerify: config.TLSInsecureSkipVerify,
+	}
+
+	if config.TLSCAFile != "" {
+		pem, err := ioutil.ReadFile(config.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("Unable To Read TLS CA File : %v", err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if tlsConfig.RootCAs.AppendCertsFromPEM(pem) == false {
+			return nil, fmt.Errorf("No Certificates Found In TLS CA File %s", config.TLSCAFile)
+		}
+	}
+
+	if config.TLSCertFile != "" {
+		// The key may be in the certificate file, as is common for MongoDB client certificates.
+		keyFile := config.TLSKeyFile
+		if keyFile == "" {
+			keyFile = config.TLSCertFile
+		}
+
+		certificate, err := tls.LoadX509KeyPair(config.TLSCertFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Unable To Load TLS Client Certificate : %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return &tlsConfig, nil
+}
+
+// readMode returns the mode of the read preference, or Monotonic if none is configured.
+func (config *mongoConfiguration) readMode() (mgo.Mode, error) {
+	if config.ReadPreference == "" {
+		return mgo.Monotonic, nil
+	}
+
+	return readMode(config.ReadPreference)
+}
+
+// safe returns the safety mode of the write concern. Writes are acknowledged by
+// the primary unless another write concern is configured, and w=0 makes them unsafe.
+func (config *mongoConfiguration) safe() (*mgo.Safe, error) {
+	writeConcern := WriteConcern{
+		W:        config.W,
+		WTimeout: config.WTimeout,
+		Journal:  config.Journal,
+	}
+
+	return writeConcern.safe()
+}
@@ -0,0 +1,141 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mongo
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestDialInfo checks the configuration is turned into dial information.
+func TestDialInfo(t *testing.T) {
+	config := mongoConfiguration{
+		Hosts:      "db1:27017, db2:27017",
+		Database:   "goinggo",
+		UserName:   "guest",
+		Password:   "welcome",
+		AuthSource: "admin",
+		Mechanism:  "SCRAM-SHA-1",
+		ReplicaSet: "rs0",
+		PoolLimit:  16,
+	}
+
+	dialInfo, err := config.DialInfo()
+	if err != nil {
+		t.Fatalf("DialInfo returned error %v", err)
+	}
+
+	if len(dialInfo.Addrs) != 2 || dialInfo.Addrs[0] != "db1:27017" || dialInfo.Addrs[1] != "db2:27017" {
+		t.Errorf("Addrs is %v; expected [db1:27017 db2:27017]", dialInfo.Addrs)
+	}
+	if dialInfo.Source != "admin" || dialInfo.Mechanism != "SCRAM-SHA-1" || dialInfo.ReplicaSetName != "rs0" || dialInfo.PoolLimit != 16 {
+		t.Errorf("DialInfo is %+v; expected the configured options", dialInfo)
+	}
+	if dialInfo.Timeout != defaultTimeout {
+		t.Errorf("Timeout is %v; expected %v", dialInfo.Timeout, defaultTimeout)
+	}
+	if dialInfo.DialServer != nil {
+		t.Errorf("DialServer is set without TLS")
+	}
+}
+
+// TestDialInfoErrors checks invalid configurations are reported.
+func TestDialInfoErrors(t *testing.T) {
+	tests := []mongoConfiguration{
+		{Hosts: ""},
+		{Hosts: "db1:27017,"},
+		{Hosts: "db1:27017", Mechanism: "SCRAM-SHA-256"},
+		{Hosts: "db1:27017", PoolLimit: -1},
+		{Hosts: "db1:27017", Mechanism: "MONGODB-X509"},
+		{Hosts: "db1:27017", TLSCAFile: "ca.pem"},
+		{Hosts: "db1:27017", TLS: true, TLSCAFile: "missing.pem"},
+		{Hosts: "db1:27017", TLS: true, Mechanism: "MONGODB-X509"},
+	}
+
+	for _, config := range tests {
+		if _, err := config.DialInfo(); err == nil {
+			t.Errorf("DialInfo of %+v returned nil; expected error", config)
+		}
+	}
+}
+
+// TestDialInfoX509 checks the user of X.509 authentication is the subject of the client certificate.
+func TestDialInfoX509(t *testing.T) {
+	directory, err := ioutil.TempDir("", "mongo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	certFile := filepath.Join(directory, "client.pem")
+	writeCertificate(t, certFile, "buoy-client")
+
+	config := mongoConfiguration{
+		Hosts:       "db1:27017",
+		Mechanism:   "MONGODB-X509",
+		TLS:         true,
+		TLSCAFile:   certFile,
+		TLSCertFile: certFile,
+		Timeout:     5 * time.Second,
+	}
+
+	dialInfo, err := config.DialInfo()
+	if err != nil {
+		t.Fatalf("DialInfo returned error %v", err)
+	}
+
+	if dialInfo.Username != "CN=buoy-client,O=Ardan Studios" {
+		t.Errorf("Username is %q; expected the certificate subject", dialInfo.Username)
+	}
+	if dialInfo.Source != "$external" {
+		t.Errorf("Source is %q; expected $external", dialInfo.Source)
+	}
+	if dialInfo.DialServer == nil || dialInfo.Timeout != 5*time.Second {
+		t.Errorf("DialInfo is %+v; expected a TLS dialer with a 5s timeout", dialInfo)
+	}
+}
+
+// writeCertificate writes a self-signed certificate and its key to a PEM file.
+func writeCertificate(t *testing.T, fileName string, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"Ardan Studios"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})...)
+	if err := ioutil.WriteFile(fileName, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
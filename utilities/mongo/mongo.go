@@ -8,8 +8,6 @@ package mongo
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
-	"time"
 
 	log "github.com/goinggo/tracelog"
 	"github.com/kelseyhightower/envconfig"
@@ -31,14 +29,6 @@ var (
 )
 
 type (
-	// mongoConfiguration contains settings for initialization.
-	mongoConfiguration struct {
-		Hosts    string
-		Database string
-		UserName string
-		Password string
-	}
-
 	// mongoManager contains dial and session information.
 	mongoSession struct {
 		mongoDBDialInfo *mgo.DialInfo
@@ -47,6 +37,7 @@ type (
 
 	// mongoManager manages a map of session.
 	mongoManager struct {
+		config   mongoConfiguration
 		sessions map[string]mongoSession
 	}
 
@@ -71,26 +62,41 @@ func Startup(sessionID string) error {
 		return err
 	}
 
+	// Apply the connection string, which overrides the individual settings.
+	if err := config.ApplyURI(); err != nil {
+		log.CompletedError(err, sessionID, "Startup")
+		return err
+	}
+
+	// Build the dial information, which validates the configuration.
+	dialInfo, err := config.DialInfo()
+	if err != nil {
+		log.CompletedError(err, sessionID, "Startup")
+		return err
+	}
+
 	// Create the Mongo Manager.
 	singleton = mongoManager{
+		config:   config,
 		sessions: make(map[string]mongoSession),
 	}
 
 	// Log the mongodb connection straps.
 	log.Trace(sessionID, "Startup", "MongoDB : Hosts[%s]", config.Hosts)
 	log.Trace(sessionID, "Startup", "MongoDB : Database[%s]", config.Database)
-	log.Trace(sessionID, "Startup", "MongoDB : Username[%s]", config.UserName)
-
-	hosts := strings.Split(config.Hosts, ",")
+	log.Trace(sessionID, "Startup", "MongoDB : Username[%s]", dialInfo.Username)
+	log.Trace(sessionID, "Startup", "MongoDB : AuthSource[%s] Mechanism[%s] ReplicaSet[%s]", dialInfo.Source, dialInfo.Mechanism, dialInfo.ReplicaSetName)
+	log.Trace(sessionID, "Startup", "MongoDB : TLS[%v] Direct[%v] PoolLimit[%d]", config.TLS, dialInfo.Direct, dialInfo.PoolLimit)
+	log.Trace(sessionID, "Startup", "MongoDB : ReadPreference[%s] W[%s] WTimeout[%v] Journal[%v]", config.ReadPreference, config.W, config.WTimeout, config.Journal)
 
 	// Create the strong session.
-	if err := CreateSession(sessionID, "strong", MasterSession, hosts, config.Database, config.UserName, config.Password); err != nil {
+	if err := CreateSession(sessionID, "strong", MasterSession, dialInfo); err != nil {
 		log.CompletedError(err, sessionID, "Startup")
 		return err
 	}
 
 	// Create the monotonic session.
-	if err := CreateSession(sessionID, "monotonic", MonotonicSession, hosts, config.Database, config.UserName, config.Password); err != nil {
+	if err := CreateSession(sessionID, "monotonic", MonotonicSession, dialInfo); err != nil {
 		log.CompletedError(err, sessionID, "Startup")
 		return err
 	}
@@ -113,18 +119,12 @@ func Shutdown(sessionID string) error {
 }
 
 // CreateSession creates a connection pool for use.
-func CreateSession(sessionID string, mode string, sessionName string, hosts []string, databaseName string, username string, password string) error {
-	log.Startedf(sessionID, "CreateSession", "Mode[%s] SessionName[%s] Hosts[%s] DatabaseName[%s] Username[%s]", mode, sessionName, hosts, databaseName, username)
+func CreateSession(sessionID string, mode string, sessionName string, dialInfo *mgo.DialInfo) error {
+	log.Startedf(sessionID, "CreateSession", "Mode[%s] SessionName[%s] Hosts[%s] DatabaseName[%s] Username[%s]", mode, sessionName, dialInfo.Addrs, dialInfo.Database, dialInfo.Username)
 
 	// Create the database object
 	mongoSession := mongoSession{
-		mongoDBDialInfo: &mgo.DialInfo{
-			Addrs:    hosts,
-			Timeout:  60 * time.Second,
-			Database: databaseName,
-			Username: username,
-			Password: password,
-		},
+		mongoDBDialInfo: dialInfo,
 	}
 
 	// Establish the master session.
@@ -149,13 +149,25 @@ func CreateSession(sessionID string, mode string, sessionName string, hosts []st
 		// history of changes moving forward, the data read will be consistent
 		// across sequential queries in the same session, and modifications made
 		// within the session will be observed in following queries (read-your-writes).
+		// A configured read preference replaces the monotonic mode.
 		// http://godoc.org/github.com/finapps/mgo#Session.SetMode
-		mongoSession.mongoSession.SetMode(mgo.Monotonic, true)
+		mode, _ := singleton.config.readMode()
+		mongoSession.mongoSession.SetMode(mode, true)
 	}
 
-	// Have the session check for errors.
+	// Apply the configured timeouts, leaving the mgo defaults otherwise.
+	// http://godoc.org/github.com/finapps/mgo#Session.SetSocketTimeout
+	if singleton.config.SocketTimeout > 0 {
+		mongoSession.mongoSession.SetSocketTimeout(singleton.config.SocketTimeout)
+	}
+	if singleton.config.SyncTimeout > 0 {
+		mongoSession.mongoSession.SetSyncTimeout(singleton.config.SyncTimeout)
+	}
+
+	// Have the session check for errors with the configured write concern.
 	// http://godoc.org/github.com/finapps/mgo#Session.SetSafe
-	mongoSession.mongoSession.SetSafe(&mgo.Safe{})
+	safe, _ := singleton.config.safe()
+	mongoSession.mongoSession.SetSafe(safe)
 
 	// Add the database to the map.
 	singleton.sessions[sessionName] = mongoSession
@@ -281,10 +293,26 @@ func ToStringD(queryMap bson.D) string {
 
 // Execute the MongoDB literal function.
 func Execute(sessionID string, mongoSession *mgo.Session, databaseName string, collectionName string, dbCall DBCall) error {
-	log.Startedf(sessionID, "Execute", "Database[%s] Collection[%s]", databaseName, collectionName)
+	return ExecuteWithOptions(sessionID, mongoSession, databaseName, collectionName, DBOptions{}, dbCall)
+}
+
+// ExecuteWithOptions executes the MongoDB literal function with the write concern
+// and read preference of the options instead of those of the session.
+func ExecuteWithOptions(sessionID string, mongoSession *mgo.Session, databaseName string, collectionName string, options DBOptions, dbCall DBCall) error {
+	log.Startedf(sessionID, "Execute", "Database[%s] Collection[%s] Options[%s]", databaseName, collectionName, options)
+
+	// Copy the session if the options change it.
+	optionsSession, err := options.Apply(mongoSession)
+	if err != nil {
+		log.CompletedError(err, sessionID, "Execute")
+		return err
+	}
+	if optionsSession != mongoSession {
+		defer optionsSession.Close()
+	}
 
 	// Capture the specified collection.
-	collection := GetCollection(mongoSession, databaseName, collectionName)
+	collection := GetCollection(optionsSession, databaseName, collectionName)
 	if collection == nil {
 		err := fmt.Errorf("Collection %s does not exist", collectionName)
 		log.CompletedError(err, sessionID, "Execute")
@@ -292,8 +320,7 @@ func Execute(sessionID string, mongoSession *mgo.Session, databaseName string, c
 	}
 
 	// Execute the MongoDB call.
-	err := dbCall(collection)
-	if err != nil {
+	if err := dbCall(collection); err != nil {
 		log.CompletedError(err, sessionID, "Execute")
 		return err
 	}
@@ -0,0 +1,177 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mongo
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type (
+	// WriteConcern is the acknowledgment a write waits for.
+	WriteConcern struct {
+		// W is the number of servers that must acknowledge the write or a mode such as
+		// majority. Zero makes the write unacknowledged and empty means the primary.
+		W string
+		// WTimeout is how long to wait for W before the write fails.
+		WTimeout time.Duration
+		// Journal waits for the write to be journaled.
+		Journal bool
+	}
+
+	// ReadPreference selects the servers a read is sent to.
+	ReadPreference struct {
+		// Mode is primary, primaryPreferred, secondary, secondaryPreferred or nearest.
+		Mode string
+		// TagSets select servers by their replica set tags, in order of preference
+		// (e.g. {{"dc", "east"}}, {{"dc", "west"}}). Empty tag sets match any server.
+		TagSets []bson.D
+	}
+
+	// DBOptions changes the write concern and read preference of the session
+	// for one call. Nil fields keep the settings of the session.
+	DBOptions struct {
+		WriteConcern   *WriteConcern
+		ReadPreference *ReadPreference
+	}
+)
+
+// readPreferences maps the read preference modes to mgo modes.
+var readPreferences = map[string]mgo.Mode{
+	"primary":            mgo.Primary,
+	"primaryPreferred":   mgo.PrimaryPreferred,
+	"secondary":          mgo.Secondary,
+	"secondaryPreferred": mgo.SecondaryPreferred,
+	"nearest":            mgo.Nearest,
+}
+
+var (
+	// FastWrites are acknowledged by the primary alone, which suits
+	// high volume writes that can be repeated such as ingesting readings.
+	FastWrites = DBOptions{WriteConcern: &WriteConcern{W: "1"}}
+
+	// MajorityWrites are acknowledged by a majority of the replica set, so they
+	// survive a failover. Use them for updates that must not be lost.
+	MajorityWrites = DBOptions{WriteConcern: &WriteConcern{W: "majority", WTimeout: 10 * time.Second}}
+
+	// SecondaryReads prefer the secondaries, which takes load off the primary
+	// for reads that may be slightly out of date such as reports.
+	SecondaryReads = DBOptions{ReadPreference: &ReadPreference{Mode: "secondaryPreferred"}}
+)
+
+// Apply returns a copy of the session with the options, which the caller must close,
+// or the session itself when there are no options.
+func (options DBOptions) Apply(mongoSession *mgo.Session) (*mgo.Session, error) {
+	if options.WriteConcern == nil && options.ReadPreference == nil {
+		return mongoSession, nil
+	}
+
+	var safe *mgo.Safe
+	if options.WriteConcern != nil {
+		var err error
+		if safe, err = options.WriteConcern.safe(); err != nil {
+			return nil, err
+		}
+	}
+
+	var mode mgo.Mode
+	if options.ReadPreference != nil {
+		var err error
+		if mode, err = readMode(options.ReadPreference.Mode); err != nil {
+			return nil, err
+		}
+		if mode == mgo.Primary && len(options.ReadPreference.TagSets) > 0 {
+			return nil, fmt.Errorf("Read Preference primary Can Not Have Tag Sets")
+		}
+	}
+
+	mongoSession = mongoSession.Copy()
+
+	if options.WriteConcern != nil {
+		mongoSession.SetSafe(safe)
+	}
+
+	if options.ReadPreference != nil {
+		mongoSession.SetMode(mode, true)
+		mongoSession.SelectServers(options.ReadPreference.TagSets...)
+	}
+
+	return mongoSession, nil
+}
+
+// Merge returns the options with the fields that are set in other.
+func (options DBOptions) Merge(other DBOptions) DBOptions {
+	if other.WriteConcern != nil {
+		options.WriteConcern = other.WriteConcern
+	}
+	if other.ReadPreference != nil {
+		options.ReadPreference = other.ReadPreference
+	}
+
+	return options
+}
+
+// String returns the options for logging.
+func (options DBOptions) String() string {
+	var s string
+	if options.WriteConcern != nil {
+		s = fmt.Sprintf("W[%s] WTimeout[%v] Journal[%v]", options.WriteConcern.W, options.WriteConcern.WTimeout, options.WriteConcern.Journal)
+	}
+	if options.ReadPreference != nil {
+		if s != "" {
+			s += " "
+		}
+		s += fmt.Sprintf("ReadPreference[%s] TagSets%s", options.ReadPreference.Mode, ToString(options.ReadPreference.TagSets))
+	}
+
+	return s
+}
+
+// safe returns the safety mode of the write concern.
+func (writeConcern *WriteConcern) safe() (*mgo.Safe, error) {
+	safe := mgo.Safe{
+		WTimeout: int(writeConcern.WTimeout / time.Millisecond),
+		J:        writeConcern.Journal,
+	}
+
+	if writeConcern.WTimeout < 0 {
+		return nil, fmt.Errorf("Invalid Write Timeout %v", writeConcern.WTimeout)
+	}
+
+	if writeConcern.W == "" {
+		return &safe, nil
+	}
+
+	w, err := strconv.Atoi(writeConcern.W)
+	switch {
+	case err != nil:
+		safe.WMode = writeConcern.W
+	case w < 0:
+		return nil, fmt.Errorf("Invalid Write Concern %s", writeConcern.W)
+	case w == 0:
+		if writeConcern.Journal {
+			return nil, fmt.Errorf("Unacknowledged Writes Can Not Be Journaled")
+		}
+		return nil, nil
+	default:
+		safe.W = w
+	}
+
+	return &safe, nil
+}
+
+// readMode returns the mgo mode of a read preference mode.
+func readMode(readPreference string) (mgo.Mode, error) {
+	mode, ok := readPreferences[readPreference]
+	if ok == false {
+		return 0, fmt.Errorf("Unsupported Read Preference %s", readPreference)
+	}
+
+	return mode, nil
+}
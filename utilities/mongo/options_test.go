@@ -0,0 +1,46 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mongo
+
+import (
+	"testing"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// TestDBOptionsMerge checks later options replace the fields they set.
+func TestDBOptionsMerge(t *testing.T) {
+	tagged := DBOptions{ReadPreference: &ReadPreference{Mode: "nearest", TagSets: []bson.D{{{Name: "dc", Value: "east"}}}}}
+
+	options := DBOptions{}.Merge(SecondaryReads).Merge(MajorityWrites).Merge(tagged)
+	if options.WriteConcern != MajorityWrites.WriteConcern || options.ReadPreference != tagged.ReadPreference {
+		t.Errorf("Merge returned %s; expected majority writes and nearest reads", options)
+	}
+
+	expected := `W[majority] WTimeout[10s] Journal[false] ReadPreference[nearest] TagSets[[{"Name":"dc","Value":"east"}]]`
+	if s := options.String(); s != expected {
+		t.Errorf("String returned %s; expected %s", s, expected)
+	}
+}
+
+// TestDBOptionsApply checks invalid options are reported before the session is used.
+func TestDBOptionsApply(t *testing.T) {
+	if session, err := (DBOptions{}).Apply(nil); session != nil || err != nil {
+		t.Errorf("Apply without options returned %v, %v; expected the session", session, err)
+	}
+
+	tests := []DBOptions{
+		{WriteConcern: &WriteConcern{W: "-2"}},
+		{WriteConcern: &WriteConcern{W: "0", Journal: true}},
+		{ReadPreference: &ReadPreference{Mode: "secondaryOnly"}},
+		{ReadPreference: &ReadPreference{Mode: "primary", TagSets: []bson.D{{{Name: "dc", Value: "east"}}}}},
+	}
+
+	for _, options := range tests {
+		if _, err := options.Apply(nil); err == nil {
+			t.Errorf("Apply of %s returned nil; expected error", options)
+		}
+	}
+}
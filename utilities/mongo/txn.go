@@ -0,0 +1,111 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mongo
+
+import (
+	"fmt"
+
+	log "github.com/goinggo/tracelog"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const (
+	// TxnCollection contains the transactions of a database.
+	TxnCollection = "txns"
+
+	// TxnAttempts is how many times a transaction is attempted
+	// when its assertions fail because of concurrent changes.
+	TxnAttempts = 3
+)
+
+type (
+	// TxnCall defines a type of function that builds the operations of a transaction.
+	// It may read the collections of the database to decide on the operations, and is
+	// called again to build new operations when the transaction aborts because the
+	// assertions of its operations failed.
+	//
+	// Documents changed by transactions must only be changed by transactions,
+	// since the transactions keep their state in the documents.
+	TxnCall func(*mgo.Database) ([]txn.Op, error)
+
+	// txnRunFunc runs the operations of a transaction.
+	txnRunFunc func(ops []txn.Op, id bson.ObjectId) error
+)
+
+// ErrTxnConflict is returned when a transaction is still aborted after TxnAttempts.
+var ErrTxnConflict = fmt.Errorf("Transaction Aborted By Concurrent Changes")
+
+// ExecuteTxn applies the operations of the transaction function atomically across
+// the collections of the database, using the two-phase commit of the mgo/txn runner.
+// The transaction is retried when its assertions fail, and ErrTxnConflict is returned
+// when they still fail after TxnAttempts.
+func ExecuteTxn(sessionID string, mongoSession *mgo.Session, databaseName string, txnCall TxnCall) error {
+	log.Startedf(sessionID, "ExecuteTxn", "Database[%s]", databaseName)
+
+	// Transactions must see the latest state of the documents.
+	txnSession := mongoSession.Copy()
+	defer txnSession.Close()
+	txnSession.SetMode(mgo.Strong, true)
+
+	database := txnSession.DB(databaseName)
+	runner := txn.NewRunner(database.C(TxnCollection))
+	run := func(ops []txn.Op, id bson.ObjectId) error {
+		return runner.Run(ops, id, nil)
+	}
+
+	if err := executeTxn(sessionID, database, run, txnCall); err != nil {
+		log.CompletedError(err, sessionID, "ExecuteTxn")
+		return err
+	}
+
+	log.Completed(sessionID, "ExecuteTxn")
+	return nil
+}
+
+// ResumeTxns completes the transactions of the database that were pending when
+// a process stopped, e.g. after a crash. It should be called at startup.
+func ResumeTxns(sessionID string, mongoSession *mgo.Session, databaseName string) error {
+	log.Startedf(sessionID, "ResumeTxns", "Database[%s]", databaseName)
+
+	txnSession := mongoSession.Copy()
+	defer txnSession.Close()
+	txnSession.SetMode(mgo.Strong, true)
+
+	runner := txn.NewRunner(txnSession.DB(databaseName).C(TxnCollection))
+
+	log.Trace(sessionID, "ResumeTxns", "MGO : db.%s.find({s: {$in: [preparing, prepared, applying]}})", TxnCollection)
+	if err := runner.ResumeAll(); err != nil {
+		log.CompletedError(err, sessionID, "ResumeTxns")
+		return err
+	}
+
+	log.Completed(sessionID, "ResumeTxns")
+	return nil
+}
+
+// executeTxn builds and runs the operations of the transaction until it is
+// not aborted or has been attempted TxnAttempts times.
+func executeTxn(sessionID string, database *mgo.Database, run txnRunFunc, txnCall TxnCall) error {
+	for attempt := 1; attempt <= TxnAttempts; attempt++ {
+		ops, err := txnCall(database)
+		if err != nil {
+			return err
+		}
+
+		id := bson.NewObjectId()
+		log.Trace(sessionID, "executeTxn", "MGO : Txn[%s] Attempt[%d] Ops%s", id.Hex(), attempt, ToString(ops))
+
+		err = run(ops, id)
+		if err != txn.ErrAborted {
+			return err
+		}
+
+		log.Warning(sessionID, "executeTxn", "Txn[%s] Attempt[%d] : Aborted", id.Hex(), attempt)
+	}
+
+	return ErrTxnConflict
+}
@@ -0,0 +1,67 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mongo
+
+import (
+	"fmt"
+	"testing"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// TestExecuteTxn checks aborted transactions are built again and retried.
+func TestExecuteTxn(t *testing.T) {
+	errRun := fmt.Errorf("connection reset")
+	errCall := fmt.Errorf("station not found")
+
+	tests := []struct {
+		results  []error
+		callErr  error
+		err      error
+		attempts int
+	}{
+		{[]error{nil}, nil, nil, 1},
+		{[]error{txn.ErrAborted, nil}, nil, nil, 2},
+		{[]error{txn.ErrAborted, txn.ErrAborted, txn.ErrAborted}, nil, ErrTxnConflict, TxnAttempts},
+		{[]error{errRun}, nil, errRun, 1},
+		{nil, errCall, errCall, 0},
+	}
+
+	for _, test := range tests {
+		calls := 0
+		attempts := 0
+		var ids []bson.ObjectId
+
+		txnCall := func(database *mgo.Database) ([]txn.Op, error) {
+			calls++
+			if test.callErr != nil {
+				return nil, test.callErr
+			}
+			return []txn.Op{{C: "buoy_stations", Id: calls, Assert: txn.DocExists}}, nil
+		}
+
+		run := func(ops []txn.Op, id bson.ObjectId) error {
+			if ops[0].Id != calls {
+				t.Errorf("attempt %d ran the operations of call %v", attempts+1, ops[0].Id)
+			}
+			ids = append(ids, id)
+			err := test.results[attempts]
+			attempts++
+			return err
+		}
+
+		if err := executeTxn("testing", nil, run, txnCall); err != test.err {
+			t.Errorf("executeTxn with results %v returned %v; expected %v", test.results, err, test.err)
+		}
+		if attempts != test.attempts {
+			t.Errorf("executeTxn with results %v made %d attempts; expected %d", test.results, attempts, test.attempts)
+		}
+		if len(ids) == 2 && ids[0] == ids[1] {
+			t.Errorf("executeTxn reused the transaction id %s", ids[0].Hex())
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mongo
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// uriOptions applies the options of a connection string, keyed by their lower case name.
+var uriOptions = map[string]func(config *mongoConfiguration, value string) error{
+	"replicaset": func(config *mongoConfiguration, value string) error {
+		config.ReplicaSet = value
+		return nil
+	},
+	"authsource": func(config *mongoConfiguration, value string) error {
+		config.AuthSource = value
+		return nil
+	},
+	"authmechanism": func(config *mongoConfiguration, value string) error {
+		config.Mechanism = value
+		return nil
+	},
+	"connect": func(config *mongoConfiguration, value string) error {
+		switch value {
+		case "direct":
+			config.Direct = true
+		case "replicaSet", "automatic":
+			config.Direct = false
+		default:
+			return fmt.Errorf("must be direct, replicaSet or automatic")
+		}
+		return nil
+	},
+	"maxpoolsize": func(config *mongoConfiguration, value string) (err error) {
+		config.PoolLimit, err = strconv.Atoi(value)
+		return err
+	},
+	"connecttimeoutms": func(config *mongoConfiguration, value string) (err error) {
+		config.Timeout, err = parseMilliseconds(value)
+		return err
+	},
+	"sockettimeoutms": func(config *mongoConfiguration, value string) (err error) {
+		config.SocketTimeout, err = parseMilliseconds(value)
+		return err
+	},
+	"serverselectiontimeoutms": func(config *mongoConfiguration, value string) (err error) {
+		config.SyncTimeout, err = parseMilliseconds(value)
+		return err
+	},
+	"ssl": func(config *mongoConfiguration, value string) (err error) {
+		config.TLS, err = strconv.ParseBool(value)
+		return err
+	},
+	"tls": func(config *mongoConfiguration, value string) (err error) {
+		config.TLS, err = strconv.ParseBool(value)
+		return err
+	},
+	"tlscafile": func(config *mongoConfiguration, value string) error {
+		config.TLSCAFile = value
+		return nil
+	},
+	"tlscertificatekeyfile": func(config *mongoConfiguration, value string) error {
+		config.TLSCertFile = value
+		return nil
+	},
+	"tlsinsecure": func(config *mongoConfiguration, value string) (err error) {
+		config.TLSInsecureSkipVerify, err = strconv.ParseBool(value)
+		return err
+	},
+	"readpreference": func(config *mongoConfiguration, value string) error {
+		config.ReadPreference = value
+		return nil
+	},
+	"w": func(config *mongoConfiguration, value string) error {
+		config.W = value
+		return nil
+	},
+	"wtimeoutms": func(config *mongoConfiguration, value string) (err error) {
+		config.WTimeout, err = parseMilliseconds(value)
+		return err
+	},
+	"journal": func(config *mongoConfiguration, value string) (err error) {
+		config.Journal, err = strconv.ParseBool(value)
+		return err
+	},
+}
+
+// ApplyURI parses the mongodb:// connection string of the configuration, if there is one,
+// and replaces the settings it contains. The format is documented at
+// https://docs.mongodb.com/manual/reference/connection-string/
+//
+//	mongodb://[username:password@]host1[:port1][,host2[:port2],...][/[database][?options]]
+func (config *mongoConfiguration) ApplyURI() error {
+	if config.URI == "" {
+		return nil
+	}
+
+	// The message of a url.Error contains the connection string, which may contain a password.
+	uri, err := url.Parse(config.URI)
+	if err != nil {
+		return fmt.Errorf("Invalid MGO_URI : Unable To Parse The Connection String")
+	}
+
+	switch uri.Scheme {
+	case "mongodb":
+	case "mongodb+srv":
+		return fmt.Errorf("Invalid MGO_URI : mongodb+srv Is Not Supported, List The Hosts With mongodb://")
+	default:
+		return fmt.Errorf("Invalid MGO_URI : Scheme Must Be mongodb Not %q", uri.Scheme)
+	}
+
+	if uri.Host == "" {
+		return fmt.Errorf("Invalid MGO_URI : Missing Hosts")
+	}
+	for _, host := range strings.Split(uri.Host, ",") {
+		if host == "" || strings.HasPrefix(host, ":") {
+			return fmt.Errorf("Invalid MGO_URI : Invalid Host %q", host)
+		}
+	}
+	config.Hosts = uri.Host
+
+	if uri.User != nil {
+		config.UserName = uri.User.Username()
+		config.Password, _ = uri.User.Password()
+	}
+
+	if database := strings.TrimPrefix(uri.Path, "/"); database != "" {
+		if strings.ContainsAny(database, "/\\. \"$") {
+			return fmt.Errorf("Invalid MGO_URI : Invalid Database %q", database)
+		}
+		config.Database = database
+	}
+
+	options, err := url.ParseQuery(uri.RawQuery)
+	if err != nil {
+		return fmt.Errorf("Invalid MGO_URI : Unable To Parse The Options : %v", err)
+	}
+
+	for name, values := range options {
+		apply, ok := uriOptions[strings.ToLower(name)]
+		if ok == false {
+			return fmt.Errorf("Invalid MGO_URI : Unsupported Option %s", name)
+		}
+
+		value := values[len(values)-1]
+		if err := apply(config, value); err != nil {
+			return fmt.Errorf("Invalid MGO_URI : Invalid Option %s=%s : %v", name, value, err)
+		}
+	}
+
+	return nil
+}
+
+// parseMilliseconds parses the milliseconds of a timeout option.
+func parseMilliseconds(value string) (time.Duration, error) {
+	ms, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if ms < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+
+	return time.Duration(ms) * time.Millisecond, nil
+}
@@ -0,0 +1,309 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package websocket implements the server side of the WebSocket protocol (RFC 6455)
+// needed to push messages to browsers. Fragmented and binary messages sent by
+// clients are not supported, since browsers only send text and control frames to us.
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+//** CONSTANTS
+
+const (
+	// The opcodes of the frames.
+	TextMessage  = 1
+	CloseMessage = 8
+	PingMessage  = 9
+	PongMessage  = 10
+
+	// acceptGUID is appended to the key of the handshake to compute the accept header.
+	acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+	// maxPayload is the largest message a client may send.
+	maxPayload = 64 * 1024
+
+	// writeTimeout is how long a write may block on a slow client.
+	writeTimeout = 10 * time.Second
+)
+
+//** TYPES
+
+// Conn is an upgraded WebSocket connection. Writes may be called
+// concurrently with ReadMessage.
+type Conn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	writeLock sync.Mutex
+	closed    bool
+}
+
+//** PACKAGE VARIABLES
+
+var (
+	// ErrNotWebSocket is returned when the request is not a WebSocket handshake.
+	ErrNotWebSocket = fmt.Errorf("Not A WebSocket Handshake")
+
+	// ErrOriginNotAllowed is returned when the page that opened the WebSocket is on another site.
+	ErrOriginNotAllowed = fmt.Errorf("Origin Not Allowed")
+)
+
+//** PUBLIC FUNCTIONS
+
+// Upgrade completes the handshake of the request and hijacks its connection.
+// Nothing must have been written to the response before.
+//
+// Browsers send cookies with WebSocket handshakes from any site, so the Origin header
+// must be the host of the request or one of allowedOrigins (e.g. https://www.goinggo.net).
+// Requests without an Origin header do not come from browsers and are allowed.
+func Upgrade(w http.ResponseWriter, r *http.Request, allowedOrigins []string) (*Conn, error) {
+	key := r.Header.Get("Sec-Websocket-Key")
+	if r.Method != "GET" ||
+		headerContains(r.Header, "Connection", "upgrade") == false ||
+		headerContains(r.Header, "Upgrade", "websocket") == false ||
+		r.Header.Get("Sec-Websocket-Version") != "13" ||
+		key == "" {
+		http.Error(w, ErrNotWebSocket.Error(), http.StatusBadRequest)
+		return nil, ErrNotWebSocket
+	}
+
+	if originAllowed(r, allowedOrigins) == false {
+		http.Error(w, ErrOriginNotAllowed.Error(), http.StatusForbidden)
+		return nil, ErrOriginNotAllowed
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if ok == false {
+		http.Error(w, "WebSocket Not Supported", http.StatusInternalServerError)
+		return nil, fmt.Errorf("Response Does Not Support Hijacking")
+	}
+
+	netConn, buf, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + AcceptKey(key) + "\r\n\r\n"
+
+	netConn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := netConn.Write([]byte(response)); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	return &Conn{conn: netConn, reader: buf.Reader}, nil
+}
+
+// AcceptKey returns the Sec-WebSocket-Accept header for the key of a handshake.
+func AcceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// WriteText sends a text message.
+func (c *Conn) WriteText(data []byte) error {
+	return c.writeFrame(TextMessage, data)
+}
+
+// WriteJSON sends the value as a JSON text message.
+func (c *Conn) WriteJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return c.writeFrame(TextMessage, data)
+}
+
+// WritePing sends a ping, which the browser answers with a pong.
+func (c *Conn) WritePing() error {
+	return c.writeFrame(PingMessage, nil)
+}
+
+// ReadMessage returns the next text message of the client. Pings are answered
+// and pongs skipped. It returns io.EOF once the client closed the connection.
+func (c *Conn) ReadMessage() ([]byte, error) {
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case TextMessage:
+			return payload, nil
+
+		case PingMessage:
+			if err := c.writeFrame(PongMessage, payload); err != nil {
+				return nil, err
+			}
+
+		case PongMessage:
+
+		case CloseMessage:
+			c.writeFrame(CloseMessage, payload)
+			c.Close()
+			return nil, io.EOF
+
+		default:
+			c.Close()
+			return nil, fmt.Errorf("Unsupported WebSocket Opcode %d", opcode)
+		}
+	}
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+	return c.conn.Close()
+}
+
+//** PRIVATE FUNCTIONS
+
+// originAllowed checks the Origin header of the request against its host and the allowed origins.
+func originAllowed(r *http.Request, allowedOrigins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	// Sandboxed pages and local files send the origin null, which has no host.
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+
+	for _, allowed := range allowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), u.Scheme+"://"+u.Host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// headerContains checks if the comma separated values of the header contain the token.
+func headerContains(header http.Header, name string, token string) bool {
+	for _, value := range header[name] {
+		for _, v := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(v), token) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// writeFrame writes an unmasked final frame, as servers must.
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if c.closed {
+		return io.ErrClosedPipe
+	}
+
+	frame := make([]byte, 0, len(payload)+10)
+	frame = append(frame, 0x80|opcode)
+
+	switch length := len(payload); {
+	case length < 126:
+		frame = append(frame, byte(length))
+	case length <= 0xFFFF:
+		frame = append(frame, 126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(length))
+	default:
+		frame = append(frame, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(length))
+	}
+	frame = append(frame, payload...)
+
+	c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// readFrame reads a masked final frame, as clients must send.
+func (c *Conn) readFrame() (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return 0, nil, err
+	}
+
+	final := header[0]&0x80 != 0
+	opcode := header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+
+	if final == false {
+		return 0, nil, fmt.Errorf("Fragmented WebSocket Messages Are Not Supported")
+	}
+	if masked == false {
+		return 0, nil, fmt.Errorf("WebSocket Client Frames Must Be Masked")
+	}
+
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+
+	if length > maxPayload {
+		return 0, nil, fmt.Errorf("WebSocket Message Of %d Bytes Is Too Large", length)
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		return 0, nil, err
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return opcode, payload, nil
+}
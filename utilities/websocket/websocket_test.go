@@ -0,0 +1,143 @@
+// Copyright 2013 Ardan Studios. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAcceptKey(t *testing.T) {
+	// The example of RFC 6455 section 1.3.
+	if accept := AcceptKey("dGhlIHNhbXBsZSBub25jZQ=="); accept != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("AcceptKey() = %q", accept)
+	}
+}
+
+func TestUpgradeRejectsPlainRequests(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/buoy/socket", nil)
+
+	if _, err := Upgrade(w, r, nil); err != ErrNotWebSocket {
+		t.Errorf("Upgrade() error = %v; expected %v", err, ErrNotWebSocket)
+	}
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Upgrade() status = %d; expected %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestUpgradeChecksOrigin(t *testing.T) {
+	allowedOrigins := []string{"https://www.goinggo.net/"}
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"", true},
+		{"http://localhost:9003", true},
+		{"https://www.goinggo.net", true},
+		{"HTTPS://WWW.GOINGGO.NET", true},
+		{"http://www.goinggo.net", false},
+		{"https://evil.example.com", false},
+		{"https://localhost:9003.example.com", false},
+		{"null", false},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "http://localhost:9003/buoy/socket", nil)
+		r.Header.Set("Connection", "Upgrade")
+		r.Header.Set("Upgrade", "websocket")
+		r.Header.Set("Sec-WebSocket-Version", "13")
+		r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		if test.origin != "" {
+			r.Header.Set("Origin", test.origin)
+		}
+
+		// The recorder can not be hijacked, so allowed handshakes fail after the origin check.
+		_, err := Upgrade(w, r, allowedOrigins)
+		if test.allowed && err == ErrOriginNotAllowed {
+			t.Errorf("Origin[%s] Upgrade() error = %v; expected the origin to be allowed", test.origin, err)
+		}
+		if test.allowed == false && (err != ErrOriginNotAllowed || w.Code != http.StatusForbidden) {
+			t.Errorf("Origin[%s] Upgrade() error = %v status = %d; expected %v %d", test.origin, err, w.Code, ErrOriginNotAllowed, http.StatusForbidden)
+		}
+	}
+}
+
+func TestEcho(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			conn.WriteText([]byte(strings.ToUpper(string(message))))
+		}
+	}))
+	defer server.Close()
+
+	client, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	handshake := "GET / HTTP/1.1\r\nHost: localhost\r\nOrigin: http://localhost\r\nConnection: keep-alive, Upgrade\r\nUpgrade: websocket\r\n" +
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n\r\n"
+	if _, err := client.Write([]byte(handshake)); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := bufio.NewReader(client)
+	response, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.StatusCode != http.StatusSwitchingProtocols || response.Header.Get("Sec-Websocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("handshake response = %d %v", response.StatusCode, response.Header)
+	}
+
+	// A masked text frame with the payload "region".
+	mask := []byte{1, 2, 3, 4}
+	frame := []byte{0x81, 0x80 | 6}
+	frame = append(frame, mask...)
+	for i, b := range []byte("region") {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := client.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+
+	reply := make([]byte, 8)
+	if _, err := io.ReadFull(reader, reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply[0] != 0x81 || reply[1] != 6 || string(reply[2:]) != "REGION" {
+		t.Errorf("reply = %v; expected a text frame with REGION", reply)
+	}
+
+	// A masked close frame is echoed.
+	if _, err := client.Write([]byte{0x88, 0x80, 0, 0, 0, 0}); err != nil {
+		t.Fatal(err)
+	}
+	closing := make([]byte, 2)
+	if _, err := io.ReadFull(reader, closing); err != nil {
+		t.Fatal(err)
+	}
+	if closing[0] != 0x88 {
+		t.Errorf("close reply = %v; expected a close frame", closing)
+	}
+}
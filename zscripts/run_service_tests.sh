@@ -3,6 +3,8 @@ export MGO_DATABASE=goinggo
 export MGO_USERNAME=guest
 export MGO_PASSWORD=welcome
 export BUOY_DATABASE=goinggo
+export TRANSLATION_DATABASE=goinggo
+export MIGRATION_DATABASE=goinggo
 
 cd $GOPATH/src/github.com/goinggo/beego-mgo/test/serviceTests
 go test -v
\ No newline at end of file
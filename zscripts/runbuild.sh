@@ -3,6 +3,12 @@ export MGO_DATABASE=goinggo
 export MGO_USERNAME=guest
 export MGO_PASSWORD=welcome
 export BUOY_DATABASE=goinggo
+export BUOY_WATCH=events
+export BUOY_CACHESIZE=1000
+export BUOY_CACHETTL=1m
+export BUOY_CACHENOTFOUNDTTL=5s
+export TRANSLATION_DATABASE=goinggo
+export MIGRATION_DATABASE=goinggo
 
 cd $GOPATH/src/github.com/goinggo/beego-mgo
 go clean -i
@@ -0,0 +1,10 @@
+export MGO_HOSTS=ds035428.mongolab.com:35428
+export MGO_DATABASE=goinggo
+export MGO_USERNAME=guest
+export MGO_PASSWORD=welcome
+export BUOY_DATABASE=goinggo
+export TRANSLATION_DATABASE=goinggo
+export MIGRATION_DATABASE=goinggo
+
+cd $GOPATH/src/github.com/goinggo/beego-mgo/migrations/migrate
+go run main.go "$@"
\ No newline at end of file